@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	AdaptiveWindow      = 5 * time.Minute
+	AdaptiveReactions   = 20
+	AdaptiveScan        = 10
+	AdaptiveStep        = 0.05
+	AdaptiveMin         = 0.75
+	AdaptiveMax         = 1.25
+	TargetDeathsPerMin  = 1.0
+	TargetReactionCells = 4.0
+	AdaptiveDeathWeight = 0.15
+	AdaptiveReactWeight = 0.05
+)
+
+type Adaptive struct {
+	Factor    float64
+	Since     time.Time
+	Deaths    []time.Time
+	Reactions []int
+}
+
+func NewAdaptive() Adaptive {
+	return Adaptive{Factor: 1, Since: time.Now()}
+}
+
+func (g *Game) AdaptiveActive() bool {
+	return g.Settings.AdaptiveDifficulty && g.Mode.IsRanked() && g.Mode != ModeWeekly
+}
+
+func (g *Game) SubscribeAdaptive() {
+	g.Events.Subscribe(EventDeath, func(e Event) {
+		g.Adaptive.Deaths = append(g.Adaptive.Deaths, time.Now())
+		g.AdjustDifficulty()
+	})
+	g.Events.Subscribe(EventLevelUp, func(e Event) {
+		g.AdjustDifficulty()
+	})
+}
+
+func (g *Game) RecordReaction(from Direction) {
+	if !g.AdaptiveActive() || g.Snake.Body.Len() == 0 {
+		return
+	}
+
+	p := g.Snake.Body.Head()
+	for i := 1; i <= AdaptiveScan; i++ {
+		p = g.WrapWalls(p.Move(from))
+		if g.IsDeadly(p) {
+			g.Adaptive.Reactions = append(g.Adaptive.Reactions, i)
+			break
+		}
+	}
+	if len(g.Adaptive.Reactions) > AdaptiveReactions {
+		g.Adaptive.Reactions = g.Adaptive.Reactions[len(g.Adaptive.Reactions)-AdaptiveReactions:]
+	}
+}
+
+func (a *Adaptive) DeathsPerMinute() float64 {
+	cutoff := time.Now().Add(-AdaptiveWindow)
+	recent := a.Deaths[:0]
+	for _, t := range a.Deaths {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	a.Deaths = recent
+
+	elapsed := min(AdaptiveWindow, time.Since(a.Since))
+	return float64(len(a.Deaths)) / max(1, elapsed.Minutes())
+}
+
+func (a *Adaptive) AverageReaction() float64 {
+	if len(a.Reactions) == 0 {
+		return TargetReactionCells
+	}
+	total := 0
+	for _, r := range a.Reactions {
+		total += r
+	}
+	return float64(total) / float64(len(a.Reactions))
+}
+
+func (g *Game) AdjustDifficulty() {
+	if !g.AdaptiveActive() {
+		return
+	}
+
+	a := &g.Adaptive
+	desired := 1 -
+		AdaptiveDeathWeight*(a.DeathsPerMinute()-TargetDeathsPerMin) +
+		AdaptiveReactWeight*(a.AverageReaction()-TargetReactionCells)
+	desired = min(AdaptiveMax, max(AdaptiveMin, desired))
+
+	switch {
+	case desired > a.Factor+AdaptiveStep:
+		a.Factor += AdaptiveStep
+	case desired < a.Factor-AdaptiveStep:
+		a.Factor -= AdaptiveStep
+	default:
+		a.Factor = desired
+	}
+	g.ApplySpeed()
+}
+
+func (g *Game) DifficultyFactor() float64 {
+	if !g.AdaptiveActive() {
+		return 1
+	}
+	return g.Adaptive.Factor
+}
+
+func (g *Game) AdaptiveDebugLine() string {
+	if !g.AdaptiveActive() {
+		return " adaptativo: desligado "
+	}
+	return fmt.Sprintf(" adaptativo: x%.2f (%.1f mortes/min, reacao %.1f) ",
+		g.Adaptive.Factor, g.Adaptive.DeathsPerMinute(), g.Adaptive.AverageReaction())
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	ExpertShortcutBuffer = 3
+	DemoRestartTicks     = 20
+)
+
+var botControllers = map[string]func() Controller{
+	"guloso":       func() Controller { return GreedyController{} },
+	"aleatorio":    func() Controller { return RandomController{} },
+	"cauteloso":    func() Controller { return CautiousController{} },
+	"especialista": func() Controller { return &ExpertController{} },
+}
+
+type RandomController struct{}
+
+func (RandomController) Direction(g *Game, s *Snake) Direction {
+	head := s.Body.Head()
+	var safe []Direction
+	for _, direction := range Directions {
+		if direction != s.Direction.Opposite() && !g.IsDeadly(g.WrapWalls(head.Move(direction))) {
+			safe = append(safe, direction)
+		}
+	}
+	if len(safe) == 0 || (g.Rand.Intn(4) != 0 && containsDirection(safe, s.Direction)) {
+		return s.Direction
+	}
+	return safe[g.Rand.Intn(len(safe))]
+}
+
+func containsDirection(directions []Direction, direction Direction) bool {
+	for _, d := range directions {
+		if d == direction {
+			return true
+		}
+	}
+	return false
+}
+
+type CautiousController struct{}
+
+func (CautiousController) Direction(g *Game, s *Snake) Direction {
+	head := s.Body.Head()
+	target := g.NearestFood(head)
+
+	best := s.Direction
+	bestSpace, bestDistance := -1, 0
+	for _, direction := range Directions {
+		if direction == s.Direction.Opposite() {
+			continue
+		}
+
+		next := g.WrapWalls(head.Move(direction))
+		if g.IsDeadly(next) {
+			continue
+		}
+
+		space := g.ReachableArea(next, s.Body.Len()*2)
+		distance := abs(next.X-target.X) + abs(next.Y-target.Y)
+		if space > bestSpace || (space == bestSpace && distance < bestDistance) {
+			best, bestSpace, bestDistance = direction, space, distance
+		}
+	}
+	return best
+}
+
+func (g *Game) ReachableArea(from Point, limit int) int {
+	seen := map[Point]bool{from: true}
+	queue := []Point{from}
+	for len(queue) > 0 && len(seen) < limit {
+		p := queue[0]
+		queue = queue[1:]
+		for _, direction := range Directions {
+			next := g.WrapWalls(p.Move(direction))
+			if !seen[next] && !g.IsDeadly(next) {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return len(seen)
+}
+
+func BotNames() []string {
+	var names []string
+	for name := range botControllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type ExpertController struct {
+	order  map[Point]int
+	bounds [4]int
+}
+
+func HamiltonianCycle(left, top, width, height int) []Point {
+	transpose := false
+	if height%2 != 0 {
+		if width%2 != 0 {
+			return nil
+		}
+		transpose = true
+		width, height = height, width
+	}
+	if width < 2 || height < 2 {
+		return nil
+	}
+
+	var cycle []Point
+	for x := 0; x < width; x++ {
+		cycle = append(cycle, Point{X: x, Y: 0})
+	}
+	for y := 1; y < height; y++ {
+		if y%2 == 1 {
+			for x := width - 1; x >= 1; x-- {
+				cycle = append(cycle, Point{X: x, Y: y})
+			}
+		} else {
+			for x := 1; x < width; x++ {
+				cycle = append(cycle, Point{X: x, Y: y})
+			}
+		}
+	}
+	for y := height - 1; y >= 1; y-- {
+		cycle = append(cycle, Point{X: 0, Y: y})
+	}
+
+	for i, p := range cycle {
+		if transpose {
+			p.X, p.Y = p.Y, p.X
+		}
+		cycle[i] = Point{X: p.X + left, Y: p.Y + top}
+	}
+	return cycle
+}
+
+func (c *ExpertController) prepare(g *Game) {
+	inset := g.Shrink.Rings
+	bounds := [4]int{1 + inset, 1 + inset, g.Width - 2 - 2*inset, g.Height - 2 - 2*inset}
+	if c.order != nil && c.bounds == bounds {
+		return
+	}
+
+	c.bounds = bounds
+	c.order = map[Point]int{}
+	for i, p := range HamiltonianCycle(bounds[0], bounds[1], bounds[2], bounds[3]) {
+		c.order[p] = i
+	}
+}
+
+func (c *ExpertController) Direction(g *Game, s *Snake) Direction {
+	c.prepare(g)
+	head := s.Body.Head()
+	position, ok := c.order[head]
+	if !ok || len(c.order) == 0 {
+		return CautiousController{}.Direction(g, s)
+	}
+
+	total := len(c.order)
+	ahead := func(p Point) int {
+		return (c.order[p] - position + total) % total
+	}
+
+	target := g.NearestFood(head)
+	room := ahead(s.Body.Tail()) - s.Body.Len() - ExpertShortcutBuffer
+	if s.Body.Len() > total/2 {
+		room = 0
+	}
+
+	best, bestStep := DirNone, 0
+	for _, direction := range Directions {
+		next := head.Move(direction)
+		if _, onCycle := c.order[next]; !onCycle || g.IsDeadly(next) {
+			continue
+		}
+
+		step := ahead(next)
+		allowed := step == 1 || (step <= room && step <= ahead(target))
+		if allowed && step > bestStep && g.ReachableArea(next, s.Body.Len()) >= s.Body.Len() {
+			best, bestStep = direction, step
+		}
+	}
+
+	if best == DirNone {
+		return CautiousController{}.Direction(g, s)
+	}
+	return best
+}
+
+func NewController(name string) (Controller, error) {
+	factory, ok := botControllers[name]
+	if !ok {
+		return nil, fmt.Errorf("IA desconhecida: %s (opcoes: %s)", name, strings.Join(BotNames(), ", "))
+	}
+	return factory(), nil
+}
+
+func (g *Game) RivalController() Controller {
+	if controller, err := NewController(g.Settings.RivalAI); err == nil {
+		return controller
+	}
+	return GreedyController{}
+}
+
+func (g *Game) Persists() bool {
+	return !g.Headless && !g.Demo
+}
+
+func (g *Game) StartDemo(controller Controller) {
+	g.Demo = true
+	g.PlayerController = controller
+	g.Reset()
+}
+
+func (g *Game) StopDemo() {
+	g.Demo = false
+	g.PlayerController = nil
+	g.State = StateMenu
+}
+
+func (g *Game) UpdateDemo() {
+	if !g.Demo || g.State != StateGameOver {
+		return
+	}
+	g.DemoWait++
+	if g.DemoWait >= DemoRestartTicks {
+		g.DemoWait = 0
+		g.Reset()
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import "snake/term"
+
+const (
+	AmbienceLevels  = 3
+	RainSpacing     = 3
+	RainDrops       = 2
+	StarDensity     = 40
+	StarDriftFrames = 8
+)
+
+type Ambience interface {
+	Draw(g *Game, setCell CellSetter)
+}
+
+var ambiences = []Ambience{StarsAmbience{}, RainAmbience{}}
+
+func (g *Game) Ambience() Ambience {
+	if !g.Settings.Ambience || g.ReducedEffects() || g.Camera.HalfRows {
+		return nil
+	}
+	return ambiences[(g.Level-1)/AmbienceLevels%len(ambiences)]
+}
+
+func (g *Game) DrawAmbience(setCell CellSetter) {
+	if ambience := g.Ambience(); ambience != nil {
+		ambience.Draw(g, g.ambienceSetter(setCell))
+	}
+}
+
+func (g *Game) ambienceSetter(setCell CellSetter) CellSetter {
+	return func(x, y int, ch rune, fg, bg term.Attribute) {
+		if !g.CheckWallCollision(Point{X: x, Y: y}) {
+			setCell(x, y, ch, fg|term.AttrDim, bg)
+		}
+	}
+}
+
+func ambienceHash(n int) int {
+	n = (n ^ 61) ^ (n >> 16)
+	n *= 9
+	n ^= n >> 4
+	n *= 0x27d4eb2d
+	n ^= n >> 15
+	return n & 0x7fffffff
+}
+
+type RainAmbience struct{}
+
+func (RainAmbience) Draw(g *Game, setCell CellSetter) {
+	for x := 1; x < g.Width-1; x++ {
+		seed := ambienceHash(x)
+		if seed%RainSpacing != 0 {
+			continue
+		}
+		speed := 1 + seed%2
+		for drop := 0; drop < RainDrops; drop++ {
+			y := (g.FrameCount*speed + seed/RainSpacing + drop*g.Height/RainDrops) % g.Height
+			setCell(x, y, '·', term.ColorBlue, term.ColorDefault)
+		}
+	}
+}
+
+type StarsAmbience struct{}
+
+func (StarsAmbience) Draw(g *Game, setCell CellSetter) {
+	inner := g.Width - 2
+	drift := g.FrameCount / StarDriftFrames
+	for y := 1; y < g.Height-1; y++ {
+		for x := 0; x < inner; x++ {
+			if ambienceHash(y*inner+x)%StarDensity != 0 {
+				continue
+			}
+			setCell((x+drift)%inner+1, y, '.', term.ColorWhite, term.ColorDefault)
+		}
+	}
+}
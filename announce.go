@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"snake/term"
+)
+
+type Announcer struct {
+	Last    string
+	Out     io.Writer
+	pending []string
+	food    Point
+	danger  int
+}
+
+func (g *Game) Announcing() bool {
+	return g.Settings.Announcements || g.Announcer.Out != nil
+}
+
+func (g *Game) Announce(format string, args ...any) {
+	g.Announcer.pending = append(g.Announcer.pending, fmt.Sprintf(format, args...))
+}
+
+func (g *Game) FlushAnnouncements() {
+	a := &g.Announcer
+	if len(a.pending) == 0 {
+		return
+	}
+	a.Last = strings.Join(a.pending, ". ")
+	a.pending = nil
+	if a.Out != nil {
+		fmt.Fprintln(a.Out, a.Last)
+	}
+}
+
+func (g *Game) RelativePosition(p Point) string {
+	head := g.FocusPoint()
+	dx, dy := p.X-head.X, p.Y-head.Y
+
+	var parts []string
+	if dy < 0 {
+		parts = append(parts, fmt.Sprintf("%d acima", -dy))
+	} else if dy > 0 {
+		parts = append(parts, fmt.Sprintf("%d abaixo", dy))
+	}
+	if dx > 0 {
+		parts = append(parts, fmt.Sprintf("%d a direita", dx))
+	} else if dx < 0 {
+		parts = append(parts, fmt.Sprintf("%d a esquerda", -dx))
+	}
+	if len(parts) == 0 {
+		return "aqui"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (g *Game) announceTick() {
+	a := &g.Announcer
+	if g.Food.Position != a.food {
+		a.food = g.Food.Position
+		g.Announce("comida: %s", g.RelativePosition(a.food))
+	}
+
+	danger := g.NearestDanger()
+	if danger != a.danger {
+		a.danger = danger
+		switch danger {
+		case 1:
+			g.Announce("perigo a 1 casa")
+		case 0:
+		default:
+			g.Announce("perigo a %d casas", danger)
+		}
+	}
+	g.FlushAnnouncements()
+}
+
+func (g *Game) SubscribeAnnouncements() {
+	if !g.Announcing() {
+		return
+	}
+
+	g.Events.Subscribe(EventGameStart, func(e Event) {
+		g.Announcer = Announcer{Out: g.Announcer.Out, food: Point{X: -1, Y: -1}}
+		g.Announce("partida iniciada, %s, nivel %d", g.Mode, g.Level)
+	})
+	g.Events.Subscribe(EventTick, func(e Event) {
+		if g.State == StatePlaying {
+			g.announceTick()
+		}
+	})
+	g.Events.Subscribe(EventFoodEaten, func(e Event) {
+		g.Announce("mais %d pontos, total %d", e.Points, g.Score)
+	})
+	g.Events.Subscribe(EventLevelUp, func(e Event) {
+		g.Announce("nivel %d", g.Level)
+	})
+	g.Events.Subscribe(EventPowerUpActivated, func(e Event) {
+		g.Announce("power-up ativado")
+	})
+	g.Events.Subscribe(EventRandom, func(e Event) {
+		g.Announce("evento: %s", strings.ToLower(strings.TrimSuffix(randomEvents[e.Name].Banner(), "!")))
+	})
+	g.Events.Subscribe(EventBossSpawned, func(e Event) {
+		g.Announce("chefe apareceu: %s", g.RelativePosition(e.Position))
+	})
+	g.Events.Subscribe(EventBossDefeated, func(e Event) {
+		g.Announce("chefe derrotado")
+	})
+	g.Events.Subscribe(EventDeath, func(e Event) {
+		if g.State == StateGameOver {
+			g.Announce("fim de jogo, %d pontos", g.Score)
+		} else {
+			g.Announce("perdeu uma vida, restam %d", g.Lives)
+		}
+		g.FlushAnnouncements()
+	})
+}
+
+func OpenAnnouncements(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+}
+
+func (g *Game) DrawAnnouncement() {
+	if !g.Announcing() || g.Announcer.Last == "" {
+		return
+	}
+	DrawText(g.Camera.OffsetX+2, g.Camera.OffsetY+g.Camera.ScreenHeight()+1, "Aviso: "+g.Announcer.Last, term.ColorWhite, term.ColorDefault)
+}
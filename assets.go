@@ -0,0 +1,47 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed assets/levels/*.json
+var bundledLevels embed.FS
+
+var (
+	version = "dev"
+	commit  = ""
+)
+
+func VersionString() string {
+	if commit == "" {
+		return "snake " + version
+	}
+	return "snake " + version + " (" + commit + ")"
+}
+
+func BundledLevelNames() []string {
+	entries, err := bundledLevels.ReadDir("assets/levels")
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func LoadBundledLayout(name string) (*Layout, error) {
+	file := path.Join("assets/levels", name+".json")
+	data, err := bundledLevels.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("nivel nao encontrado: %s (veja -list-levels)", name)
+	}
+	return ParseLayout(data, file)
+}
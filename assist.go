@@ -0,0 +1,72 @@
+package main
+
+import (
+	"time"
+
+	"snake/term"
+)
+
+const (
+	BulletTimeTicks    = 6
+	BulletTimeCooldown = 20
+	BulletTimeFactor   = 2
+)
+
+func (g *Game) IsHeadingIntoDanger(s *Snake) bool {
+	if s.Body.Len() == 0 || s.Invulnerable > 0 || g.CollisionsDisabled() {
+		return false
+	}
+	return g.IsDeadly(g.AheadOf(s))
+}
+
+func (g *Game) AheadOf(s *Snake) Point {
+	return g.WrapWalls(s.Body.Head().Move(s.Direction))
+}
+
+func (g *Game) DrawCollisionWarning(setCell CellSetter) {
+	if !g.Settings.CollisionWarning || g.State != StatePlaying {
+		return
+	}
+	for _, s := range g.PlayerSnakes() {
+		if g.IsHeadingIntoDanger(s) {
+			p := g.AheadOf(s)
+			setCell(p.X, p.Y, '!', term.ColorWhite|term.AttrBold, term.ColorRed)
+		}
+	}
+}
+
+func (g *Game) UpdateBulletTime() {
+	if g.BulletTime > 0 {
+		g.BulletTime--
+		if g.BulletTime == 0 {
+			g.BulletTimeCooldown = BulletTimeCooldown
+		}
+		return
+	}
+
+	if g.BulletTimeCooldown > 0 {
+		g.BulletTimeCooldown--
+		return
+	}
+
+	if !g.Settings.BulletTime || g.State != StatePlaying {
+		return
+	}
+
+	for _, s := range g.PlayerSnakes() {
+		if g.IsHeadingIntoDanger(s) {
+			g.BulletTime = BulletTimeTicks
+			return
+		}
+	}
+}
+
+func (g *Game) TickInterval() time.Duration {
+	if g.BulletTime > 0 {
+		return g.Speed * BulletTimeFactor
+	}
+	if g.Boost.Active {
+		return g.Speed / BoostFactor
+	}
+	return g.Speed
+}
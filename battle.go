@@ -0,0 +1,218 @@
+package main
+
+import "snake/term"
+
+const PelletFood FoodType = 100
+
+type Controller interface {
+	Direction(g *Game, s *Snake) Direction
+}
+
+type Rival struct {
+	Snake      Snake
+	Score      int
+	Alive      bool
+	RespawnIn  int
+	Color      term.Attribute
+	Controller Controller
+}
+
+var rivalColors = []term.Attribute{term.ColorMagenta, term.ColorBlue, term.ColorCyan}
+
+type PelletFoodBehavior struct{}
+
+func (PelletFoodBehavior) Weight() int { return 0 }
+
+func (PelletFoodBehavior) OnSpawn(g *Game, f *Food) {}
+
+func (PelletFoodBehavior) OnEaten(g *Game, f *Food) int { return 5 }
+
+func (PelletFoodBehavior) Tick(g *Game, f *Food) {}
+
+func (PelletFoodBehavior) Render(g *Game, f *Food) (rune, term.Attribute) {
+	return '•', term.ColorRed
+}
+
+func init() {
+	RegisterFood(PelletFood, PelletFoodBehavior{})
+}
+
+type GreedyController struct{}
+
+func (GreedyController) Direction(g *Game, s *Snake) Direction {
+	head := s.Body.Head()
+	target := g.NearestFood(head)
+
+	best := s.Direction
+	bestDistance := -1
+	for _, direction := range Directions {
+		if direction == s.Direction.Opposite() {
+			continue
+		}
+
+		next := head.Move(direction)
+		if g.IsDeadly(next) {
+			continue
+		}
+
+		distance := abs(next.X-target.X) + abs(next.Y-target.Y)
+		if bestDistance < 0 || distance < bestDistance {
+			best = direction
+			bestDistance = distance
+		}
+	}
+
+	return best
+}
+
+func (g *Game) SpawnRivals(count int) {
+	for i := 0; i < count; i++ {
+		rival := &Rival{
+			Color:      rivalColors[len(g.Rivals)%len(rivalColors)],
+			Controller: g.RivalController(),
+		}
+		g.Rivals = append(g.Rivals, rival)
+		g.RespawnRival(rival)
+	}
+}
+
+func (g *Game) RespawnRival(r *Rival) {
+	for attempts := 0; attempts < 100; attempts++ {
+		head := Point{
+			X: g.Rand.Intn(g.Width-6) + 3,
+			Y: g.Rand.Intn(g.Height-2) + 1,
+		}
+		body := []Point{head, {X: head.X + 1, Y: head.Y}, {X: head.X + 2, Y: head.Y}}
+
+		safe := true
+		for _, p := range body {
+			if !g.IsPositionSafe(p) {
+				safe = false
+				break
+			}
+		}
+		if safe {
+			r.Snake = Snake{Body: NewSnakeBody(body), Direction: DirLeft}
+			r.Alive = true
+			g.Occupancy.AddBody(LayerRival, &r.Snake.Body)
+			return
+		}
+	}
+	r.RespawnIn = 10
+}
+
+func (g *Game) MoveRivals() {
+	for _, r := range g.Rivals {
+		if !r.Alive {
+			r.RespawnIn--
+			if r.RespawnIn <= 0 {
+				g.RespawnRival(r)
+			}
+			continue
+		}
+
+		r.Snake.Direction = r.Controller.Direction(g, &r.Snake)
+		newHead := r.Snake.Body.Head().Move(r.Snake.Direction)
+
+		if g.IsDeadly(newHead) {
+			g.KillRival(r)
+			continue
+		}
+
+		r.Snake.Body.PushFront(newHead)
+		g.Occupancy.Add(LayerRival, newHead)
+
+		if newHead == g.Food.Position {
+			r.Score += 10
+			g.GenerateFood()
+		} else if _, ok := g.EatPellet(newHead); ok {
+			r.Score += 5
+		} else {
+			g.Occupancy.Remove(LayerRival, r.Snake.Body.PopBack())
+		}
+	}
+}
+
+func (g *Game) KillRival(r *Rival) {
+	r.Alive = false
+	r.RespawnIn = 20
+
+	for i, p := range r.Snake.Body.All() {
+		if i%2 == 0 {
+			g.Pellets = append(g.Pellets, Food{Position: p, Type: PelletFood})
+		}
+	}
+	g.Occupancy.RemoveBody(LayerRival, &r.Snake.Body)
+	r.Snake.Body.Clear()
+}
+
+func (g *Game) CheckRivalCollision(p Point) bool {
+	return g.Occupancy.Has(LayerRival, p)
+}
+
+func (g *Game) PelletAt(p Point) int {
+	for i, pellet := range g.Pellets {
+		if pellet.Position == p {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *Game) EatPellet(p Point) (int, bool) {
+	i := g.PelletAt(p)
+	if i < 0 {
+		return 0, false
+	}
+
+	points := foodBehaviors[g.Pellets[i].Type].OnEaten(g, &g.Pellets[i])
+	g.Pellets = append(g.Pellets[:i], g.Pellets[i+1:]...)
+	return points, true
+}
+
+func (g *Game) NearestFood(from Point) Point {
+	nearest := g.Food.Position
+	bestDistance := abs(from.X-nearest.X) + abs(from.Y-nearest.Y)
+
+	for _, pellet := range g.Pellets {
+		distance := abs(from.X-pellet.Position.X) + abs(from.Y-pellet.Position.Y)
+		if distance < bestDistance {
+			nearest = pellet.Position
+			bestDistance = distance
+		}
+	}
+	return nearest
+}
+
+func (g *Game) BestRivalScore() int {
+	best := 0
+	for _, r := range g.Rivals {
+		best = max(best, r.Score)
+	}
+	return best
+}
+
+func (g *Game) DrawRivals(setCell CellSetter) {
+	for i := range g.Pellets {
+		pellet := &g.Pellets[i]
+		char, color := foodBehaviors[pellet.Type].Render(g, pellet)
+		setCell(pellet.Position.X, pellet.Position.Y, char, color, term.ColorDefault)
+	}
+
+	for _, r := range g.Rivals {
+		for i, chunk := range r.Snake.Body.All() {
+			char := '▒'
+			if i == 0 {
+				char = '◉'
+			}
+			setCell(chunk.X, chunk.Y, char, r.Color, term.ColorDefault)
+		}
+	}
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
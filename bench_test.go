@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func BenchmarkMoveSnake(b *testing.B) {
+	g := newTestGame(b, ModeSandbox)
+	g.Sandbox.CollisionsOff = true
+
+	for i := 0; b.Loop(); i++ {
+		if i%7 == 0 {
+			g.TurnPlayer(Directions[g.Rand.Intn(len(Directions))])
+		}
+		g.MoveSnake()
+	}
+}
+
+func BenchmarkCollision(b *testing.B) {
+	g := newTestGame(b, ModeBattle)
+	g.Level = 10
+	g.GenerateObstacles()
+
+	var cells []Point
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			cells = append(cells, Point{X: x, Y: y})
+		}
+	}
+
+	for b.Loop() {
+		for _, p := range cells {
+			g.IsDeadly(p)
+		}
+	}
+}
+
+func BenchmarkGenerateObstacles(b *testing.B) {
+	g := newTestGame(b, ModeClassic)
+	g.Level = 10
+
+	for b.Loop() {
+		g.GenerateObstacles()
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"unicode"
+
+	"snake/term"
+)
+
+type BindingPreset struct {
+	Name  string
+	Label string
+	Keys  map[rune]Direction
+	Turns map[rune]int
+}
+
+var bindingPresets = []BindingPreset{
+	{Name: "arrows", Label: "Setas"},
+	{Name: "wasd", Label: "WASD", Keys: map[rune]Direction{'w': DirUp, 's': DirDown, 'a': DirLeft, 'd': DirRight}},
+	{Name: "hjkl", Label: "HJKL", Keys: map[rune]Direction{'k': DirUp, 'j': DirDown, 'h': DirLeft, 'l': DirRight}},
+	{Name: "dvorak", Label: ",AOE", Keys: map[rune]Direction{',': DirUp, 'o': DirDown, 'a': DirLeft, 'e': DirRight}},
+	{Name: "ijkl", Label: "IJKL", Keys: map[rune]Direction{'i': DirUp, 'k': DirDown, 'j': DirLeft, 'l': DirRight}},
+	{Name: "numpad", Label: "8456", Keys: map[rune]Direction{'8': DirUp, '5': DirDown, '2': DirDown, '4': DirLeft, '6': DirRight}},
+	{Name: "girar", Label: "Z/X", Turns: map[rune]int{'z': -1, 'x': 1}},
+}
+
+var arrowKeys = map[term.Key]Direction{
+	term.KeyArrowUp:    DirUp,
+	term.KeyArrowDown:  DirDown,
+	term.KeyArrowLeft:  DirLeft,
+	term.KeyArrowRight: DirRight,
+}
+
+func FindBindingPreset(name string) BindingPreset {
+	for _, preset := range bindingPresets {
+		if preset.Name == name {
+			return preset
+		}
+	}
+	return bindingPresets[0]
+}
+
+func IsValidBindingPreset(name string) bool {
+	return FindBindingPreset(name).Name == name
+}
+
+func (p BindingPreset) Direction(ev term.Event) (Direction, bool) {
+	if direction, ok := arrowKeys[ev.Key]; ok {
+		return direction, true
+	}
+	direction, ok := p.Keys[unicode.ToLower(ev.Ch)]
+	return direction, ok
+}
+
+func (p BindingPreset) Turn(ev term.Event) (int, bool) {
+	turn, ok := p.Turns[unicode.ToLower(ev.Ch)]
+	return turn, ok
+}
+
+func (p BindingPreset) Uses(ch rune) bool {
+	ch = unicode.ToLower(ch)
+	_, moves := p.Keys[ch]
+	_, turns := p.Turns[ch]
+	return moves || turns
+}
+
+func (g *Game) IsHelpKey(ev term.Event) bool {
+	if ev.Key == term.KeyF1 {
+		return true
+	}
+	return unicode.ToLower(ev.Ch) == 'h' && !g.Bindings().Uses('h')
+}
+
+func (g *Game) HelpKeyLabel() string {
+	if g.Bindings().Uses('h') {
+		return "F1"
+	}
+	return "H / F1"
+}
+
+func (g *Game) Bindings() BindingPreset {
+	if g.Mode == ModeCoop {
+		return bindingPresets[0]
+	}
+	return FindBindingPreset(g.Settings.Bindings)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"iter"
+)
+
+type SnakeBody struct {
+	points []Point
+	head   int
+	length int
+}
+
+func NewSnakeBody(points []Point) SnakeBody {
+	b := SnakeBody{points: make([]Point, max(4, len(points)))}
+	copy(b.points, points)
+	b.length = len(points)
+	return b
+}
+
+func (b *SnakeBody) Len() int {
+	return b.length
+}
+
+func (b *SnakeBody) At(i int) Point {
+	return b.points[(b.head+i)%len(b.points)]
+}
+
+func (b *SnakeBody) Set(i int, p Point) {
+	b.points[(b.head+i)%len(b.points)] = p
+}
+
+func (b *SnakeBody) Head() Point {
+	return b.At(0)
+}
+
+func (b *SnakeBody) Tail() Point {
+	return b.At(b.length - 1)
+}
+
+func (b *SnakeBody) PushFront(p Point) {
+	if b.length == len(b.points) {
+		b.grow()
+	}
+	b.head = (b.head - 1 + len(b.points)) % len(b.points)
+	b.points[b.head] = p
+	b.length++
+}
+
+func (b *SnakeBody) PopBack() Point {
+	tail := b.Tail()
+	b.length--
+	return tail
+}
+
+func (b *SnakeBody) Clear() {
+	b.head = 0
+	b.length = 0
+}
+
+func (b *SnakeBody) Clone() SnakeBody {
+	return NewSnakeBody(b.Points())
+}
+
+func (b *SnakeBody) Points() []Point {
+	points := make([]Point, b.length)
+	for i := range points {
+		points[i] = b.At(i)
+	}
+	return points
+}
+
+func (b *SnakeBody) All() iter.Seq2[int, Point] {
+	return func(yield func(int, Point) bool) {
+		for i := 0; i < b.length; i++ {
+			if !yield(i, b.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+func (b SnakeBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Points())
+}
+
+func (b *SnakeBody) UnmarshalJSON(data []byte) error {
+	var points []Point
+	if err := json.Unmarshal(data, &points); err != nil {
+		return err
+	}
+	*b = NewSnakeBody(points)
+	return nil
+}
+
+func (b *SnakeBody) grow() {
+	points := make([]Point, max(4, 2*len(b.points)))
+	for i := 0; i < b.length; i++ {
+		points[i] = b.At(i)
+	}
+	b.points = points
+	b.head = 0
+}
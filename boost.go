@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	MaxStamina      = 40
+	StaminaPerFood  = 10
+	BoostFactor     = 2
+	BoostHoldWindow = 600 * time.Millisecond
+	StaminaBarWidth = 10
+)
+
+type Boost struct {
+	Stamina   int
+	HeldUntil time.Time
+	Active    bool
+}
+
+func (g *Game) SubscribeBoost() {
+	g.Events.Subscribe(EventFoodEaten, func(e Event) {
+		g.Boost.Stamina = min(MaxStamina, g.Boost.Stamina+StaminaPerFood)
+	})
+}
+
+func (g *Game) HoldBoost() {
+	if g.VenomMode() {
+		return
+	}
+	g.Boost.HeldUntil = time.Now().Add(BoostHoldWindow)
+}
+
+func (g *Game) UpdateBoost() {
+	g.Boost.Active = g.Boost.Stamina > 0 && time.Now().Before(g.Boost.HeldUntil)
+	if g.Boost.Active {
+		g.Boost.Stamina--
+	}
+}
+
+func (g *Game) StaminaBar() string {
+	filled := g.Boost.Stamina * StaminaBarWidth / MaxStamina
+	return strings.Repeat("█", filled) + strings.Repeat("░", StaminaBarWidth-filled)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"snake/term"
+)
+
+const (
+	BossFood      FoodType = 103
+	BossEvery              = 5
+	BossHealth             = 5
+	BossSize               = 2
+	BossMoveEvery          = 3
+	BossTimeLimit          = 60 * time.Second
+	BossReward             = 200
+)
+
+type Boss struct {
+	Active   bool
+	Position Point
+	Health   int
+	TimeLeft time.Duration
+	Ticks    int
+}
+
+func (b Boss) Contains(p Point) bool {
+	return b.Active && p.X >= b.Position.X && p.X < b.Position.X+BossSize &&
+		p.Y >= b.Position.Y && p.Y < b.Position.Y+BossSize
+}
+
+func (b Boss) Cells() []Point {
+	var cells []Point
+	for dy := 0; dy < BossSize; dy++ {
+		for dx := 0; dx < BossSize; dx++ {
+			cells = append(cells, Point{X: b.Position.X + dx, Y: b.Position.Y + dy})
+		}
+	}
+	return cells
+}
+
+type BossFoodBehavior struct{}
+
+func (BossFoodBehavior) Weight() int { return 0 }
+
+func (BossFoodBehavior) OnSpawn(g *Game, f *Food) {}
+
+func (BossFoodBehavior) OnEaten(g *Game, f *Food) int {
+	g.Boss.Health--
+	if g.Boss.Health > 0 {
+		return 20
+	}
+	g.Boss.Active = false
+	g.Emit(Event{Type: EventBossDefeated, Position: f.Position, Points: BossReward})
+	return 20 + BossReward
+}
+
+func (BossFoodBehavior) Tick(g *Game, f *Food) {}
+
+func (BossFoodBehavior) Render(g *Game, f *Food) (rune, term.Attribute) {
+	return '✦', term.ColorMagenta | term.AttrBold
+}
+
+func init() {
+	RegisterFood(BossFood, BossFoodBehavior{})
+	foodNames[BossFood] = "Arma contra o chefe"
+}
+
+func (g *Game) SubscribeBoss() {
+	g.Events.Subscribe(EventLevelUp, func(e Event) {
+		if g.Level%BossEvery == 0 && g.Mode.IsRanked() && g.Mode != ModeCoop {
+			g.SpawnBoss()
+		}
+	})
+}
+
+func (g *Game) SpawnBoss() {
+	head := g.FocusPoint()
+	corners := []Point{
+		{X: 1, Y: 1},
+		{X: g.Width - 1 - BossSize, Y: 1},
+		{X: 1, Y: g.Height - 1 - BossSize},
+		{X: g.Width - 1 - BossSize, Y: g.Height - 1 - BossSize},
+	}
+
+	distance := func(p Point) int {
+		return abs(p.X-head.X) + abs(p.Y-head.Y)
+	}
+
+	best := corners[0]
+	for _, corner := range corners[1:] {
+		if distance(corner) > distance(best) {
+			best = corner
+		}
+	}
+
+	g.Boss = Boss{
+		Active:   true,
+		Position: best,
+		Health:   BossHealth,
+		TimeLeft: BossTimeLimit,
+	}
+	g.Emit(Event{Type: EventBossSpawned, Position: best})
+	g.GenerateFood()
+}
+
+func (g *Game) UpdateBoss() {
+	if !g.Boss.Active || g.State != StatePlaying {
+		return
+	}
+
+	g.Boss.TimeLeft -= g.TickInterval()
+	if g.Boss.TimeLeft <= 0 {
+		g.Metrics.DeathCause = "o chefe venceu (tempo esgotado)"
+		g.EndGame(g.FocusPoint())
+		return
+	}
+
+	g.Boss.Ticks++
+	if g.Boss.Ticks%BossMoveEvery != 0 {
+		return
+	}
+
+	head := g.FocusPoint()
+	dx, dy := head.X-g.Boss.Position.X, head.Y-g.Boss.Position.Y
+	next := g.Boss.Position
+	if abs(dx) >= abs(dy) {
+		next.X += sign(dx)
+	} else {
+		next.Y += sign(dy)
+	}
+	next.X = clamp(next.X, 1, g.Width-1-BossSize)
+	next.Y = clamp(next.Y, 1, g.Height-1-BossSize)
+	g.Boss.Position = next
+
+	for _, cell := range g.Boss.Cells() {
+		if g.CheckSelfCollision(cell) || g.CheckPartnerCollision(cell) {
+			g.Metrics.DeathCause = "esmagado pelo chefe"
+			g.EndGame(cell)
+			return
+		}
+		if cell == g.Food.Position {
+			g.GenerateFood()
+		}
+	}
+}
+
+func (g *Game) BossHUD() string {
+	health := strings.Repeat("█", g.Boss.Health) + strings.Repeat("░", BossHealth-g.Boss.Health)
+	return fmt.Sprintf("| CHEFE %s %ds ", health, int(g.Boss.TimeLeft.Seconds()))
+}
+
+func (g *Game) DrawBoss(setCell CellSetter) {
+	if !g.Boss.Active {
+		return
+	}
+
+	color := term.ColorRed | term.AttrBold
+	if g.Boss.Health <= 2 && g.Steady(2) {
+		color = term.ColorMagenta
+	}
+
+	glyphs := []rune{'▛', '▜', '▙', '▟'}
+	for i, cell := range g.Boss.Cells() {
+		setCell(cell.X, cell.Y, glyphs[i], color, term.ColorDefault)
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
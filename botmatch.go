@@ -0,0 +1,136 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type BotResult struct {
+	Score    int
+	Survived time.Duration
+	Died     bool
+}
+
+type BotStanding struct {
+	Name     string
+	Games    int
+	Score    int
+	Survived time.Duration
+	Deaths   int
+	Wins     int
+}
+
+func PlayBotGame(controller Controller, mode GameMode, seed int64, maxTicks int) BotResult {
+	game := NewHeadlessGame(mode, seed)
+	game.PlayerController = controller
+
+	for tick := 0; tick < maxTicks && game.State == StatePlaying; tick++ {
+		game.MoveSnake()
+	}
+
+	var survived time.Duration
+	for _, t := range game.Metrics.LevelTime {
+		survived += t
+	}
+	return BotResult{Score: game.Score, Survived: survived, Died: game.State == StateGameOver}
+}
+
+func RunBotMatch(names []string, factories map[string]func() Controller, mode GameMode, games int, seed int64, maxTicks int) []BotStanding {
+	standings := make([]BotStanding, len(names))
+	for i, name := range names {
+		standings[i].Name = name
+	}
+
+	for round := 0; round < games; round++ {
+		results := make([]BotResult, len(names))
+		best := -1
+		for i, name := range names {
+			results[i] = PlayBotGame(factories[name](), mode, seed+int64(round), maxTicks)
+			best = max(best, results[i].Score)
+
+			st := &standings[i]
+			st.Games++
+			st.Score += results[i].Score
+			st.Survived += results[i].Survived
+			if results[i].Died {
+				st.Deaths++
+			}
+		}
+		for i := range names {
+			if results[i].Score == best {
+				standings[i].Wins++
+			}
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].Wins > standings[j].Wins ||
+			(standings[i].Wins == standings[j].Wins && standings[i].Score > standings[j].Score)
+	})
+	return standings
+}
+
+func runBotMatch(args []string) error {
+	fs := flag.NewFlagSet("botmatch", flag.ExitOnError)
+	bots := fs.String("bots", strings.Join(BotNames(), ","), "bots separados por virgula ("+strings.Join(BotNames(), ", ")+" ou grpc:host:porta)")
+	games := fs.Int("games", 10, "partidas por bot")
+	seed := fs.Int64("seed", 1, "seed da primeira partida (as seguintes usam seed+1, seed+2...)")
+	maxTicks := fs.Int("max-ticks", 5000, "limite de passos por partida")
+	modeName := fs.String("mode", "Classico", "modo de jogo")
+	fs.Parse(args)
+
+	mode, ok := ParseMode(*modeName)
+	if !ok {
+		return fmt.Errorf("modo desconhecido: %s (modos: %s)", *modeName, strings.Join(modeNames, ", "))
+	}
+	if *games < 1 || *maxTicks < 1 {
+		return fmt.Errorf("-games e -max-ticks precisam ser positivos")
+	}
+
+	names := strings.Split(*bots, ",")
+	factories := map[string]func() Controller{}
+	var remotes []*RemoteController
+	for _, name := range names {
+		if factory, ok := botControllers[name]; ok {
+			factories[name] = factory
+			continue
+		}
+		if !IsRemoteBot(name) {
+			return fmt.Errorf("bot desconhecido: %s (bots: %s ou grpc:host:porta)", name, strings.Join(BotNames(), ", "))
+		}
+		remote, err := DialRemoteBot(name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		defer remote.Close()
+		remotes = append(remotes, remote)
+		factories[name] = func() Controller { return remote }
+	}
+
+	standings := RunBotMatch(names, factories, mode, *games, *seed, *maxTicks)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tBOT\tMEDIA PTS\tSOBREVIVENCIA\tMORTES\tVITORIAS")
+	for i, st := range standings {
+		fmt.Fprintf(w, "%d\t%s\t%.1f\t%s\t%d/%d\t%.0f%%\n", i+1, st.Name,
+			float64(st.Score)/float64(st.Games),
+			(st.Survived / time.Duration(st.Games)).Round(time.Second),
+			st.Deaths, st.Games,
+			100*float64(st.Wins)/float64(st.Games))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, remote := range remotes {
+		if remote.Errors > 0 {
+			fmt.Printf("grpc:%s ficou sem responder em %d passos (seguiu reto)\n", remote.Address, remote.Errors)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,410 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: bot.proto
+
+package botpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Direction int32
+
+const (
+	Direction_DIRECTION_NONE  Direction = 0
+	Direction_DIRECTION_UP    Direction = 1
+	Direction_DIRECTION_DOWN  Direction = 2
+	Direction_DIRECTION_LEFT  Direction = 3
+	Direction_DIRECTION_RIGHT Direction = 4
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "DIRECTION_NONE",
+		1: "DIRECTION_UP",
+		2: "DIRECTION_DOWN",
+		3: "DIRECTION_LEFT",
+		4: "DIRECTION_RIGHT",
+	}
+	Direction_value = map[string]int32{
+		"DIRECTION_NONE":  0,
+		"DIRECTION_UP":    1,
+		"DIRECTION_DOWN":  2,
+		"DIRECTION_LEFT":  3,
+		"DIRECTION_RIGHT": 4,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_bot_proto_enumTypes[0].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_bot_proto_enumTypes[0]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{0}
+}
+
+type Point struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	mi := &file_bot_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Point) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Point) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type GameState struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Mode      string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	Seed      int64                  `protobuf:"varint,2,opt,name=seed,proto3" json:"seed,omitempty"`
+	Tick      int32                  `protobuf:"varint,3,opt,name=tick,proto3" json:"tick,omitempty"`
+	Width     int32                  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height    int32                  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Score     int32                  `protobuf:"varint,6,opt,name=score,proto3" json:"score,omitempty"`
+	Level     int32                  `protobuf:"varint,7,opt,name=level,proto3" json:"level,omitempty"`
+	Direction Direction              `protobuf:"varint,8,opt,name=direction,proto3,enum=snake.bot.v1.Direction" json:"direction,omitempty"`
+	// Corpo da cobra, da cabeca para a cauda.
+	Snake     []*Point `protobuf:"bytes,9,rep,name=snake,proto3" json:"snake,omitempty"`
+	Food      *Point   `protobuf:"bytes,10,opt,name=food,proto3" json:"food,omitempty"`
+	FoodKind  string   `protobuf:"bytes,11,opt,name=food_kind,json=foodKind,proto3" json:"food_kind,omitempty"`
+	Obstacles []*Point `protobuf:"bytes,12,rep,name=obstacles,proto3" json:"obstacles,omitempty"`
+	// Corpos dos rivais (modo Batalha), um depois do outro.
+	Rivals        []*Point `protobuf:"bytes,13,rep,name=rivals,proto3" json:"rivals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameState) Reset() {
+	*x = GameState{}
+	mi := &file_bot_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameState) ProtoMessage() {}
+
+func (x *GameState) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameState.ProtoReflect.Descriptor instead.
+func (*GameState) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GameState) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *GameState) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *GameState) GetTick() int32 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *GameState) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *GameState) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GameState) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *GameState) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *GameState) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_DIRECTION_NONE
+}
+
+func (x *GameState) GetSnake() []*Point {
+	if x != nil {
+		return x.Snake
+	}
+	return nil
+}
+
+func (x *GameState) GetFood() *Point {
+	if x != nil {
+		return x.Food
+	}
+	return nil
+}
+
+func (x *GameState) GetFoodKind() string {
+	if x != nil {
+		return x.FoodKind
+	}
+	return ""
+}
+
+func (x *GameState) GetObstacles() []*Point {
+	if x != nil {
+		return x.Obstacles
+	}
+	return nil
+}
+
+func (x *GameState) GetRivals() []*Point {
+	if x != nil {
+		return x.Rivals
+	}
+	return nil
+}
+
+type MoveReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Direction     Direction              `protobuf:"varint,1,opt,name=direction,proto3,enum=snake.bot.v1.Direction" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveReply) Reset() {
+	*x = MoveReply{}
+	mi := &file_bot_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveReply) ProtoMessage() {}
+
+func (x *MoveReply) ProtoReflect() protoreflect.Message {
+	mi := &file_bot_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveReply.ProtoReflect.Descriptor instead.
+func (*MoveReply) Descriptor() ([]byte, []int) {
+	return file_bot_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MoveReply) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_DIRECTION_NONE
+}
+
+var File_bot_proto protoreflect.FileDescriptor
+
+const file_bot_proto_rawDesc = "" +
+	"\n" +
+	"\tbot.proto\x12\fsnake.bot.v1\"#\n" +
+	"\x05Point\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\"\xa9\x03\n" +
+	"\tGameState\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x12\n" +
+	"\x04seed\x18\x02 \x01(\x03R\x04seed\x12\x12\n" +
+	"\x04tick\x18\x03 \x01(\x05R\x04tick\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x05 \x01(\x05R\x06height\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x05R\x05score\x12\x14\n" +
+	"\x05level\x18\a \x01(\x05R\x05level\x125\n" +
+	"\tdirection\x18\b \x01(\x0e2\x17.snake.bot.v1.DirectionR\tdirection\x12)\n" +
+	"\x05snake\x18\t \x03(\v2\x13.snake.bot.v1.PointR\x05snake\x12'\n" +
+	"\x04food\x18\n" +
+	" \x01(\v2\x13.snake.bot.v1.PointR\x04food\x12\x1b\n" +
+	"\tfood_kind\x18\v \x01(\tR\bfoodKind\x121\n" +
+	"\tobstacles\x18\f \x03(\v2\x13.snake.bot.v1.PointR\tobstacles\x12+\n" +
+	"\x06rivals\x18\r \x03(\v2\x13.snake.bot.v1.PointR\x06rivals\"B\n" +
+	"\tMoveReply\x125\n" +
+	"\tdirection\x18\x01 \x01(\x0e2\x17.snake.bot.v1.DirectionR\tdirection*n\n" +
+	"\tDirection\x12\x12\n" +
+	"\x0eDIRECTION_NONE\x10\x00\x12\x10\n" +
+	"\fDIRECTION_UP\x10\x01\x12\x12\n" +
+	"\x0eDIRECTION_DOWN\x10\x02\x12\x12\n" +
+	"\x0eDIRECTION_LEFT\x10\x03\x12\x13\n" +
+	"\x0fDIRECTION_RIGHT\x10\x042?\n" +
+	"\x03Bot\x128\n" +
+	"\x04Move\x12\x17.snake.bot.v1.GameState\x1a\x17.snake.bot.v1.MoveReplyB\rZ\vsnake/botpbb\x06proto3"
+
+var (
+	file_bot_proto_rawDescOnce sync.Once
+	file_bot_proto_rawDescData []byte
+)
+
+func file_bot_proto_rawDescGZIP() []byte {
+	file_bot_proto_rawDescOnce.Do(func() {
+		file_bot_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bot_proto_rawDesc), len(file_bot_proto_rawDesc)))
+	})
+	return file_bot_proto_rawDescData
+}
+
+var file_bot_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_bot_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_bot_proto_goTypes = []any{
+	(Direction)(0),    // 0: snake.bot.v1.Direction
+	(*Point)(nil),     // 1: snake.bot.v1.Point
+	(*GameState)(nil), // 2: snake.bot.v1.GameState
+	(*MoveReply)(nil), // 3: snake.bot.v1.MoveReply
+}
+var file_bot_proto_depIdxs = []int32{
+	0, // 0: snake.bot.v1.GameState.direction:type_name -> snake.bot.v1.Direction
+	1, // 1: snake.bot.v1.GameState.snake:type_name -> snake.bot.v1.Point
+	1, // 2: snake.bot.v1.GameState.food:type_name -> snake.bot.v1.Point
+	1, // 3: snake.bot.v1.GameState.obstacles:type_name -> snake.bot.v1.Point
+	1, // 4: snake.bot.v1.GameState.rivals:type_name -> snake.bot.v1.Point
+	0, // 5: snake.bot.v1.MoveReply.direction:type_name -> snake.bot.v1.Direction
+	2, // 6: snake.bot.v1.Bot.Move:input_type -> snake.bot.v1.GameState
+	3, // 7: snake.bot.v1.Bot.Move:output_type -> snake.bot.v1.MoveReply
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_bot_proto_init() }
+func file_bot_proto_init() {
+	if File_bot_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bot_proto_rawDesc), len(file_bot_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bot_proto_goTypes,
+		DependencyIndexes: file_bot_proto_depIdxs,
+		EnumInfos:         file_bot_proto_enumTypes,
+		MessageInfos:      file_bot_proto_msgTypes,
+	}.Build()
+	File_bot_proto = out.File
+	file_bot_proto_goTypes = nil
+	file_bot_proto_depIdxs = nil
+}
@@ -0,0 +1,125 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: bot.proto
+
+package botpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Bot_Move_FullMethodName = "/snake.bot.v1.Bot/Move"
+)
+
+// BotClient is the client API for Bot service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Bot decide a direcao da cobra a cada passo de uma partida do botmatch.
+type BotClient interface {
+	Move(ctx context.Context, in *GameState, opts ...grpc.CallOption) (*MoveReply, error)
+}
+
+type botClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBotClient(cc grpc.ClientConnInterface) BotClient {
+	return &botClient{cc}
+}
+
+func (c *botClient) Move(ctx context.Context, in *GameState, opts ...grpc.CallOption) (*MoveReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveReply)
+	err := c.cc.Invoke(ctx, Bot_Move_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BotServer is the server API for Bot service.
+// All implementations must embed UnimplementedBotServer
+// for forward compatibility.
+//
+// Bot decide a direcao da cobra a cada passo de uma partida do botmatch.
+type BotServer interface {
+	Move(context.Context, *GameState) (*MoveReply, error)
+	mustEmbedUnimplementedBotServer()
+}
+
+// UnimplementedBotServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBotServer struct{}
+
+func (UnimplementedBotServer) Move(context.Context, *GameState) (*MoveReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method Move not implemented")
+}
+func (UnimplementedBotServer) mustEmbedUnimplementedBotServer() {}
+func (UnimplementedBotServer) testEmbeddedByValue()             {}
+
+// UnsafeBotServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BotServer will
+// result in compilation errors.
+type UnsafeBotServer interface {
+	mustEmbedUnimplementedBotServer()
+}
+
+func RegisterBotServer(s grpc.ServiceRegistrar, srv BotServer) {
+	// If the following call panics, it indicates UnimplementedBotServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Bot_ServiceDesc, srv)
+}
+
+func _Bot_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GameState)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BotServer).Move(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bot_Move_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BotServer).Move(ctx, req.(*GameState))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Bot_ServiceDesc is the grpc.ServiceDesc for Bot service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Bot_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "snake.bot.v1.Bot",
+	HandlerType: (*BotServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Move",
+			Handler:    _Bot_Move_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bot.proto",
+}
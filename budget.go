@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"snake/term"
+)
+
+const (
+	RenderWindow    = 40
+	RenderSlowShare = 0.5
+)
+
+type FrameCache struct {
+	cells []term.Cell
+}
+
+func (c *FrameCache) Changed(cells []term.Cell) bool {
+	if c.cells != nil && slices.Equal(cells, c.cells) {
+		return false
+	}
+	c.cells = append(c.cells[:0], cells...)
+	return true
+}
+
+type RenderBudget struct {
+	Frame   FrameCache
+	Skipped int
+	Start   time.Time
+	Last    time.Duration
+	Average time.Duration
+	Window  [RenderWindow]bool
+	Next    int
+	Frames  int
+	Slow    int
+	Reduced bool
+}
+
+func (g *Game) BeginRender() {
+	g.Render.Start = time.Now()
+}
+
+func (g *Game) SkipRender() {
+	g.Render.Start = time.Time{}
+	g.Render.Skipped++
+}
+
+func (g *Game) RecordRender() {
+	b := &g.Render
+	if b.Start.IsZero() {
+		return
+	}
+	b.Last = time.Since(b.Start)
+	b.Start = time.Time{}
+	if b.Average == 0 {
+		b.Average = b.Last
+	} else {
+		b.Average += (b.Last - b.Average) / 8
+	}
+
+	slow := b.Last > g.TickInterval()
+	if b.Window[b.Next] {
+		b.Slow--
+	}
+	if slow {
+		b.Slow++
+	}
+	b.Window[b.Next] = slow
+	b.Next = (b.Next + 1) % RenderWindow
+	b.Frames = min(RenderWindow, b.Frames+1)
+
+	if !b.Reduced && b.Frames == RenderWindow && float64(b.Slow) >= RenderSlowShare*RenderWindow {
+		b.Reduced = true
+		logger.Warn("terminal lento, reduzindo efeitos", "desenho_ms", b.Average.Milliseconds(),
+			"intervalo_ms", g.TickInterval().Milliseconds())
+		g.ShowToast("Terminal lento: efeitos visuais reduzidos", term.ColorYellow)
+	}
+}
+
+func (g *Game) ReducedEffects() bool {
+	return g.Settings.ReducedEffects || g.ReducedMotion() || g.Render.Reduced
+}
+
+func (g *Game) SmoothRender() bool {
+	return g.Settings.SmoothRender && !g.ReducedEffects()
+}
+
+func (g *Game) Blink(period int) bool {
+	return !g.ReducedEffects() && (g.FrameCount/period)%2 == 0
+}
+
+func (g *Game) RenderDebugLine() string {
+	line := fmt.Sprintf(" desenho: %v (media %v, lentos %d/%d, repetidos %d) ",
+		g.Render.Last.Round(time.Microsecond), g.Render.Average.Round(time.Microsecond), g.Render.Slow, g.Render.Frames,
+		g.Render.Skipped)
+	if g.ReducedEffects() {
+		line += "efeitos reduzidos "
+	}
+	return line
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"snake/term"
+)
+
+func TestPresentSkipsUnchangedFrame(t *testing.T) {
+	g := newTestGame(t, ModeClassic)
+	flushes := 0
+	flush := func() error {
+		flushes++
+		return nil
+	}
+
+	cells := []term.Cell{{Ch: 'a'}, {Ch: 'b'}}
+	g.present(cells, flush)
+	g.present(cells, flush)
+	if flushes != 1 || g.Render.Skipped != 1 {
+		t.Fatalf("quadro repetido: %d flushes e %d pulados, quer 1 e 1", flushes, g.Render.Skipped)
+	}
+
+	cells[1].Ch = 'c'
+	g.present(cells, flush)
+	if flushes != 2 {
+		t.Errorf("quadro alterado: %d flushes, quer 2", flushes)
+	}
+}
+
+func TestTickMenuReducedEffects(t *testing.T) {
+	g := newTestGame(t, ModeClassic)
+	g.State = StateMenu
+	g.Settings.ReducedEffects = true
+
+	g.TickMenu()
+	drawn := g.MenuDrawnAt
+	g.TickMenu()
+	if g.MenuFrame != 0 || g.MenuDrawnAt != drawn {
+		t.Errorf("menu parado foi redesenhado (quadro %d)", g.MenuFrame)
+	}
+
+	g.HandleInput(term.Event{Type: term.EventResize})
+	g.TickMenu()
+	if g.MenuDrawnAt == drawn {
+		t.Error("menu nao foi redesenhado depois de um evento")
+	}
+}
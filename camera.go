@@ -0,0 +1,160 @@
+package main
+
+import "snake/term"
+
+type CellSetter func(x, y int, ch rune, fg, bg term.Attribute)
+
+type Camera struct {
+	X        int
+	Y        int
+	Width    int
+	Height   int
+	OffsetX  int
+	OffsetY  int
+	Scale    int
+	HalfRows bool
+}
+
+func (c Camera) Contains(p Point) bool {
+	return p.X >= c.X && p.X < c.X+c.Width && p.Y >= c.Y && p.Y < c.Y+c.Height
+}
+
+func (c Camera) ToScreen(p Point) (int, int) {
+	if c.HalfRows {
+		return c.OffsetX + (p.X-c.X)*c.Scale, c.OffsetY + (p.Y-c.Y)/2
+	}
+	return c.OffsetX + (p.X-c.X)*c.Scale, c.OffsetY + p.Y - c.Y
+}
+
+func (c Camera) ToBoard(x, y int) Point {
+	if c.HalfRows {
+		return Point{X: (x-c.OffsetX)/max(1, c.Scale) + c.X, Y: (y-c.OffsetY)*2 + c.Y}
+	}
+	return Point{X: (x-c.OffsetX)/max(1, c.Scale) + c.X, Y: y - c.OffsetY + c.Y}
+}
+
+func (c Camera) ScreenHeight() int {
+	if c.HalfRows {
+		return (c.Height + 1) / 2
+	}
+	return c.Height
+}
+
+func (c Camera) ScreenWidth() int {
+	return c.Width * c.Scale
+}
+
+func (c Camera) Center() (int, int) {
+	return c.OffsetX + c.ScreenWidth()/2, c.OffsetY + c.ScreenHeight()/2
+}
+
+func (g *Game) IsBoardLargerThanView() bool {
+	return g.Camera.Width < g.Width || g.Camera.Height < g.Height
+}
+
+func (g *Game) UpdateCamera(focus Point) {
+	screenWidth, screenHeight := screen.Size()
+
+	g.Camera.Scale = 1
+	if g.Settings.SquareCells || (g.Settings.ScaleCells && screenWidth >= g.Width*2) {
+		g.Camera.Scale = 2
+	}
+
+	g.Camera.Width = min(g.Width, screenWidth/g.Camera.Scale)
+	g.Camera.HalfRows = g.Renderer == "hires"
+	g.Camera.Height = min(g.Height, screenHeight-1)
+	if g.Camera.HalfRows {
+		g.Camera.Height = min(g.Height, (screenHeight-1)*2)
+	}
+	g.Camera.OffsetX = (screenWidth - g.Camera.ScreenWidth()) / 2
+	g.Camera.OffsetY = (screenHeight - 1 - g.Camera.ScreenHeight()) / 2
+
+	g.Camera.X = clamp(focus.X-g.Camera.Width/2, 0, g.Width-g.Camera.Width)
+	g.Camera.Y = clamp(focus.Y-g.Camera.Height/2, 0, g.Height-g.Camera.Height)
+}
+
+func (g *Game) BoardSetter(offsetX, offsetY int) CellSetter {
+	return func(x, y int, ch rune, fg, bg term.Attribute) {
+		p := Point{X: x, Y: y}
+		if !g.Camera.Contains(p) {
+			return
+		}
+		screenX, screenY := g.Camera.ToScreen(p)
+		if g.Camera.HalfRows {
+			lower := (p.Y-g.Camera.Y)%2 == 1
+			for i := 0; i < g.Camera.Scale; i++ {
+				SetHalfBlock(screenX+offsetX+i, screenY+offsetY, lower, ch, fg, bg)
+			}
+			return
+		}
+		if g.Camera.Scale == 2 {
+			left, right := SquareGlyph(ch)
+			screen.SetCell(screenX+offsetX, screenY+offsetY, DisplayRune(left), fg, bg)
+			screen.SetCell(screenX+offsetX+1, screenY+offsetY, DisplayRune(right), fg, bg)
+			return
+		}
+		screen.SetCell(screenX+offsetX, screenY+offsetY, DisplayRune(ch), fg, bg)
+	}
+}
+
+func (g *Game) DrawMinimap() {
+	if !g.IsBoardLargerThanView() {
+		return
+	}
+
+	mapWidth := min(20, g.Camera.Width/3)
+	mapHeight := min(10, g.Camera.Height/3)
+	if mapWidth < 4 || mapHeight < 3 {
+		return
+	}
+
+	startX := g.Camera.OffsetX + g.Camera.ScreenWidth() - mapWidth - 1
+	startY := g.Camera.OffsetY + 1
+
+	toMap := func(p Point) (int, int) {
+		return startX + p.X*mapWidth/g.Width, startY + p.Y*mapHeight/g.Height
+	}
+
+	for y := 0; y < mapHeight; y++ {
+		for x := 0; x < mapWidth; x++ {
+			screen.SetCell(startX+x, startY+y, ' ', term.ColorDefault, term.ColorBlack)
+		}
+	}
+
+	viewX1, viewY1 := toMap(Point{X: g.Camera.X, Y: g.Camera.Y})
+	viewX2, viewY2 := toMap(Point{X: g.Camera.X + g.Camera.Width - 1, Y: g.Camera.Y + g.Camera.Height - 1})
+	for y := viewY1; y <= viewY2; y++ {
+		for x := viewX1; x <= viewX2; x++ {
+			screen.SetCell(x, y, DisplayRune('·'), term.ColorBlue, term.ColorBlack)
+		}
+	}
+
+	if !g.Foggy() {
+		for _, obs := range g.Obstacles {
+			x, y := toMap(obs.Position)
+			screen.SetCell(x, y, DisplayRune('▪'), term.ColorWhite, term.ColorBlack)
+		}
+
+		x, y := toMap(g.Food.Position)
+		screen.SetCell(x, y, DisplayRune('◆'), term.ColorRed, term.ColorBlack)
+	}
+
+	for i := g.Snake.Body.Len() - 1; i >= 0; i-- {
+		color := term.ColorGreen
+		if i == 0 {
+			color = term.ColorYellow
+		}
+		x, y := toMap(g.Snake.Body.At(i))
+		screen.SetCell(x, y, DisplayRune('•'), color, term.ColorBlack)
+	}
+}
+
+func clamp(value, low, high int) int {
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"snake/term"
+)
+
+const (
+	challengeVersion  = 1
+	maxChallengeBoard = 1000
+	MaxCodeLength     = 32
+)
+
+var ErrInvalidCode = errors.New("codigo de desafio invalido")
+
+type ChallengeCode struct {
+	Mode      GameMode
+	Modifiers Modifiers
+	Width     int
+	Height    int
+	Seed      int64
+}
+
+type CodeEntry struct {
+	Active bool
+	Text   string
+	Err    string
+}
+
+func (c ChallengeCode) Encode() string {
+	data := []byte{challengeVersion, byte(c.Mode), byte(c.Modifiers)}
+	data = binary.AppendUvarint(data, uint64(c.Width))
+	data = binary.AppendUvarint(data, uint64(c.Height))
+	data = binary.AppendVarint(data, c.Seed)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeChallenge(code string) (ChallengeCode, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(code))
+	if err != nil || len(data) < 3 {
+		return ChallengeCode{}, ErrInvalidCode
+	}
+	if data[0] != challengeVersion {
+		return ChallengeCode{}, fmt.Errorf("%w: versao %d", ErrInvalidCode, data[0])
+	}
+
+	c := ChallengeCode{Mode: GameMode(data[1]), Modifiers: Modifiers(data[2])}
+	if int(c.Mode) >= len(modeNames) {
+		return ChallengeCode{}, fmt.Errorf("%w: modo %d", ErrInvalidCode, data[1])
+	}
+
+	rest := data[3:]
+	width, n := binary.Uvarint(rest)
+	if n <= 0 {
+		return ChallengeCode{}, ErrInvalidCode
+	}
+	rest = rest[n:]
+	height, n := binary.Uvarint(rest)
+	if n <= 0 {
+		return ChallengeCode{}, ErrInvalidCode
+	}
+	rest = rest[n:]
+	seed, n := binary.Varint(rest)
+	if n <= 0 || n != len(rest) {
+		return ChallengeCode{}, ErrInvalidCode
+	}
+
+	if width < MinWidth || height < MinHeight || width > maxChallengeBoard || height > maxChallengeBoard {
+		return ChallengeCode{}, fmt.Errorf("%w: tabuleiro %dx%d", ErrInvalidCode, width, height)
+	}
+	c.Width, c.Height, c.Seed = int(width), int(height), seed
+	return c, nil
+}
+
+func (g *Game) ShareCode() string {
+	return ChallengeCode{
+		Mode:      g.Mode,
+		Modifiers: g.Modifiers,
+		Width:     g.Width,
+		Height:    g.Height,
+		Seed:      g.Seed,
+	}.Encode()
+}
+
+func (g *Game) MenuChallengeLine() string {
+	if g.Challenge != nil {
+		return "DESAFIO: " + g.Challenge.Encode()
+	}
+	return g.WeeklyMenuLine()
+}
+
+func (g *Game) StartChallenge(c ChallengeCode) {
+	g.SelectMode(c.Mode)
+	g.Challenge = &c
+	g.Modifiers = c.Modifiers
+	g.Reset()
+}
+
+func (g *Game) OpenCodeEntry() {
+	g.CodeEntry = CodeEntry{Active: true}
+}
+
+func (g *Game) HandleCodeEntryKey(ev term.Event) {
+	entry := &g.CodeEntry
+	switch {
+	case ev.Key == term.KeyEsc:
+		*entry = CodeEntry{}
+	case ev.Key == term.KeyEnter:
+		c, err := DecodeChallenge(entry.Text)
+		if err != nil {
+			entry.Err = err.Error()
+			return
+		}
+		*entry = CodeEntry{}
+		g.StartChallenge(c)
+	case ev.Key == term.KeyBackspace || ev.Key == term.KeyBackspace2:
+		if len(entry.Text) > 0 {
+			entry.Text = entry.Text[:len(entry.Text)-1]
+		}
+		entry.Err = ""
+	case ev.Ch != 0 && ev.Ch < 128 && len(entry.Text) < MaxCodeLength:
+		entry.Text += string(ev.Ch)
+		entry.Err = ""
+	}
+}
+
+func (g *Game) DrawCodeEntry() {
+	box := BoxLines([]string{
+		"Jogar por codigo",
+		"",
+		"> " + g.CodeEntry.Text + "_",
+		g.CodeEntry.Err,
+		"",
+		"ENTER - Jogar   ESC - Cancelar",
+	}, MaxCodeLength+2)
+
+	screenWidth, screenHeight := screen.Size()
+	DrawBox(screenWidth/2-BoxWidth(box)/2, screenHeight/2-len(box)/2, box, func(i int) term.Attribute {
+		switch i {
+		case 1:
+			return term.ColorYellow | term.AttrBold
+		case 4:
+			return term.ColorRed
+		}
+		return term.ColorYellow
+	})
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"snake/matchpb"
+	"snake/term"
+)
+
+const (
+	MaxChatLines   = 3
+	MaxChatLength  = 60
+	ChatRateLimit  = 3
+	ChatRateWindow = 5 * time.Second
+	ChatMessageTTL = 15 * time.Second
+	hostChatName   = "anfitriao"
+)
+
+type ChatMessage struct {
+	From string
+	Text string
+	At   time.Time
+}
+
+type ChatLimiter struct {
+	sent []time.Time
+}
+
+func (l *ChatLimiter) Allow(now time.Time) bool {
+	for len(l.sent) > 0 && now.Sub(l.sent[0]) >= ChatRateWindow {
+		l.sent = l.sent[1:]
+	}
+	if len(l.sent) >= ChatRateLimit {
+		return false
+	}
+	l.sent = append(l.sent, now)
+	return true
+}
+
+type Chat struct {
+	Messages []ChatMessage
+	Typing   bool
+	Text     string
+	Limiter  ChatLimiter
+}
+
+func CleanChat(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(text))
+	if runes := []rune(text); len(runes) > MaxChatLength {
+		text = string(runes[:MaxChatLength])
+	}
+	return text
+}
+
+func (g *Game) ChatEnabled() bool {
+	return g.Server != nil || g.Remote != nil
+}
+
+func (g *Game) IsChatKey(ev term.Event) bool {
+	return g.ChatEnabled() && (ev.Ch == 't' || ev.Ch == 'T')
+}
+
+func (g *Game) HandleChatKey(ev term.Event) {
+	chat := &g.Chat
+	switch {
+	case ev.Key == term.KeyEsc:
+		chat.Typing, chat.Text = false, ""
+	case ev.Key == term.KeyEnter:
+		text := CleanChat(chat.Text)
+		chat.Typing, chat.Text = false, ""
+		if text != "" {
+			g.SendChat(text)
+		}
+	case ev.Key == term.KeyBackspace || ev.Key == term.KeyBackspace2:
+		if runes := []rune(chat.Text); len(runes) > 0 {
+			chat.Text = string(runes[:len(runes)-1])
+		}
+	case ev.Key == term.KeySpace && len([]rune(chat.Text)) < MaxChatLength:
+		chat.Text += " "
+	case ev.Ch != 0 && len([]rune(chat.Text)) < MaxChatLength:
+		chat.Text += string(ev.Ch)
+	}
+}
+
+func (g *Game) SendChat(text string) {
+	if !g.Chat.Limiter.Allow(time.Now()) {
+		g.ShowToast(fmt.Sprintf("Chat: no maximo %d mensagens a cada %v", ChatRateLimit, ChatRateWindow), term.ColorYellow)
+		return
+	}
+	if g.Remote != nil {
+		g.Remote.Send(&matchpb.ClientMessage{Message: &matchpb.ClientMessage_Chat{Chat: &matchpb.Chat{Text: text}}})
+		return
+	}
+	g.AddChat(hostChatName, text)
+}
+
+func (g *Game) ReceiveChat(c *NetClient, text string) {
+	text = CleanChat(text)
+	if text == "" {
+		return
+	}
+	if !c.chat.Allow(time.Now()) {
+		c.send(&matchpb.ServerMessage{Message: &matchpb.ServerMessage_Chat{Chat: &matchpb.Chat{
+			Text: fmt.Sprintf("no maximo %d mensagens a cada %v", ChatRateLimit, ChatRateWindow),
+		}}})
+		return
+	}
+	g.AddChat(c.Name, text)
+}
+
+func (g *Game) AddChat(from, text string) {
+	g.Chat.Messages = append(g.Chat.Messages, ChatMessage{From: from, Text: text, At: time.Now()})
+	if len(g.Chat.Messages) > MaxChatLines {
+		g.Chat.Messages = g.Chat.Messages[len(g.Chat.Messages)-MaxChatLines:]
+	}
+	if g.Server == nil {
+		return
+	}
+	msg := &matchpb.ServerMessage{Message: &matchpb.ServerMessage_Chat{Chat: &matchpb.Chat{From: from, Text: text}}}
+	for _, c := range g.Server.Clients {
+		c.send(msg)
+	}
+}
+
+func (g *Game) DrawChat(x, y int) {
+	for _, m := range g.Chat.Messages {
+		if time.Since(m.At) > ChatMessageTTL {
+			continue
+		}
+		if m.From == "" {
+			DrawText(x, y, "* "+m.Text, term.ColorYellow, term.ColorDefault)
+		} else {
+			DrawText(DrawText(x, y, m.From+": ", term.ColorGreen|term.AttrBold, term.ColorDefault), y, m.Text, term.ColorWhite, term.ColorDefault)
+		}
+		y++
+	}
+	if g.Chat.Typing {
+		DrawText(x, y, "> "+g.Chat.Text+"_", term.ColorCyan, term.ColorDefault)
+	} else if g.ChatEnabled() && g.State == StatePlaying {
+		DrawText(x, y, "[T] chat", term.ColorBlack|term.AttrBold, term.ColorDefault)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"snake/matchpb"
+	"snake/term"
+)
+
+func TestChatLimiter(t *testing.T) {
+	var l ChatLimiter
+	now := time.Now()
+	for i := range ChatRateLimit {
+		if !l.Allow(now.Add(time.Duration(i) * time.Millisecond)) {
+			t.Fatalf("mensagem %d bloqueada", i)
+		}
+	}
+	if l.Allow(now.Add(time.Second)) {
+		t.Error("limite de envio ignorado")
+	}
+	if !l.Allow(now.Add(ChatRateWindow)) {
+		t.Error("limite nao liberou depois da janela")
+	}
+}
+
+func TestCleanChat(t *testing.T) {
+	if got := CleanChat("  oi\x1b[2J tudo\tbem  "); got != "oi[2J tudobem" {
+		t.Errorf("caracteres de controle: %q", got)
+	}
+	if got := CleanChat(strings.Repeat("é", MaxChatLength+10)); len([]rune(got)) != MaxChatLength {
+		t.Errorf("mensagem longa com %d letras", len([]rune(got)))
+	}
+}
+
+func chatMessages(c *NetClient) []*matchpb.Chat {
+	var chats []*matchpb.Chat
+	for {
+		select {
+		case msg := <-c.out:
+			if chat := msg.GetChat(); chat != nil {
+				chats = append(chats, chat)
+			}
+		default:
+			return chats
+		}
+	}
+}
+
+func TestChatThroughServer(t *testing.T) {
+	g := newTestGame(t, ModeClassic)
+	bia := &NetClient{Name: "bia", out: make(chan *matchpb.ServerMessage, netOutboxSize)}
+	g.Server = &MatchServer{Clients: []*NetClient{bia}}
+
+	if g.HandleInput(term.Event{Type: term.EventKey, Ch: 't'}) || !g.Chat.Typing {
+		t.Fatal("T nao abriu o chat")
+	}
+	for _, ch := range "oi" {
+		g.HandleInput(term.Event{Type: term.EventKey, Ch: ch})
+	}
+	direction := g.Snake.Direction
+	g.HandleInput(term.Event{Type: term.EventKey, Key: term.KeyArrowUp})
+	g.HandleInput(term.Event{Type: term.EventKey, Key: term.KeyEnter})
+	if g.Chat.Typing || g.Snake.Direction != direction {
+		t.Fatalf("teclas do chat vazaram para o jogo: digitando %v, direcao %v", g.Chat.Typing, g.Snake.Direction)
+	}
+	if chats := chatMessages(bia); len(chats) != 1 || chats[0].GetFrom() != hostChatName || chats[0].GetText() != "oi" {
+		t.Fatalf("mensagem do anfitriao: %v", chats)
+	}
+
+	for range ChatRateLimit + 1 {
+		g.ReceiveChat(bia, "spam")
+	}
+	chats := chatMessages(bia)
+	if len(chats) != ChatRateLimit+1 || chats[ChatRateLimit].GetFrom() != "" {
+		t.Fatalf("limite do cliente: %v", chats)
+	}
+	if last := g.Chat.Messages[len(g.Chat.Messages)-1]; last.From != "bia" || len(g.Chat.Messages) != MaxChatLines {
+		t.Errorf("historico do chat: %+v", g.Chat.Messages)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import "snake/term"
+
+const (
+	CheckpointEvery   = 100
+	CheckpointPenalty = 50
+)
+
+func (g *Game) UpdateCheckpoint() {
+	if g.Mode != ModeCasual || g.Score < g.NextCheckpoint {
+		return
+	}
+
+	snapshot := g.TakeSnapshot()
+	g.Checkpoint = &snapshot
+	g.NextCheckpoint = (g.Score/CheckpointEvery + 1) * CheckpointEvery
+	g.ShowToast("Checkpoint salvo!", term.ColorCyan)
+}
+
+func (g *Game) CanRestoreCheckpoint() bool {
+	return g.Mode == ModeCasual && g.State == StateGameOver && g.Checkpoint != nil
+}
+
+func (g *Game) RestoreCheckpoint() {
+	if !g.CanRestoreCheckpoint() {
+		return
+	}
+
+	snapshot := *g.Checkpoint
+	snapshot.Snake.Body = snapshot.Snake.Body.Clone()
+	snapshot.Obstacles = append([]Obstacle{}, snapshot.Obstacles...)
+
+	g.Twin = Snake{}
+	g.RestoreSnapshot(snapshot)
+	g.History = nil
+	g.Score = max(0, g.Score-CheckpointPenalty)
+	g.NextCheckpoint = (g.Score/CheckpointEvery + 1) * CheckpointEvery
+	g.GameOver = false
+	g.State = StatePlaying
+	g.ShakeFrames = 0
+	g.FlashFrames = 0
+	g.FreezeTicks = UndoFreeze
+	g.Metrics.DeathCause = ""
+	g.ShowSummary = false
+}
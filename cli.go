@@ -0,0 +1,328 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type Command struct {
+	Name  string
+	Usage string
+	Run   func(args []string) error
+}
+
+var commands []Command
+
+func init() {
+	commands = []Command{
+		{"play", "joga (padrao quando nenhum comando e informado)", runPlay},
+		{"replay", "reproduz uma gravacao .cast no terminal", runReplay},
+		{"top", "mostra as melhores partidas registradas", runTop},
+		{"bench", "mede o tempo medio de um passo da simulacao", runBench},
+		{"botmatch", "roda partidas sem tela entre os bots embutidos e mostra a tabela", runBotMatch},
+		{"fuzz", "joga entradas aleatorias sem tela e verifica as invariantes do motor", runFuzz},
+		{"soundpack", "mostra o pacote de sons padrao (-check valida um pacote, -samples as amostras)", runSoundPack},
+		{"editor", "abre direto no editor de niveis", runEditor},
+		{"gui", "joga em uma janela com sprites (requer -tags ebiten)", runGUI},
+		{"web", "joga no navegador, em um canvas (so no build GOOS=js GOARCH=wasm)", runWeb},
+		{"join", "entra em uma partida aberta com play -serve (-spectate so assiste, -observe marca o tabuleiro)", runJoin},
+		{"serve-tournament", "abre um torneio eliminatorio na rede local com o tabuleiro do dia", runServeTournament},
+		{"tournament", "entra em um torneio aberto com serve-tournament", runTournament},
+		{"sync", "sincroniza recordes, estatisticas e configuracoes com o sync_url", runSync},
+		{"help", "lista os comandos", runHelp},
+	}
+}
+
+func RunCommand(args []string) error {
+	if len(args) > 0 {
+		for _, command := range commands {
+			if command.Name == args[0] {
+				return command.Run(args[1:])
+			}
+		}
+	}
+	return runPlay(args)
+}
+
+func runHelp(args []string) error {
+	fmt.Println("uso: snake [comando] [opcoes]")
+	fmt.Println()
+	for _, command := range commands {
+		fmt.Printf("  %-8s %s\n", command.Name, command.Usage)
+	}
+	fmt.Println()
+	fmt.Println("use snake <comando> -h para ver as opcoes de cada comando")
+	return nil
+}
+
+func ParseMode(name string) (GameMode, bool) {
+	for i, modeName := range modeNames {
+		if strings.EqualFold(modeName, name) {
+			return GameMode(i), true
+		}
+	}
+	return ModeClassic, false
+}
+
+func boardFlags(fs *flag.FlagSet) func() (Options, error) {
+	width := fs.Int("width", DefaultWidth, "largura do tabuleiro")
+	height := fs.Int("height", DefaultHeight, "altura do tabuleiro")
+	theme := fs.String("theme", "normal", "tema do tabuleiro: normal, gelo, deserto, pantano ou rotativo")
+	level := fs.String("level", "", "carrega um nivel salvo pelo editor (.json) ou um nivel embutido pelo nome")
+	debugMode := fs.Bool("debug", false, "grava logs de diagnostico em "+debugLogFile)
+	renderer := fs.String("renderer", "texto", "renderizador do tabuleiro: texto ou hires (meio bloco, experimental)")
+	square := fs.Bool("square", false, "desenha cada casa com dois caracteres de largura (proporcao quadrada)")
+
+	return func() (Options, error) {
+		if *width < MinWidth || *height < MinHeight {
+			return Options{}, fmt.Errorf("tabuleiro muito pequeno: minimo %dx%d", MinWidth, MinHeight)
+		}
+		if !IsValidTheme(*theme) {
+			return Options{}, fmt.Errorf("tema desconhecido: %s", *theme)
+		}
+		if !IsValidRenderer(*renderer) {
+			return Options{}, fmt.Errorf("renderizador desconhecido: %s", *renderer)
+		}
+
+		var layout *Layout
+		if *level != "" {
+			loaded, err := LoadLayout(*level)
+			if err != nil {
+				return Options{}, err
+			}
+			layout = loaded
+		}
+
+		return Options{
+			Width:    *width,
+			Height:   *height,
+			Theme:    *theme,
+			Layout:   layout,
+			Debug:    *debugMode,
+			Square:   *square,
+			Renderer: *renderer,
+			Lives:    3,
+		}, nil
+	}
+}
+
+func runPlay(args []string) error {
+	fs := flag.NewFlagSet("play", flag.ExitOnError)
+	board := boardFlags(fs)
+	mode := fs.String("mode", "", "comeca direto neste modo (ex: Classico, Batalha, Neblina)")
+	record := fs.String("record", "", "grava a partida no formato asciicast (.cast)")
+	lives := fs.Int("lives", 3, "numero de vidas no modo Vidas")
+	pprofAddr := fs.String("pprof", "", "serve os endpoints do pprof neste endereco local (ex: :6060 vira localhost:6060)")
+	twitch := fs.String("twitch", "", "controla a cobra pelos comandos do chat deste canal da Twitch")
+	gamepad := fs.String("gamepad", "", "usa um controle/joystick do Linux (ex: /dev/input/js0)")
+	showVersion := fs.Bool("version", false, "mostra a versao e sai")
+	listLevels := fs.Bool("list-levels", false, "lista os niveis embutidos e sai")
+	listThemes := fs.Bool("list-themes", false, "lista os temas e sai")
+	demo := fs.String("demo", "", "modo demonstracao: a IA escolhida joga sozinha ("+strings.Join(BotNames(), ", ")+")")
+	code := fs.String("code", "", "joga o tabuleiro de um codigo de desafio")
+	rivalAI := fs.String("rival-ai", "", "IA dos rivais no modo Batalha ("+strings.Join(BotNames(), ", ")+")")
+	announce := fs.String("announce", "", "grava avisos em texto para leitores de tela neste arquivo (ex: um FIFO)")
+	serve := fs.String("serve", "", "abre a partida para a rede local neste endereco (ex: "+DefaultMatchPort+")")
+	export := fs.String("export", "", "exporta o historico de partidas para a saida padrao (csv ou json)")
+	fs.Parse(args)
+
+	if *showVersion {
+		fmt.Println(VersionString())
+		return nil
+	}
+
+	if *listLevels {
+		for _, name := range BundledLevelNames() {
+			fmt.Println(name)
+		}
+		return nil
+	}
+
+	if *listThemes {
+		for _, name := range append(themeNames, "rotativo") {
+			fmt.Println(name)
+		}
+		return nil
+	}
+
+	if *export != "" {
+		return ExportRuns(os.Stdout, *export)
+	}
+
+	opts, err := board()
+	if err != nil {
+		return err
+	}
+
+	if *mode != "" {
+		parsed, ok := ParseMode(*mode)
+		if !ok {
+			return fmt.Errorf("modo desconhecido: %s (modos: %s)", *mode, strings.Join(modeNames, ", "))
+		}
+		opts.Mode = parsed
+		opts.StartPlaying = true
+	}
+
+	opts.Record = *record
+	opts.Lives = *lives
+	if *pprofAddr != "" {
+		if opts.PprofAddr, err = ProfilerAddr(*pprofAddr); err != nil {
+			return err
+		}
+	}
+	opts.Twitch = *twitch
+	opts.Gamepad = *gamepad
+	opts.Demo = *demo
+	opts.Announce = *announce
+	opts.Serve = *serve
+	if *code != "" {
+		challenge, err := DecodeChallenge(*code)
+		if err != nil {
+			return err
+		}
+		opts.Code = &challenge
+	}
+	if *rivalAI != "" {
+		if _, err := NewController(*rivalAI); err != nil {
+			return err
+		}
+		opts.RivalAI = *rivalAI
+	}
+
+	AutoSync()
+	defer AutoSync()
+	return Run(opts)
+}
+
+func runEditor(args []string) error {
+	fs := flag.NewFlagSet("editor", flag.ExitOnError)
+	board := boardFlags(fs)
+	fs.Parse(args)
+
+	opts, err := board()
+	if err != nil {
+		return err
+	}
+	opts.Editor = true
+	return Run(opts)
+}
+
+func runReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	speed := fs.Float64("speed", 1, "velocidade da reproducao")
+	plain := fs.Bool("plain", false, "apenas escreve os quadros na saida, sem controles")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("uso: snake replay [-speed N] [-plain] arquivo.cast")
+	}
+	if *speed <= 0 {
+		return fmt.Errorf("velocidade invalida: %v", *speed)
+	}
+	if *plain {
+		return ReplayCast(fs.Arg(0), os.Stdout, *speed)
+	}
+
+	cast, err := LoadCast(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return PlayCast(cast, *speed)
+}
+
+func ReplayCast(path string, w io.Writer, speed float64) error {
+	cast, err := LoadCast(path)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	for _, frame := range cast.Frames {
+		time.Sleep(time.Until(start.Add(time.Duration(frame.At / speed * float64(time.Second)))))
+		io.WriteString(w, frame.Data)
+	}
+	return nil
+}
+
+func runTop(args []string) error {
+	fs := flag.NewFlagSet("top", flag.ExitOnError)
+	limit := fs.Int("n", 10, "quantidade de partidas")
+	mode := fs.String("mode", "", "mostra apenas este modo")
+	prestige := fs.Bool("prestige", false, "placar de prestigio: so as partidas do modo Hardcore, com o replay")
+	fs.Parse(args)
+	if *prestige {
+		*mode = ModeHardcore.String()
+	}
+
+	runs, err := LoadRuns()
+	if err != nil {
+		return err
+	}
+
+	var filtered []RunRecord
+	for _, run := range runs {
+		if *mode == "" && run.Mode != ModeHardcore.String() || strings.EqualFold(run.Mode, *mode) {
+			filtered = append(filtered, run)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Score > filtered[j].Score
+	})
+	if len(filtered) > *limit {
+		filtered = filtered[:*limit]
+	}
+
+	if len(filtered) == 0 {
+		fmt.Println("nenhuma partida registrada")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if *prestige {
+		fmt.Fprintln(writer, "#\tPONTOS\tNIVEL\tTAMANHO\tDATA\tMORTE\tREPLAY")
+		for i, run := range filtered {
+			fmt.Fprintf(writer, "%d\t%d\t%d\t%d\t%s\t%s\t%s\n", i+1, run.Score, run.Level, run.Length,
+				run.Timestamp.Format("2006-01-02 15:04"), run.DeathCause, run.Replay)
+		}
+		return writer.Flush()
+	}
+	fmt.Fprintln(writer, "#\tPONTOS\tMODO\tNIVEL\tTAMANHO\tDATA\tMODIFICADORES\tALERTA")
+	for i, run := range filtered {
+		fmt.Fprintf(writer, "%d\t%d\t%s\t%d\t%d\t%s\t%s\t%s\n", i+1, run.Score, run.Mode, run.Level, run.Length,
+			run.Timestamp.Format("2006-01-02 15:04"), run.Modifiers, run.FairPlayFlag)
+	}
+	return writer.Flush()
+}
+
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	ticks := fs.Int("ticks", 100000, "numero de passos simulados")
+	fs.Parse(args)
+
+	game := NewGame(DefaultWidth, DefaultHeight, "normal", nil)
+	game.Settings = DefaultSettings()
+	game.Mode = ModeSandbox
+	game.Reset()
+	game.Sandbox.CollisionsOff = true
+
+	start := time.Now()
+	for i := 0; i < *ticks; i++ {
+		if i%7 == 0 {
+			game.TurnPlayer(Directions[game.Rand.Intn(len(Directions))])
+		}
+		game.MoveSnake()
+	}
+	elapsed := time.Since(start)
+
+	fmt.Printf("passos: %d\n", *ticks)
+	fmt.Printf("tempo total: %s\n", elapsed.Round(time.Millisecond))
+	fmt.Printf("tempo por passo: %s\n", elapsed/time.Duration(max(1, *ticks)))
+	fmt.Printf("tamanho final: %d\n", game.Snake.Body.Len())
+	return nil
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"time"
+)
+
+func consoleBeep(freq float64, duration time.Duration) {
+	os.Stdout.WriteString("\a")
+}
+
+func NeedsGlyphFallback() bool {
+	return false
+}
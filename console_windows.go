@@ -0,0 +1,19 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+var procBeep = syscall.NewLazyDLL("kernel32.dll").NewProc("Beep")
+
+func consoleBeep(freq float64, duration time.Duration) {
+	procBeep.Call(uintptr(freq), uintptr(duration.Milliseconds()))
+}
+
+func NeedsGlyphFallback() bool {
+	return os.Getenv("WT_SESSION") == ""
+}
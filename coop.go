@@ -0,0 +1,170 @@
+package main
+
+import (
+	"time"
+
+	"snake/term"
+)
+
+const (
+	HeartFood   FoodType = 101
+	ReviveLimit          = 20 * time.Second
+)
+
+type Coop struct {
+	Partner    Snake
+	Down       int
+	ReviveLeft time.Duration
+	Heart      Food
+}
+
+type HeartFoodBehavior struct{}
+
+func (HeartFoodBehavior) Weight() int { return 0 }
+
+func (HeartFoodBehavior) OnSpawn(g *Game, f *Food) {}
+
+func (HeartFoodBehavior) OnEaten(g *Game, f *Food) int { return 0 }
+
+func (HeartFoodBehavior) Tick(g *Game, f *Food) {}
+
+func (HeartFoodBehavior) Render(g *Game, f *Food) (rune, term.Attribute) {
+	if g.Steady(3) {
+		return '♥', term.ColorRed | term.AttrBold
+	}
+	return '♥', term.ColorMagenta
+}
+
+func init() {
+	RegisterFood(HeartFood, HeartFoodBehavior{})
+}
+
+func (g *Game) StartCoop() {
+	g.Coop.Partner = g.NewPlayerSnake()
+
+	offset := -4
+	if g.StartPoint().Y+offset < 1 {
+		offset = 4
+	}
+	body := &g.Coop.Partner.Body
+	for i := 0; i < body.Len(); i++ {
+		p := body.At(i)
+		body.Set(i, Point{X: p.X, Y: p.Y + offset})
+	}
+}
+
+func (g *Game) TeamSnake(player int) *Snake {
+	if player == 1 {
+		return &g.Snake
+	}
+	return &g.Coop.Partner
+}
+
+func (g *Game) MoveCoop() {
+	for player := 1; player <= 2; player++ {
+		if g.Coop.Down == player {
+			continue
+		}
+
+		s := g.TeamSnake(player)
+		newHead, alive := g.StepPlayer(s)
+		if !alive {
+			if g.Coop.Down > 0 {
+				g.EndGame(newHead)
+				return
+			}
+			g.DownPlayer(player, newHead)
+			continue
+		}
+
+		if g.Coop.Down > 0 && newHead == g.Coop.Heart.Position {
+			g.RevivePlayer(g.Coop.Down)
+		}
+	}
+
+	if g.Coop.Down == 0 {
+		return
+	}
+	g.Coop.ReviveLeft -= g.TickInterval()
+	if g.Coop.ReviveLeft <= 0 {
+		g.Metrics.DeathCause = "tempo para reviver esgotado"
+		g.EndGame(g.FocusPoint())
+	}
+}
+
+func (g *Game) DownPlayer(player int, position Point) {
+	g.Coop.Down = player
+	g.Coop.ReviveLeft = ReviveLimit
+	s := g.TeamSnake(player)
+	g.Occupancy.RemoveBody(g.LayerOf(s), &s.Body)
+	s.Body.Clear()
+	g.TriggerShake(4, 4)
+
+	heart := Food{Type: HeartFood}
+	for attempts := 0; attempts < 100; attempts++ {
+		heart.Position = g.RandomCell()
+		if g.IsPositionSafe(heart.Position) {
+			break
+		}
+	}
+	g.Coop.Heart = heart
+}
+
+func (g *Game) RevivePlayer(player int) {
+	body, ok := g.FindSpawn(3)
+	if !ok {
+		return
+	}
+
+	s := g.TeamSnake(player)
+	*s = Snake{Body: NewSnakeBody(body), Direction: DirRight, Invulnerable: InvulnerableTicks}
+	g.Occupancy.AddBody(g.LayerOf(s), &s.Body)
+	g.Coop.Down = 0
+	g.Emit(Event{Type: EventPowerUpActivated, Position: body[0], Food: HeartFood})
+}
+
+func (c Coop) ReviveSecondsLeft() int {
+	return max(0, int(c.ReviveLeft.Seconds()))
+}
+
+func (g *Game) CheckPartnerCollision(p Point) bool {
+	return g.Mode == ModeCoop && g.Occupancy.Has(LayerPartner, p)
+}
+
+func (g *Game) HandlePartnerInput(ch rune) {
+	directions := map[rune]Direction{
+		'w': DirUp, 'W': DirUp,
+		's': DirDown, 'S': DirDown,
+		'a': DirLeft, 'A': DirLeft,
+		'd': DirRight, 'D': DirRight,
+	}
+
+	direction, ok := directions[ch]
+	if ok && g.Coop.Partner.CanTurn(direction) {
+		g.Coop.Partner.Direction = direction
+	}
+}
+
+func (g *Game) DrawCoop(setCell CellSetter) {
+	if g.Mode != ModeCoop {
+		return
+	}
+
+	for i, chunk := range g.Coop.Partner.Body.All() {
+		char := '█'
+		color := term.ColorBlue
+		if i == 0 {
+			char = '●'
+			color = term.ColorCyan
+		}
+		if g.Coop.Partner.Invulnerable > 0 && g.Steady(2) {
+			color = term.ColorWhite
+		}
+		setCell(chunk.X, chunk.Y, char, color, term.ColorDefault)
+	}
+
+	if g.Coop.Down > 0 {
+		char, color := foodBehaviors[HeartFood].Render(g, &g.Coop.Heart)
+		setCell(g.Coop.Heart.Position.X, g.Coop.Heart.Position.Y, char, color, term.ColorDefault)
+	}
+}
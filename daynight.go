@@ -0,0 +1,67 @@
+package main
+
+import (
+	"time"
+
+	"snake/term"
+)
+
+const (
+	DayLength      = 90 * time.Second
+	NightLength    = 45 * time.Second
+	NightRadius    = 12
+	NightFogShrink = 2
+	GlowFactor     = 2
+)
+
+type DayNight struct {
+	Elapsed time.Duration
+}
+
+func (g *Game) SubscribeDayNight() {
+	g.Events.Subscribe(EventGameStart, func(e Event) {
+		g.DayNight = DayNight{}
+	})
+	g.Events.Subscribe(EventTick, func(e Event) {
+		if g.State == StatePlaying && g.Modifiers.Has(ModDayNight) {
+			wasNight := g.Night()
+			g.DayNight.Elapsed += g.TickInterval()
+			if g.Night() != wasNight {
+				g.ShowToast(g.DayNightLabel(), term.ColorBlue)
+			}
+		}
+	})
+}
+
+func (g *Game) Night() bool {
+	if !g.Modifiers.Has(ModDayNight) {
+		return false
+	}
+	return g.DayNight.Elapsed%(DayLength+NightLength) >= DayLength
+}
+
+func (g *Game) DayNightLabel() string {
+	if g.Night() {
+		return "Anoiteceu"
+	}
+	return "Amanheceu"
+}
+
+func (g *Game) DayNightHUD() string {
+	if g.Night() {
+		return "| Noite "
+	}
+	return "| Dia "
+}
+
+func (g *Game) Glowing(p Point) bool {
+	return g.Night() && g.WithinRadius(p, g.VisibleRadius()*GlowFactor)
+}
+
+func (g *Game) DrawFoodGlow(setCell CellSetter) {
+	if !g.Glowing(g.Food.Position) {
+		return
+	}
+	char, color := foodBehaviors[g.Food.Type].Render(g, &g.Food)
+	setCell(g.Food.Position.X, g.Food.Position.Y, char, color|term.AttrBold, term.ColorDefault)
+}
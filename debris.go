@@ -0,0 +1,48 @@
+package main
+
+import "snake/term"
+
+const (
+	DebrisObstacle   ObstacleType = 101
+	DebrisPerPowerUp              = 2
+)
+
+type DebrisObstacleBehavior struct {
+	WallObstacleBehavior
+}
+
+func (DebrisObstacleBehavior) Render(g *Game, o *Obstacle) (rune, term.Attribute) {
+	return '▒', term.ColorGreen
+}
+
+func init() {
+	RegisterObstacle(DebrisObstacle, DebrisObstacleBehavior{})
+}
+
+func (g *Game) SubscribeDebris() {
+	g.Events.Subscribe(EventPowerUpActivated, func(e Event) {
+		if g.Mode == ModeDebris && e.Food == PowerUpFood {
+			g.DropTail(DebrisPerPowerUp)
+		}
+	})
+}
+
+func (g *Game) DropTail(segments int) {
+	for i := 0; i < segments && g.Snake.Body.Len() > 2; i++ {
+		p := g.Snake.Body.PopBack()
+		g.Occupancy.Remove(LayerPlayer, p)
+		g.Debris = append(g.Debris, p)
+		g.addDebrisObstacle(p)
+	}
+}
+
+func (g *Game) RestoreDebris() {
+	for _, p := range g.Debris {
+		g.addDebrisObstacle(p)
+	}
+}
+
+func (g *Game) addDebrisObstacle(p Point) {
+	g.Obstacles = append(g.Obstacles, Obstacle{Position: p, Type: DebrisObstacle})
+	g.Occupancy.SetObstacle(p, len(g.Obstacles)-1)
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	_ "net/http/pprof"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"snake/term"
+)
+
+const (
+	debugLogFile    = "snake-debug.log"
+	debugLogMaxSize = 1 << 20
+	debugLogBackups = 3
+)
+
+var logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+type DebugInfo struct {
+	Show         bool
+	FrameTime    time.Duration
+	TickTime     time.Duration
+	LastTick     time.Time
+	InputLatency time.Duration
+	LastInput    time.Time
+}
+
+type RotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	backups int
+	file    *os.File
+	size    int64
+}
+
+func OpenRotatingFile(path string, maxSize int64, backups int) (*RotatingFile, error) {
+	r := &RotatingFile{path: path, maxSize: maxSize, backups: backups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	for i := r.backups - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return err
+	}
+
+	return r.open()
+}
+
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+func SetupDebugLog() (io.Closer, error) {
+	file, err := OpenRotatingFile(debugLogFile, debugLogMaxSize, debugLogBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	logger = slog.New(slog.NewJSONHandler(file, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return file, nil
+}
+
+func ProfilerAddr(addr string) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("endereco do pprof invalido: %w", err)
+	}
+	if host == "" {
+		host = "localhost"
+	}
+
+	if host != "localhost" {
+		ip := net.ParseIP(host)
+		if ip == nil || !ip.IsLoopback() {
+			return "", fmt.Errorf("pprof so pode ouvir em enderecos locais (localhost, 127.0.0.1 ou ::1), nao %s", host)
+		}
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+func StartProfiler(addr string) {
+	go func() {
+		if err := http.ListenAndServe(addr, nil); err != nil {
+			logger.Error("falha ao servir pprof", "endereco", addr, "erro", err)
+		}
+	}()
+}
+
+func SubscribeLogging(bus *EventBus) {
+	names := map[EventType]string{
+		EventFoodEaten:        "comida",
+		EventPowerUpActivated: "power-up",
+		EventLevelUp:          "nivel",
+		EventDeath:            "morte",
+		EventGameStart:        "inicio",
+		EventRandom:           "evento aleatorio",
+	}
+
+	for eventType, name := range names {
+		bus.Subscribe(eventType, func(e Event) {
+			logger.Info("evento", "tipo", name, "x", e.Position.X, "y", e.Position.Y,
+				"pontos", e.Points, "score", e.Score, "nivel", e.Level, "frame", e.Frame)
+		})
+	}
+}
+
+func (g *Game) RecordInput() {
+	if g.Debug.LastInput.IsZero() {
+		g.Debug.LastInput = time.Now()
+	}
+}
+
+func (g *Game) RecordInputApplied() {
+	if g.Debug.LastInput.IsZero() {
+		return
+	}
+
+	g.Debug.InputLatency = time.Since(g.Debug.LastInput)
+	g.Debug.LastInput = time.Time{}
+	logger.Debug("latencia de entrada", "ms", g.Debug.InputLatency.Milliseconds())
+}
+
+func (g *Game) RecordTick(start time.Time) {
+	if !g.Debug.LastTick.IsZero() {
+		g.Debug.TickTime = start.Sub(g.Debug.LastTick)
+	}
+	g.Debug.LastTick = start
+	g.Debug.FrameTime = time.Since(start)
+
+	if g.Debug.FrameTime > g.TickInterval() {
+		logger.Warn("frame lento", "frame_ms", g.Debug.FrameTime.Milliseconds(),
+			"intervalo_ms", g.TickInterval().Milliseconds())
+	}
+}
+
+func (g *Game) DrawDebugOverlay() {
+	stateNames := map[GameState]string{
+		StateMenu:     "menu",
+		StatePlaying:  "jogando",
+		StateGameOver: "game over",
+		StateEditor:   "editor",
+	}
+
+	lines := []string{
+		" DEBUG (F3) ",
+		fmt.Sprintf(" tick: %v / %v ", g.Debug.TickTime.Round(time.Millisecond), g.TickInterval()),
+		fmt.Sprintf(" frame: %v ", g.Debug.FrameTime.Round(time.Microsecond)),
+		fmt.Sprintf(" entrada: %v ", g.Debug.InputLatency.Round(time.Millisecond)),
+		fmt.Sprintf(" goroutines: %d ", runtime.NumGoroutine()),
+		fmt.Sprintf(" estado: %s ", stateNames[g.State]),
+		fmt.Sprintf(" modo: %s ", g.Mode),
+		fmt.Sprintf(" seed: %d ", g.Seed),
+		g.AdaptiveDebugLine(),
+		g.RenderDebugLine(),
+	}
+	lines = append(lines, g.NetDebugLines()...)
+
+	screenWidth, _ := screen.Size()
+	width := 0
+	for _, line := range lines {
+		width = max(width, len([]rune(line)))
+	}
+
+	startX := screenWidth - width - 1
+	for i, line := range lines {
+		runes := []rune(line)
+		for j := 0; j < width; j++ {
+			char := ' '
+			if j < len(runes) {
+				char = runes[j]
+			}
+			screen.SetCell(startX+j, i, char, term.ColorWhite, term.ColorBlue)
+		}
+	}
+}
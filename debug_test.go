@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestProfilerAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+		ok   bool
+	}{
+		{":6060", "localhost:6060", true},
+		{"localhost:7070", "localhost:7070", true},
+		{"127.0.0.1:6060", "127.0.0.1:6060", true},
+		{"[::1]:6060", "[::1]:6060", true},
+		{"0.0.0.0:6060", "", false},
+		{"192.168.0.10:6060", "", false},
+		{"exemplo.com:6060", "", false},
+		{"6060", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := ProfilerAddr(tt.addr)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ProfilerAddr(%q) = %q, %v; quer %q, ok %v", tt.addr, got, err, tt.want, tt.ok)
+		}
+	}
+}
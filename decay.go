@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DecayGrace  = 15 * time.Second
+	DecayEvery  = time.Second
+	DecayPoints = 1
+)
+
+type Decay struct {
+	SinceMeal time.Duration
+	Drain     time.Duration
+	Lost      int
+}
+
+func (g *Game) DecayActive() bool {
+	return g.Settings.ScoreDecay && g.Mode.IsRanked() && g.Mode != ModeHunger
+}
+
+func (g *Game) SubscribeDecay() {
+	g.Events.Subscribe(EventFoodEaten, func(e Event) {
+		g.Decay = Decay{}
+	})
+}
+
+func (g *Game) UpdateDecay() {
+	if !g.DecayActive() || g.State != StatePlaying {
+		return
+	}
+
+	elapsed := g.TickInterval()
+	g.Decay.SinceMeal += elapsed
+	if g.Decay.SinceMeal < DecayGrace {
+		return
+	}
+
+	g.Decay.Drain += elapsed
+	for g.Decay.Drain >= DecayEvery {
+		g.Decay.Drain -= DecayEvery
+		lost := min(g.Score, DecayPoints)
+		g.Score -= lost
+		g.Decay.Lost += lost
+	}
+}
+
+func (g *Game) Decaying() bool {
+	return g.DecayActive() && g.Decay.SinceMeal >= DecayGrace
+}
+
+func (g *Game) DecayHUD() string {
+	return fmt.Sprintf("| PONTOS CAINDO -%d ", g.Decay.Lost)
+}
@@ -0,0 +1,65 @@
+package main
+
+type Direction int
+
+const (
+	DirNone Direction = iota
+	DirUp
+	DirDown
+	DirLeft
+	DirRight
+)
+
+var Directions = []Direction{DirUp, DirDown, DirLeft, DirRight}
+
+var directionDeltas = map[Direction]Point{
+	DirUp:    {X: 0, Y: -1},
+	DirDown:  {X: 0, Y: 1},
+	DirLeft:  {X: -1, Y: 0},
+	DirRight: {X: 1, Y: 0},
+}
+
+var directionNames = map[Direction]string{
+	DirNone:  "none",
+	DirUp:    "up",
+	DirDown:  "down",
+	DirLeft:  "left",
+	DirRight: "right",
+}
+
+func (d Direction) Delta() Point {
+	return directionDeltas[d]
+}
+
+func (d Direction) Opposite() Direction {
+	switch d {
+	case DirUp:
+		return DirDown
+	case DirDown:
+		return DirUp
+	case DirLeft:
+		return DirRight
+	case DirRight:
+		return DirLeft
+	}
+	return d
+}
+
+func (d Direction) Rotate(turn int) Direction {
+	clockwise := []Direction{DirUp, DirRight, DirDown, DirLeft}
+	for i, c := range clockwise {
+		if c == d {
+			return clockwise[((i+turn)%4+4)%4]
+		}
+	}
+	return d
+}
+
+func (d Direction) String() string {
+	return directionNames[d]
+}
+
+func (p Point) Move(direction Direction) Point {
+	delta := direction.Delta()
+	return Point{X: p.X + delta.X, Y: p.Y + delta.Y}
+}
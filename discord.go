@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	discordOpHandshake = 0
+	discordOpFrame     = 1
+
+	PresenceInterval = 5 * time.Second
+)
+
+type Presence struct {
+	Details string
+	State   string
+	Start   time.Time
+}
+
+type DiscordClient struct {
+	conn net.Conn
+}
+
+func discordSocketDirs() []string {
+	var dirs []string
+	for _, env := range []string{"XDG_RUNTIME_DIR", "TMPDIR", "TMP", "TEMP"} {
+		if dir := os.Getenv(env); dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return append(dirs, "/tmp")
+}
+
+func ConnectDiscord(clientID string) (*DiscordClient, error) {
+	if clientID == "" {
+		return nil, errors.New("discord_client_id nao configurado")
+	}
+
+	for _, dir := range discordSocketDirs() {
+		for i := 0; i < 10; i++ {
+			conn, err := net.Dial("unix", filepath.Join(dir, fmt.Sprintf("discord-ipc-%d", i)))
+			if err != nil {
+				continue
+			}
+
+			client := &DiscordClient{conn: conn}
+			if err := client.handshake(clientID); err != nil {
+				conn.Close()
+				return nil, err
+			}
+			return client, nil
+		}
+	}
+
+	return nil, errors.New("discord nao encontrado")
+}
+
+func (c *DiscordClient) handshake(clientID string) error {
+	if err := c.send(discordOpHandshake, map[string]any{"v": 1, "client_id": clientID}); err != nil {
+		return err
+	}
+	_, err := c.receive()
+	return err
+}
+
+func (c *DiscordClient) send(opcode uint32, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, 8)
+	binary.LittleEndian.PutUint32(header[0:4], opcode)
+	binary.LittleEndian.PutUint32(header[4:8], uint32(len(data)))
+
+	_, err = c.conn.Write(append(header, data...))
+	return err
+}
+
+func (c *DiscordClient) receive() ([]byte, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(c.conn, header); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, binary.LittleEndian.Uint32(header[4:8]))
+	if _, err := io.ReadFull(c.conn, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *DiscordClient) SetActivity(p Presence) error {
+	activity := map[string]any{
+		"details": p.Details,
+		"state":   p.State,
+	}
+	if !p.Start.IsZero() {
+		activity["timestamps"] = map[string]any{"start": p.Start.Unix()}
+	}
+
+	err := c.send(discordOpFrame, map[string]any{
+		"cmd":   "SET_ACTIVITY",
+		"args":  map[string]any{"pid": os.Getpid(), "activity": activity},
+		"nonce": fmt.Sprintf("%d", time.Now().UnixNano()),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.receive()
+	return err
+}
+
+func (c *DiscordClient) Close() error {
+	return c.conn.Close()
+}
+
+func (g *Game) Presence() Presence {
+	if g.State == StateGameOver {
+		return Presence{
+			Details: fmt.Sprintf("Game over: %d pts", g.Score),
+			State:   "Modo " + g.Mode.String(),
+		}
+	}
+	return Presence{
+		Details: fmt.Sprintf("Nivel %d, %d pts", g.Level, g.Score),
+		State:   "Modo " + g.Mode.String(),
+		Start:   g.Metrics.StartedAt,
+	}
+}
+
+func (g *Game) StartPresence(client *DiscordClient) {
+	updates := make(chan Presence, 1)
+
+	publish := func(e Event) {
+		presence := g.Presence()
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- presence
+	}
+	for _, eventType := range []EventType{EventGameStart, EventFoodEaten, EventLevelUp, EventDeath} {
+		g.Events.Subscribe(eventType, publish)
+	}
+
+	go func() {
+		var pending *Presence
+		ticker := time.NewTicker(PresenceInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case presence := <-updates:
+				pending = &presence
+			case <-ticker.C:
+				if pending == nil {
+					continue
+				}
+				if err := client.SetActivity(*pending); err != nil {
+					logger.Error("falha ao atualizar presenca no discord", "erro", err)
+					return
+				}
+				pending = nil
+			}
+		}
+	}()
+}
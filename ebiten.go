@@ -0,0 +1,324 @@
+//go:build ebiten
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/bitmapfont/v3"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"snake/term"
+)
+
+const (
+	guiCellWidth  = 12
+	guiCellHeight = 24
+	guiColumns    = 120
+	guiRows       = 40
+	guiTextScale  = 2
+)
+
+var guiKeys = []struct {
+	key  ebiten.Key
+	code term.Key
+}{
+	{ebiten.KeyArrowUp, term.KeyArrowUp},
+	{ebiten.KeyArrowDown, term.KeyArrowDown},
+	{ebiten.KeyArrowLeft, term.KeyArrowLeft},
+	{ebiten.KeyArrowRight, term.KeyArrowRight},
+	{ebiten.KeyEnter, term.KeyEnter},
+	{ebiten.KeyNumpadEnter, term.KeyEnter},
+	{ebiten.KeyEscape, term.KeyEsc},
+	{ebiten.KeySpace, term.KeySpace},
+	{ebiten.KeyBackspace, term.KeyBackspace2},
+	{ebiten.KeyHome, term.KeyHome},
+	{ebiten.KeyF1, term.KeyF1},
+	{ebiten.KeyF3, term.KeyF3},
+}
+
+type spriteMask func(x, y int) bool
+
+func roundMask(inside func(u, v float64) bool) spriteMask {
+	return func(x, y int) bool {
+		u := (float64(x)+0.5)/guiCellWidth*2 - 1
+		v := ((float64(y)+0.5)/guiCellHeight*2 - 1) * guiCellHeight / guiCellWidth
+		return inside(u, v)
+	}
+}
+
+func quadrantMask(ul, ur, ll, lr bool) spriteMask {
+	return func(x, y int) bool {
+		left, top := x < guiCellWidth/2, y < guiCellHeight/2
+		switch {
+		case top && left:
+			return ul
+		case top:
+			return ur
+		case left:
+			return ll
+		default:
+			return lr
+		}
+	}
+}
+
+var guiSprites = map[rune]spriteMask{
+	'●': roundMask(func(u, v float64) bool { return u*u+v*v <= 0.85*0.85 }),
+	'•': roundMask(func(u, v float64) bool { return u*u+v*v <= 0.5*0.5 }),
+	'·': roundMask(func(u, v float64) bool { return u*u+v*v <= 0.25*0.25 }),
+	'¤': roundMask(func(u, v float64) bool { r := math.Hypot(u, v); return r >= 0.45 && r <= 0.8 }),
+	'▪': roundMask(func(u, v float64) bool { return math.Abs(u) <= 0.5 && math.Abs(v) <= 0.5 }),
+	'◆': roundMask(func(u, v float64) bool { return math.Abs(u)+math.Abs(v) <= 0.9 }),
+	'◇': roundMask(func(u, v float64) bool { d := math.Abs(u) + math.Abs(v); return d >= 0.6 && d <= 0.9 }),
+	'✦': roundMask(func(u, v float64) bool { return math.Sqrt(math.Abs(u))+math.Sqrt(math.Abs(v)) <= 1 }),
+	'★': roundMask(func(u, v float64) bool {
+		return math.Hypot(u, v) <= 0.9*(0.55+0.45*math.Cos(5*(math.Atan2(v, u)+math.Pi/2)))
+	}),
+	'♥': roundMask(func(u, v float64) bool {
+		x, y := u*1.25, -v*1.25+0.1
+		a := x*x + y*y - 1
+		return a*a*a-x*x*y*y*y <= 0
+	}),
+	'≈': roundMask(func(u, v float64) bool {
+		wave := 0.2 * math.Sin(u*math.Pi)
+		return math.Abs(v-wave+0.4) <= 0.15 || math.Abs(v-wave-0.4) <= 0.15
+	}),
+	'█': func(x, y int) bool { return true },
+	'▓': func(x, y int) bool { return x%2 != 0 || y%2 != 0 },
+	'▒': func(x, y int) bool { return (x+y)%2 == 0 },
+	'░': func(x, y int) bool { return x%2 == 0 && y%2 == 0 },
+	'▀': quadrantMask(true, true, false, false),
+	'▄': quadrantMask(false, false, true, true),
+	'▌': quadrantMask(true, false, true, false),
+	'▐': quadrantMask(false, true, false, true),
+	'▛': quadrantMask(true, true, true, false),
+	'▜': quadrantMask(true, true, false, true),
+	'▙': quadrantMask(true, false, true, true),
+	'▟': quadrantMask(false, true, true, true),
+}
+
+type guiClock struct {
+	next time.Time
+}
+
+func (c *guiClock) Due(now time.Time, every time.Duration) bool {
+	if now.Before(c.next) {
+		return false
+	}
+	c.next = now.Add(every)
+	return true
+}
+
+type GUI struct {
+	game    *Game
+	cells   *CellBuffer
+	sprites map[rune]*ebiten.Image
+	face    text.Face
+	resized bool
+	mouse   image.Point
+
+	ticks, frames, menu guiClock
+}
+
+func NewGUI(cells *CellBuffer) *GUI {
+	gui := &GUI{
+		cells:   cells,
+		sprites: make(map[rune]*ebiten.Image, len(guiSprites)),
+		face:    text.NewGoXFace(bitmapfont.Face),
+	}
+	for ch, mask := range guiSprites {
+		img := image.NewRGBA(image.Rect(0, 0, guiCellWidth, guiCellHeight))
+		for y := range guiCellHeight {
+			for x := range guiCellWidth {
+				if mask(x, y) {
+					img.Set(x, y, color.White)
+				}
+			}
+		}
+		gui.sprites[ch] = ebiten.NewImageFromImage(img)
+	}
+	return gui
+}
+
+func keyRepeats(key ebiten.Key) bool {
+	d := inpututil.KeyPressDuration(key)
+	return d == 1 || d >= 30 && (d-30)%4 == 0
+}
+
+func (gui *GUI) Events() []term.Event {
+	var events []term.Event
+	if gui.resized {
+		gui.resized = false
+		events = append(events, term.Event{Type: term.EventResize, Width: gui.cells.Width, Height: gui.cells.Height})
+	}
+
+	ctrl := ebiten.IsKeyPressed(ebiten.KeyControl)
+	if ctrl && inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		return append(events, term.Event{Type: term.EventKey, Key: term.KeyCtrlC})
+	}
+	for _, k := range guiKeys {
+		if keyRepeats(k.key) {
+			events = append(events, term.Event{Type: term.EventKey, Key: k.code})
+		}
+	}
+	if !ctrl {
+		for _, ch := range ebiten.AppendInputChars(nil) {
+			if ch != ' ' {
+				events = append(events, term.Event{Type: term.EventKey, Ch: ch})
+			}
+		}
+	}
+
+	x, y := ebiten.CursorPosition()
+	cell := image.Pt(x/guiCellWidth, y/guiCellHeight)
+	mouse := func(key term.Key, mod term.Modifier) {
+		events = append(events, term.Event{Type: term.EventMouse, Key: key, Mod: mod, MouseX: cell.X, MouseY: cell.Y})
+	}
+	switch {
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
+		mouse(term.MouseLeft, 0)
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight):
+		mouse(term.MouseRight, 0)
+	case inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft), inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonRight):
+		mouse(term.MouseRelease, 0)
+	case ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && cell != gui.mouse:
+		mouse(term.MouseLeft, term.ModMotion)
+	}
+	gui.mouse = cell
+	return events
+}
+
+func (gui *GUI) Update() error {
+	g := gui.game
+	for _, ev := range gui.Events() {
+		if g.HandleInput(ev) {
+			RemoveRecovery()
+			return ebiten.Termination
+		}
+	}
+
+	select {
+	case <-g.Steps:
+		g.Step()
+	default:
+	}
+
+	now := time.Now()
+	if gui.ticks.Due(now, g.TickInterval()) {
+		g.Tick()
+	} else if gui.frames.Due(now, SmoothFrameInterval) {
+		g.SmoothFrame()
+	}
+	if gui.menu.Due(now, MenuFrameInterval) {
+		g.BeginRender()
+		g.TickMenu()
+	}
+	return nil
+}
+
+func (gui *GUI) Draw(dst *ebiten.Image) {
+	dst.Fill(CellBackground)
+	for i, cell := range gui.cells.Cells {
+		x := float32(i % gui.cells.Width * guiCellWidth)
+		y := float32(i / gui.cells.Width * guiCellHeight)
+
+		fg, bg := CellColors(cell)
+		if bg != CellBackground {
+			vector.DrawFilledRect(dst, x, y, guiCellWidth, guiCellHeight, bg, false)
+		}
+		if cell.Ch == ' ' || cell.Ch == 0 {
+			continue
+		}
+
+		if sprite, ok := gui.sprites[cell.Ch]; ok {
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Translate(float64(x), float64(y))
+			op.ColorScale.ScaleWithColor(fg)
+			dst.DrawImage(sprite, op)
+			continue
+		}
+		op := &text.DrawOptions{}
+		op.GeoM.Scale(guiTextScale, guiTextScale)
+		op.GeoM.Translate(float64(x), float64(y))
+		op.ColorScale.ScaleWithColor(fg)
+		text.Draw(dst, string(cell.Ch), gui.face, op)
+	}
+}
+
+func (gui *GUI) Layout(outsideWidth, outsideHeight int) (int, int) {
+	columns, rows := max(1, outsideWidth/guiCellWidth), max(1, outsideHeight/guiCellHeight)
+	if columns != gui.cells.Width || rows != gui.cells.Height {
+		gui.cells = NewCellBuffer(columns, rows)
+		screen = gui.cells
+		gui.resized = true
+	}
+	return outsideWidth, outsideHeight
+}
+
+func RunGUI(opts Options) error {
+	if opts.Debug {
+		logFile, err := SetupDebugLog()
+		if err != nil {
+			return err
+		}
+		defer logFile.Close()
+		logger.Info("janela iniciada", "largura", opts.Width, "altura", opts.Height, "tema", opts.Theme)
+	}
+
+	cells := NewCellBuffer(guiColumns, guiRows)
+	screen = cells
+	defer func() { screen = termScreen{} }()
+
+	gui := NewGUI(cells)
+	game, closeSession, err := NewSession(opts)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+	gui.game = game
+
+	ebiten.SetWindowTitle("Snake")
+	ebiten.SetWindowSize(guiColumns*guiCellWidth, guiRows*guiCellHeight)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	if err := ebiten.RunGame(gui); err != nil && !errors.Is(err, ebiten.Termination) {
+		return err
+	}
+	return nil
+}
+
+func runGUI(args []string) error {
+	fs := flag.NewFlagSet("gui", flag.ExitOnError)
+	board := boardFlags(fs)
+	mode := fs.String("mode", "", "comeca direto neste modo (ex: Classico, Batalha, Neblina)")
+	demo := fs.String("demo", "", "modo demonstracao: a IA escolhida joga sozinha ("+strings.Join(BotNames(), ", ")+")")
+	fs.Parse(args)
+
+	opts, err := board()
+	if err != nil {
+		return err
+	}
+	if *mode != "" {
+		parsed, ok := ParseMode(*mode)
+		if !ok {
+			return fmt.Errorf("modo desconhecido: %s (modos: %s)", *mode, strings.Join(modeNames, ", "))
+		}
+		opts.Mode = parsed
+		opts.StartPlaying = true
+	}
+	opts.Demo = *demo
+
+	AutoSync()
+	defer AutoSync()
+	return RunGUI(opts)
+}
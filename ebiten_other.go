@@ -0,0 +1,9 @@
+//go:build !ebiten
+
+package main
+
+import "errors"
+
+func runGUI(args []string) error {
+	return errors.New("janela indisponivel: compile com go build -tags ebiten")
+}
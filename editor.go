@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"time"
+
+	"snake/term"
+)
+
+type Editor struct {
+	Layout  Layout
+	Cursor  Point
+	Message string
+}
+
+func (g *Game) OpenEditor() {
+	layout := NewLayout(g.Width, g.Height)
+	if g.Layout != nil {
+		layout = *g.Layout
+		layout.Walls = append([]Point{}, g.Layout.Walls...)
+		layout.FoodWeights = maps.Clone(g.Layout.FoodWeights)
+		if layout.FoodWeights == nil {
+			layout.FoodWeights = NewLayout(g.Width, g.Height).FoodWeights
+		}
+	}
+
+	g.Editor = Editor{
+		Layout: layout,
+		Cursor: Point{X: g.Width / 2, Y: g.Height / 2},
+	}
+	g.State = StateEditor
+}
+
+func (g *Game) ToggleEditorWall(p Point) {
+	layout := &g.Editor.Layout
+	if g.CheckWallCollision(p) {
+		return
+	}
+	if p.Y == layout.Spawn.Y && p.X >= layout.Spawn.X-2 && p.X <= layout.Spawn.X {
+		g.Editor.Message = "A cobra comeca nessas casas"
+		return
+	}
+
+	for i, wall := range layout.Walls {
+		if wall == p {
+			layout.Walls = append(layout.Walls[:i], layout.Walls[i+1:]...)
+			return
+		}
+	}
+	layout.Walls = append(layout.Walls, p)
+}
+
+func (g *Game) SetEditorSpawn(p Point) {
+	layout := &g.Editor.Layout
+	if p.X < 3 || g.CheckWallCollision(p) {
+		g.Editor.Message = "Ponto de partida precisa de 3 casas livres a esquerda"
+		return
+	}
+
+	for x := p.X - 2; x <= p.X; x++ {
+		if layout.HasWall(Point{X: x, Y: p.Y}) {
+			g.Editor.Message = "Ponto de partida bloqueado por parede"
+			return
+		}
+	}
+	layout.Spawn = p
+}
+
+func (g *Game) AdjustPowerUpWeight(delta int) {
+	weights := g.Editor.Layout.FoodWeights
+	weights["powerup"] = clamp(weights["powerup"]+delta, 0, 100)
+	weights["normal"] = 100 - weights["powerup"]
+}
+
+func (g *Game) SaveEditorLayout() {
+	path := filepath.Join(LevelsDir, fmt.Sprintf("nivel-%s.json", time.Now().Format("20060102-150405")))
+	if err := SaveLayout(path, g.Editor.Layout); err != nil {
+		g.Editor.Message = "Erro ao salvar: " + err.Error()
+		return
+	}
+	g.Editor.Message = "Nivel salvo em " + path
+}
+
+func (g *Game) PlayTestEditorLayout() {
+	layout := g.Editor.Layout
+	layout.Walls = append([]Point{}, g.Editor.Layout.Walls...)
+	layout.FoodWeights = maps.Clone(g.Editor.Layout.FoodWeights)
+	g.ApplyLayout(&layout)
+	g.Reset()
+}
+
+func (g *Game) HandleEditorKey(ev term.Event) {
+	g.Editor.Message = ""
+
+	switch ev.Key {
+	case term.KeyEsc:
+		g.State = StateMenu
+		return
+	case term.KeyArrowUp, term.KeyArrowDown, term.KeyArrowLeft, term.KeyArrowRight:
+		directions := map[term.Key]Direction{
+			term.KeyArrowUp:    DirUp,
+			term.KeyArrowDown:  DirDown,
+			term.KeyArrowLeft:  DirLeft,
+			term.KeyArrowRight: DirRight,
+		}
+		next := g.Editor.Cursor.Move(directions[ev.Key])
+		if !g.CheckWallCollision(next) {
+			g.Editor.Cursor = next
+		}
+		return
+	case term.KeySpace:
+		g.ToggleEditorWall(g.Editor.Cursor)
+		return
+	}
+
+	switch ev.Ch {
+	case 's', 'S':
+		g.SetEditorSpawn(g.Editor.Cursor)
+	case '[':
+		g.AdjustPowerUpWeight(-5)
+	case ']':
+		g.AdjustPowerUpWeight(5)
+	case 'g', 'G':
+		g.SaveEditorLayout()
+	case 'p', 'P':
+		g.PlayTestEditorLayout()
+	}
+}
+
+func (g *Game) HandleEditorMouse(ev term.Event) {
+	p := g.Camera.ToBoard(ev.MouseX, ev.MouseY)
+	if g.CheckWallCollision(p) {
+		return
+	}
+
+	g.Editor.Cursor = p
+	switch ev.Key {
+	case term.MouseLeft:
+		g.ToggleEditorWall(p)
+	case term.MouseRight:
+		g.SetEditorSpawn(p)
+	}
+}
+
+func (g *Game) DrawEditor() {
+	screen.Clear(term.ColorDefault, term.ColorDefault)
+
+	g.UpdateCamera(g.Editor.Cursor)
+	setCell := g.BoardSetter(0, 0)
+
+	g.DrawBorder(setCell, term.ColorWhite)
+
+	layout := &g.Editor.Layout
+	for _, wall := range layout.Walls {
+		setCell(wall.X, wall.Y, '▓', term.ColorWhite, term.ColorDefault)
+	}
+
+	spawn := layout.Spawn
+	setCell(spawn.X, spawn.Y, '●', term.ColorYellow, term.ColorDefault)
+	setCell(spawn.X-1, spawn.Y, '█', term.ColorGreen, term.ColorDefault)
+	setCell(spawn.X-2, spawn.Y, '█', term.ColorGreen, term.ColorDefault)
+
+	if g.Steady(3) {
+		setCell(g.Editor.Cursor.X, g.Editor.Cursor.Y, '+', term.ColorMagenta|term.AttrBold, term.ColorDefault)
+	}
+
+	lines := []string{
+		fmt.Sprintf(" EDITOR | Paredes: %d | Power-up: %d%% | Cursor: %d,%d ",
+			len(layout.Walls), layout.FoodWeights["powerup"], g.Editor.Cursor.X, g.Editor.Cursor.Y),
+		" Setas: mover | Espaco: parede | S: partida | [ ]: power-up | G: salvar | P: testar | ESC: menu ",
+		" " + g.Editor.Message,
+	}
+	for i, line := range lines {
+		DrawText(g.Camera.OffsetX+2, g.Camera.OffsetY+g.Camera.ScreenHeight()+i, line, term.ColorCyan, term.ColorDefault)
+	}
+
+	g.Flush()
+}
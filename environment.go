@@ -0,0 +1,139 @@
+package main
+
+import (
+	"time"
+
+	"snake/term"
+)
+
+type BoardEnvironment interface {
+	Name() string
+	Setup(g *Game)
+	FoodTTL() time.Duration
+	NextDirection(s *Snake) Direction
+	SkipMove(g *Game, s *Snake) bool
+	BorderColor() term.Attribute
+	TrailColor() term.Attribute
+	Draw(g *Game, setCell CellSetter)
+}
+
+var themeNames = []string{"normal", "gelo", "deserto", "pantano"}
+
+func NewEnvironment(theme string) BoardEnvironment {
+	switch theme {
+	case "gelo":
+		return &IceEnvironment{}
+	case "deserto":
+		return &DesertEnvironment{}
+	case "pantano":
+		return &SwampEnvironment{}
+	}
+	return &NormalEnvironment{}
+}
+
+func IsValidTheme(theme string) bool {
+	if theme == "rotativo" {
+		return true
+	}
+	for _, name := range themeNames {
+		if name == theme {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) SelectEnvironment() {
+	theme := g.Theme
+	if theme == "rotativo" {
+		theme = themeNames[(g.Level-1)%len(themeNames)]
+	}
+
+	g.Environment = NewEnvironment(theme)
+	g.Environment.Setup(g)
+}
+
+type NormalEnvironment struct{}
+
+func (*NormalEnvironment) Name() string { return "Normal" }
+
+func (*NormalEnvironment) Setup(g *Game) {}
+
+func (*NormalEnvironment) FoodTTL() time.Duration { return 0 }
+
+func (*NormalEnvironment) NextDirection(s *Snake) Direction { return s.Direction }
+
+func (*NormalEnvironment) SkipMove(g *Game, s *Snake) bool { return false }
+
+func (*NormalEnvironment) BorderColor() term.Attribute { return term.ColorWhite }
+
+func (*NormalEnvironment) TrailColor() term.Attribute { return term.ColorGreen }
+
+func (*NormalEnvironment) Draw(g *Game, setCell CellSetter) {}
+
+type IceEnvironment struct {
+	NormalEnvironment
+}
+
+func (*IceEnvironment) Name() string { return "Gelo" }
+
+func (*IceEnvironment) NextDirection(s *Snake) Direction {
+	if s.Moved != DirNone && s.Direction != s.Moved && !s.Slid {
+		s.Slid = true
+		return s.Moved
+	}
+	s.Slid = false
+	return s.Direction
+}
+
+func (*IceEnvironment) BorderColor() term.Attribute { return term.ColorCyan }
+
+func (*IceEnvironment) TrailColor() term.Attribute { return term.ColorCyan }
+
+type DesertEnvironment struct {
+	NormalEnvironment
+}
+
+func (*DesertEnvironment) Name() string { return "Deserto" }
+
+func (*DesertEnvironment) FoodTTL() time.Duration { return 5 * time.Second }
+
+func (*DesertEnvironment) BorderColor() term.Attribute { return term.ColorYellow }
+
+func (*DesertEnvironment) TrailColor() term.Attribute { return term.ColorYellow }
+
+type SwampEnvironment struct {
+	NormalEnvironment
+	Patches map[Point]bool
+}
+
+func (*SwampEnvironment) Name() string { return "Pantano" }
+
+func (e *SwampEnvironment) Setup(g *Game) {
+	e.Patches = make(map[Point]bool)
+
+	areaScale := max(1, (g.Width*g.Height)/(DefaultWidth*DefaultHeight))
+	for i := 0; i < 4*areaScale; i++ {
+		center := g.RandomCell()
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -2; dx <= 2; dx++ {
+				p := Point{X: center.X + dx, Y: center.Y + dy}
+				if !g.CheckWallCollision(p) {
+					e.Patches[p] = true
+				}
+			}
+		}
+	}
+}
+
+func (e *SwampEnvironment) SkipMove(g *Game, s *Snake) bool {
+	return e.Patches[s.Body.Head()] && g.FrameCount%2 == 1
+}
+
+func (*SwampEnvironment) BorderColor() term.Attribute { return term.ColorGreen }
+
+func (e *SwampEnvironment) Draw(g *Game, setCell CellSetter) {
+	for p := range e.Patches {
+		setCell(p.X, p.Y, '≈', term.ColorGreen, term.ColorDefault)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+type EventType int
+
+const (
+	EventTick EventType = iota
+	EventFoodEaten
+	EventPowerUpActivated
+	EventLevelUp
+	EventDeath
+	EventGameStart
+	EventBossSpawned
+	EventBossDefeated
+	EventRandom
+)
+
+type Event struct {
+	Type     EventType
+	Position Point
+	Food     FoodType
+	Points   int
+	Score    int
+	Level    int
+	Frame    int
+	Name     string
+}
+
+type EventHandler func(Event)
+
+type EventBus struct {
+	handlers map[EventType][]EventHandler
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		handlers: make(map[EventType][]EventHandler),
+	}
+}
+
+func (b *EventBus) Subscribe(eventType EventType, handler EventHandler) {
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+func (b *EventBus) SubscribeAll(handler EventHandler) {
+	for _, eventType := range []EventType{EventTick, EventFoodEaten, EventPowerUpActivated, EventLevelUp, EventDeath, EventGameStart} {
+		b.Subscribe(eventType, handler)
+	}
+}
+
+func (b *EventBus) Emit(event Event) {
+	for _, handler := range b.handlers[event.Type] {
+		handler(event)
+	}
+}
+
+func (g *Game) Emit(event Event) {
+	event.Score = g.Score
+	event.Level = g.Level
+	event.Frame = g.FrameCount
+	g.Events.Emit(event)
+}
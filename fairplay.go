@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+)
+
+const (
+	FairPlayBucket     = 10 * time.Millisecond
+	FairPlayBuckets    = 30
+	FairPlayIdle       = 2 * time.Second
+	FairPlayMinSamples = 50
+	FairPlayFastTurn   = 30 * time.Millisecond
+	FairPlayFastShare  = 0.25
+	FairPlayMinSpread  = 0.15
+)
+
+type FairPlay struct {
+	LastTurn  time.Time
+	Histogram [FairPlayBuckets]int
+	Samples   int
+	Fast      int
+	Sum       float64
+	SumSq     float64
+}
+
+func (f *FairPlay) Record(now time.Time) {
+	last := f.LastTurn
+	f.LastTurn = now
+	if last.IsZero() {
+		return
+	}
+
+	interval := now.Sub(last)
+	if interval >= FairPlayIdle {
+		return
+	}
+
+	f.Histogram[min(FairPlayBuckets-1, int(interval/FairPlayBucket))]++
+	f.Samples++
+	if interval < FairPlayFastTurn {
+		f.Fast++
+	}
+	ms := float64(interval) / float64(time.Millisecond)
+	f.Sum += ms
+	f.SumSq += ms * ms
+}
+
+func (f *FairPlay) Spread() float64 {
+	if f.Samples == 0 || f.Sum == 0 {
+		return 0
+	}
+	mean := f.Sum / float64(f.Samples)
+	variance := max(0, f.SumSq/float64(f.Samples)-mean*mean)
+	return math.Sqrt(variance) / mean
+}
+
+func (f *FairPlay) Flag() string {
+	if f.Samples < FairPlayMinSamples {
+		return ""
+	}
+	switch {
+	case float64(f.Fast)/float64(f.Samples) > FairPlayFastShare:
+		return "entradas rapidas demais"
+	case f.Spread() < FairPlayMinSpread:
+		return "ritmo uniforme demais"
+	}
+	return ""
+}
+
+func (f *FairPlay) HistogramSlice() []int {
+	if f.Samples == 0 {
+		return nil
+	}
+	return f.Histogram[:]
+}
+
+func (g *Game) RecordTurn(direction Direction) {
+	g.FairPlay.Record(time.Now())
+	if g.Recorder != nil {
+		g.Recorder.Input(direction.String())
+	}
+}
+
+func (g *Game) MarkFairPlay() {
+	if g.Recorder == nil {
+		return
+	}
+	data, err := json.Marshal(map[string]any{
+		"bucket_ms": FairPlayBucket.Milliseconds(),
+		"histogram": g.FairPlay.HistogramSlice(),
+		"flag":      g.FairPlay.Flag(),
+	})
+	if err != nil {
+		return
+	}
+	g.Recorder.Mark(fairPlayMarker + string(data))
+}
@@ -0,0 +1,78 @@
+package main
+
+import "snake/term"
+
+const FogRadius = 6
+
+func (g *Game) VisibleRadius() int {
+	radius := 0
+	if g.Mode == ModeFog || g.RandomEventActive(EventBlackout) {
+		radius = FogRadius
+	}
+	if !g.Night() {
+		return radius
+	}
+	if radius == 0 {
+		return NightRadius
+	}
+	return radius - NightFogShrink
+}
+
+func (g *Game) Foggy() bool {
+	return g.VisibleRadius() > 0
+}
+
+func (g *Game) IsVisible(p Point) bool {
+	return g.WithinRadius(p, g.VisibleRadius())
+}
+
+func (g *Game) WithinRadius(p Point, radius int) bool {
+	for _, s := range g.PlayerSnakes() {
+		if s.Body.Len() == 0 {
+			continue
+		}
+		head := s.Body.Head()
+		dx, dy := p.X-head.X, p.Y-head.Y
+		if dx*dx+dy*dy <= radius*radius {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) FogSetter(setCell CellSetter) CellSetter {
+	return func(x, y int, ch rune, fg, bg term.Attribute) {
+		p := Point{X: x, Y: y}
+		if g.IsVisible(p) {
+			setCell(x, y, ch, fg, bg)
+		} else if g.CheckWallCollision(p) {
+			setCell(x, y, ch, term.ColorBlack|term.AttrBold, bg)
+		}
+	}
+}
+
+func (g *Game) FoodHint() Point {
+	head := g.FocusPoint()
+	food := g.Food.Position
+	dx, dy := food.X-head.X, food.Y-head.Y
+
+	if abs(dx) >= abs(dy) {
+		if dx > 0 {
+			return Point{X: g.Width - 1, Y: food.Y}
+		}
+		return Point{X: 0, Y: food.Y}
+	}
+	if dy > 0 {
+		return Point{X: food.X, Y: g.Height - 1}
+	}
+	return Point{X: food.X, Y: 0}
+}
+
+func (g *Game) DrawFogHint(setCell CellSetter) {
+	if g.IsVisible(g.Food.Position) || g.Glowing(g.Food.Position) {
+		return
+	}
+
+	hint := g.FoodHint()
+	setCell(hint.X, hint.Y, '◇', term.ColorRed, term.ColorDefault)
+}
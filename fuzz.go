@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+)
+
+func NewHeadlessGame(mode GameMode, seed int64) *Game {
+	game := NewGame(DefaultWidth, DefaultHeight, "normal", nil)
+	game.Settings = DefaultSettings()
+	game.Headless = true
+	game.Mode = mode
+	game.Seed = seed
+	game.Reset()
+	return game
+}
+
+func DecodeInput(b byte) Direction {
+	if i := int(b) % (len(Directions) + 1); i > 0 {
+		return Directions[i-1]
+	}
+	return DirNone
+}
+
+func (g *Game) Apply(inputs []byte) error {
+	for tick, b := range inputs {
+		if g.State != StatePlaying {
+			return nil
+		}
+
+		score := g.Score
+		g.TurnPlayer(DecodeInput(b))
+		g.MoveSnake()
+		if err := g.CheckInvariants(score); err != nil {
+			return fmt.Errorf("passo %d: %w", tick, err)
+		}
+	}
+	return nil
+}
+
+func (g *Game) CheckInvariants(previousScore int) error {
+	if g.Score < previousScore && g.Mode != ModeHunger && !g.DecayActive() {
+		return fmt.Errorf("pontuacao caiu de %d para %d", previousScore, g.Score)
+	}
+	if g.State != StatePlaying {
+		return nil
+	}
+
+	seen := map[Point]bool{}
+	for _, p := range g.Snake.Body.All() {
+		if seen[p] && !g.CollisionsDisabled() {
+			return fmt.Errorf("cobra sobreposta em (%d,%d)", p.X, p.Y)
+		}
+		seen[p] = true
+	}
+
+	food := g.Food.Position
+	if seen[food] || g.CheckWallCollision(food) || g.CheckObstacleCollision(food) {
+		return fmt.Errorf("comida em casa ocupada (%d,%d)", food.X, food.Y)
+	}
+	return nil
+}
+
+func runFuzz(args []string) error {
+	fs := flag.NewFlagSet("fuzz", flag.ExitOnError)
+	runs := fs.Int("runs", 200, "partidas por modo")
+	ticks := fs.Int("ticks", 2000, "entradas por partida")
+	seed := fs.Int64("seed", 1, "seed da primeira partida")
+	modeName := fs.String("mode", "", "modo de jogo (vazio testa todos)")
+	fs.Parse(args)
+
+	modes := make([]GameMode, len(modeNames))
+	for i := range modeNames {
+		modes[i] = GameMode(i)
+	}
+	if *modeName != "" {
+		mode, ok := ParseMode(*modeName)
+		if !ok {
+			return fmt.Errorf("modo desconhecido: %s", *modeName)
+		}
+		modes = []GameMode{mode}
+	}
+
+	failures := 0
+	for _, mode := range modes {
+		for run := 0; run < *runs; run++ {
+			runSeed := *seed + int64(run)
+			inputs := make([]byte, *ticks)
+			rand.New(rand.NewSource(runSeed)).Read(inputs)
+
+			if err := NewHeadlessGame(mode, runSeed).Apply(inputs); err != nil {
+				fmt.Printf("%s seed %d: %v\n", mode, runSeed, err)
+				failures++
+			}
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d partidas violaram invariantes", failures)
+	}
+	fmt.Printf("%d partidas sem violacoes\n", len(modes)**runs)
+	return nil
+}
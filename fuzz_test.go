@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzApply(f *testing.F) {
+	f.Chdir(f.TempDir())
+
+	inputs := [][]byte{
+		nil,
+		{0, 0, 0, 0},
+		{1, 4, 2, 3},
+		bytes.Repeat([]byte{3}, 60),
+		bytes.Repeat([]byte{1, 0, 0, 4, 0, 0, 2, 0, 0, 3, 0, 0}, 20),
+		bytes.Repeat([]byte{2, 3, 1, 4}, 50),
+	}
+	for mode := range modeNames {
+		for i, in := range inputs {
+			f.Add(int64(i+1), uint8(mode), in)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64, mode uint8, in []byte) {
+		g := NewHeadlessGame(GameMode(int(mode)%len(modeNames)), seed)
+		if err := g.Apply(in); err != nil {
+			t.Fatal(err)
+		}
+		if err := g.CheckInvariants(g.Score); err != nil {
+			t.Fatalf("depois de %d entradas: %v", len(in), err)
+		}
+	})
+}
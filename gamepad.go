@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"sync"
+)
+
+const (
+	jsEventButton = 0x01
+	jsEventAxis   = 0x02
+	jsEventInit   = 0x80
+
+	GamepadDeadzone = 16384
+)
+
+type jsEvent struct {
+	Time   uint32
+	Value  int16
+	Type   uint8
+	Number uint8
+}
+
+type GamepadController struct {
+	StartButton uint8
+	OnStart     func()
+
+	mu        sync.Mutex
+	direction Direction
+	axes      map[uint8]int16
+	file      *os.File
+}
+
+func OpenGamepad(path string, startButton int, onStart func()) (*GamepadController, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	gp := &GamepadController{
+		StartButton: uint8(startButton),
+		OnStart:     onStart,
+		axes:        map[uint8]int16{},
+		file:        file,
+	}
+	go gp.readLoop()
+	return gp, nil
+}
+
+func (gp *GamepadController) readLoop() {
+	for {
+		var event jsEvent
+		if err := binary.Read(gp.file, binary.LittleEndian, &event); err != nil {
+			if err != io.EOF {
+				logger.Error("leitura do controle encerrada", "erro", err)
+			}
+			return
+		}
+
+		switch event.Type &^ jsEventInit {
+		case jsEventButton:
+			if event.Type&jsEventInit == 0 && event.Number == gp.StartButton && event.Value == 1 && gp.OnStart != nil {
+				gp.OnStart()
+			}
+		case jsEventAxis:
+			gp.mu.Lock()
+			gp.axes[event.Number] = event.Value
+			gp.direction = gp.axisDirection()
+			gp.mu.Unlock()
+		}
+	}
+}
+
+func (gp *GamepadController) axisDirection() Direction {
+	for _, pair := range [][2]uint8{{6, 7}, {0, 1}} {
+		x, y := gp.axes[pair[0]], gp.axes[pair[1]]
+		switch {
+		case y <= -GamepadDeadzone:
+			return DirUp
+		case y >= GamepadDeadzone:
+			return DirDown
+		case x <= -GamepadDeadzone:
+			return DirLeft
+		case x >= GamepadDeadzone:
+			return DirRight
+		}
+	}
+	return DirNone
+}
+
+func (gp *GamepadController) Direction(g *Game, s *Snake) Direction {
+	gp.mu.Lock()
+	defer gp.mu.Unlock()
+
+	if !s.CanTurn(gp.direction) {
+		return s.Direction
+	}
+	return gp.direction
+}
+
+func (gp *GamepadController) Close() error {
+	return gp.file.Close()
+}
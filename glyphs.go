@@ -0,0 +1,42 @@
+package main
+
+var asciiGlyphs = map[rune]rune{
+	'●': '@',
+	'█': '#',
+	'▓': '#',
+	'▪': '#',
+	'░': '.',
+	'▒': '%',
+	'·': '.',
+	'◆': '*',
+	'◇': '+',
+	'★': '$',
+	'≈': '~',
+	'•': 'o',
+	'♥': 'H',
+	'¤': 'S',
+	'✦': 'X',
+	'▛': 'B',
+	'▜': 'B',
+	'▙': 'B',
+	'▟': 'B',
+	'▌': '|',
+	'▐': '|',
+	'▀': '-',
+	'▄': '_',
+	'←': '<',
+	'→': '>',
+	'↑': '^',
+	'↓': 'v',
+}
+
+var glyphFallback = false
+
+func DisplayRune(ch rune) rune {
+	if glyphFallback {
+		if ascii, ok := asciiGlyphs[ch]; ok {
+			return ascii
+		}
+	}
+	return ch
+}
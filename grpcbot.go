@@ -0,0 +1,95 @@
+package main
+
+//go:generate protoc -I proto --go_out=. --go_opt=module=snake --go-grpc_out=. --go-grpc_opt=module=snake proto/bot.proto
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"snake/botpb"
+)
+
+const (
+	remoteBotPrefix  = "grpc:"
+	RemoteBotTimeout = 200 * time.Millisecond
+)
+
+type RemoteController struct {
+	Address string
+	Errors  int
+
+	conn   *grpc.ClientConn
+	client botpb.BotClient
+}
+
+func IsRemoteBot(name string) bool {
+	return strings.HasPrefix(name, remoteBotPrefix)
+}
+
+func DialRemoteBot(name string) (*RemoteController, error) {
+	address := strings.TrimPrefix(name, remoteBotPrefix)
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteController{Address: address, conn: conn, client: botpb.NewBotClient(conn)}, nil
+}
+
+func (r *RemoteController) Direction(g *Game, s *Snake) Direction {
+	ctx, cancel := context.WithTimeout(context.Background(), RemoteBotTimeout)
+	defer cancel()
+
+	reply, err := r.client.Move(ctx, g.BotState(s))
+	if err != nil {
+		r.Errors++
+		logger.Warn("bot remoto sem resposta", "endereco", r.Address, "erro", err)
+		return s.Direction
+	}
+
+	direction := Direction(reply.GetDirection())
+	if direction == DirNone || !s.CanTurn(direction) {
+		return s.Direction
+	}
+	return direction
+}
+
+func (r *RemoteController) Close() error {
+	return r.conn.Close()
+}
+
+func botPoint(p Point) *botpb.Point {
+	return &botpb.Point{X: int32(p.X), Y: int32(p.Y)}
+}
+
+func (g *Game) BotState(s *Snake) *botpb.GameState {
+	state := &botpb.GameState{
+		Mode:      g.Mode.String(),
+		Seed:      g.Seed,
+		Tick:      int32(g.PlayTicks),
+		Width:     int32(g.Width),
+		Height:    int32(g.Height),
+		Score:     int32(g.Score),
+		Level:     int32(g.Level),
+		Direction: botpb.Direction(s.Direction),
+		Food:      botPoint(g.Food.Position),
+		FoodKind:  modFoodKinds[g.Food.Type],
+	}
+	for _, p := range s.Body.All() {
+		state.Snake = append(state.Snake, botPoint(p))
+	}
+	for _, obs := range g.Obstacles {
+		if obs.IsSolid() {
+			state.Obstacles = append(state.Obstacles, botPoint(obs.Position))
+		}
+	}
+	for _, rival := range g.Rivals {
+		for _, p := range rival.Snake.Body.All() {
+			state.Rivals = append(state.Rivals, botPoint(p))
+		}
+	}
+	return state
+}
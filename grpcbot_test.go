@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"snake/botpb"
+)
+
+type foodSeekingBot struct {
+	botpb.UnimplementedBotServer
+	calls int
+	first *botpb.GameState
+}
+
+func (b *foodSeekingBot) Move(ctx context.Context, state *botpb.GameState) (*botpb.MoveReply, error) {
+	b.calls++
+	if b.first == nil {
+		b.first = state
+	}
+	head, food := state.GetSnake()[0], state.GetFood()
+	direction := botpb.Direction_DIRECTION_NONE
+	switch {
+	case food.GetX() > head.GetX():
+		direction = botpb.Direction_DIRECTION_RIGHT
+	case food.GetX() < head.GetX():
+		direction = botpb.Direction_DIRECTION_LEFT
+	case food.GetY() > head.GetY():
+		direction = botpb.Direction_DIRECTION_DOWN
+	case food.GetY() < head.GetY():
+		direction = botpb.Direction_DIRECTION_UP
+	}
+	return &botpb.MoveReply{Direction: direction}, nil
+}
+
+func TestRemoteBot(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	bot := &foodSeekingBot{}
+	botpb.RegisterBotServer(server, bot)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	name := remoteBotPrefix + listener.Addr().String()
+	remote, err := DialRemoteBot(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { remote.Close() })
+
+	t.Chdir(t.TempDir())
+	standings := RunBotMatch([]string{name}, map[string]func() Controller{name: func() Controller { return remote }},
+		ModeClassic, 1, 1, 200)
+	if bot.calls == 0 || remote.Errors > 0 {
+		t.Fatalf("bot remoto chamado %d vezes com %d falhas", bot.calls, remote.Errors)
+	}
+	if standings[0].Games != 1 || standings[0].Survived == 0 {
+		t.Errorf("partida do bot remoto nao rodou: %+v", standings[0])
+	}
+	if first := bot.first; first.GetMode() != "Classico" || first.GetWidth() != DefaultWidth || len(first.GetSnake()) != 3 {
+		t.Errorf("estado enviado ao bot: %v", first)
+	}
+}
+
+func TestRemoteBotUnavailable(t *testing.T) {
+	remote, err := DialRemoteBot(remoteBotPrefix + "127.0.0.1:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer remote.Close()
+
+	g := newTestGame(t, ModeClassic)
+	g.Snake.Direction = DirUp
+	if got := remote.Direction(g, &g.Snake); got != DirUp || remote.Errors != 1 {
+		t.Errorf("sem servidor: direcao %s com %d falhas, quer up com 1", got, remote.Errors)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"snake/term"
+)
+
+const (
+	HardcorePauses = 3
+	ReplaysDir     = "replays"
+)
+
+type Hardcore struct {
+	Pauses   int
+	Replay   string
+	Recorder *Recorder
+}
+
+func (g *Game) UsePause() bool {
+	if g.Mode != ModeHardcore {
+		return true
+	}
+	if g.Hardcore.Pauses >= HardcorePauses {
+		g.ShowToast("Hardcore: sem pausas restantes", term.ColorRed)
+		return false
+	}
+	g.Hardcore.Pauses++
+	return true
+}
+
+func (g *Game) PausesLeft() int {
+	return HardcorePauses - g.Hardcore.Pauses
+}
+
+func (g *Game) SubscribeHardcore() {
+	g.Events.Subscribe(EventGameStart, func(e Event) {
+		g.CloseHardcoreReplay()
+		g.Hardcore = Hardcore{}
+		if g.Mode == ModeHardcore && g.Persists() && g.Recorder == nil {
+			g.StartHardcoreReplay()
+		}
+	})
+	g.Events.Subscribe(EventDeath, func(e Event) {
+		if g.State == StateGameOver && g.Hardcore.Recorder != nil {
+			g.Hardcore.Recorder.Mark(deathMarker)
+			g.CloseHardcoreReplay()
+			g.ShowToast("Replay salvo em "+g.Hardcore.Replay, term.ColorCyan)
+		}
+	})
+}
+
+func (g *Game) StartHardcoreReplay() {
+	if err := os.MkdirAll(ReplaysDir, 0755); err != nil {
+		logger.Error("falha ao criar pasta de replays", "erro", err)
+		return
+	}
+	path := filepath.Join(ReplaysDir, fmt.Sprintf("hardcore-%s.cast", time.Now().Format("20060102-150405")))
+	width, height := screen.Size()
+	recorder, err := NewRecorder(path, width, height)
+	if err != nil {
+		logger.Error("falha ao gravar replay do hardcore", "erro", err)
+		return
+	}
+	g.Hardcore.Recorder = recorder
+	g.Hardcore.Replay = path
+	g.Recorder = recorder
+}
+
+func (g *Game) CloseHardcoreReplay() {
+	if g.Hardcore.Recorder == nil {
+		return
+	}
+	if err := g.Hardcore.Recorder.Close(); err != nil {
+		logger.Error("falha ao salvar replay do hardcore", "erro", err)
+	}
+	if g.Recorder == g.Hardcore.Recorder {
+		g.Recorder = nil
+	}
+	g.Hardcore.Recorder = nil
+}
+
+func (g *Game) HardcoreHUD() string {
+	return fmt.Sprintf("| Pausas: %d ", g.PausesLeft())
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"snake/term"
+)
+
+var helpControls = []string{
+	"P         Pausar (Start no controle)",
+	"ESC       Sair (pede confirmacao)",
+	"F3        Painel de depuracao",
+	"1-6       Modificadores (no menu)",
+}
+
+var helpFoods = []struct {
+	Glyph  rune
+	Name   string
+	Points string
+}{
+	{'◆', "Comida normal", "10+"},
+	{'★', "Power-up", "50+"},
+	{'•', "Bolinha (Batalha)", "5"},
+	{'♥', "Coracao (Cooperativo)", "revive"},
+	{'¤', "Divisao (divide a cobra)", "30+"},
+	{'✦', "Chefe (acerte 5 vezes)", "20+200"},
+	{'▓', "Obstaculo", "evite!"},
+}
+
+var modeRules = map[GameMode][]string{
+	ModeClassic:  {"A cada 50 pontos = +1 nivel", "Mais nivel = mais rapido + obstaculos"},
+	ModeBattle:   {"Rivais disputam a mesma comida", "Encostar em um rival mata voce"},
+	ModeCoop:     {"P2 usa W A S D", "Pegue o coracao em 20s para reviver"},
+	ModeLives:    {"Ao morrer, renasce com metade do tamanho", "Fica invulneravel por alguns instantes"},
+	ModeSandbox:  {"X liga/desliga colisoes", "I J K L cursor, O obstaculo, F comida", "Com HJKL ou IJKL, use as setas para a cobra"},
+	ModeCasual:   {"Sem recorde", "U desfaz ate 5 movimentos apos morrer", "A cada 100 pontos um checkpoint; K volta a ele"},
+	ModeTutorial: {"Siga as instrucoes no topo da tela"},
+	ModeFog:      {"So se enxerga perto da cabeca da cobra", "◇ na borda indica o lado da comida"},
+	ModeWeekly:   {"Modificadores e semente mudam toda segunda (UTC)", "Recorde proprio para cada semana"},
+	ModeHunger:   {"Perde 1 ponto por segundo", "Sem comer por 10s a cobra encolhe ate morrer de fome"},
+	ModeDebris:   {"Cada power-up solta 2 segmentos da cauda", "Os segmentos soltos viram paredes permanentes (▒)"},
+	ModeRisk:     {"Comida nas zonas piscantes vale o triplo", "As zonas tem mais obstaculos e ganham paredes com o tempo"},
+	ModeShrink:   {"A cada 20s a borda fecha um anel", "O anel seguinte pisca 5s antes; sobreviva!"},
+	ModeHardcore: {"Uma vida, sem desfazer e sem checkpoints", "So 3 pausas; o replay da partida e salvo sozinho"},
+	ModeZen:      {"Sem obstaculos, sem pontos e sem game over", "As bordas dao a volta e a cobra atravessa o proprio corpo"},
+	ModePuzzle:   {"A cobra so anda quando voce aperta uma direcao", "Coma toda a comida antes de acabarem os movimentos"},
+}
+
+func (g *Game) ToggleHelp() {
+	if !g.ShowHelp && g.State == StatePlaying && !g.UserPaused && !g.UsePause() {
+		return
+	}
+	g.ShowHelp = !g.ShowHelp
+}
+
+func (g *Game) TogglePause() {
+	if g.State != StatePlaying {
+		return
+	}
+	if !g.UserPaused && !g.ShowHelp && !g.UsePause() {
+		return
+	}
+	g.UserPaused = !g.UserPaused
+}
+
+func (g *Game) Paused() bool {
+	return (g.ConfirmQuit && g.Mode != ModeHardcore) || g.ShowHelp || g.UserPaused
+}
+
+func (g *Game) HelpLines() []string {
+	lines := []string{"AJUDA", "", "CONTROLES:", fmt.Sprintf("  %-9s Movimentar a cobra", g.Bindings().Label),
+		fmt.Sprintf("  %-9s Mostrar/ocultar esta ajuda", g.HelpKeyLabel())}
+	for _, line := range helpControls {
+		lines = append(lines, "  "+line)
+	}
+	if g.VenomMode() {
+		lines = append(lines, fmt.Sprintf("  %-9s Cuspir veneno (destroi obstaculos)", keyLabel(g.Settings.VenomKey)))
+	} else {
+		lines = append(lines, fmt.Sprintf("  %-9s Segure para correr (gasta folego)", keyLabel(g.Settings.BoostKey)))
+	}
+	lines = append(lines, fmt.Sprintf("  %-9s Acelerar / desacelerar",
+		g.Settings.SpeedUpKey+" / "+g.Settings.SpeedDownKey))
+
+	lines = append(lines, "", "ITENS:")
+	for _, food := range helpFoods {
+		lines = append(lines, fmt.Sprintf("  %c %-22s %s", food.Glyph, food.Name, food.Points))
+	}
+
+	lines = append(lines, "", fmt.Sprintf("MODO %s:", g.Mode))
+	for _, rule := range modeRules[g.Mode] {
+		lines = append(lines, "  "+rule)
+	}
+
+	return append(lines, "", g.HelpKeyLabel()+" para voltar ao jogo")
+}
+
+func keyLabel(key string) string {
+	if key == " " {
+		return "Espaco"
+	}
+	return strings.ToUpper(key)
+}
+
+func (g *Game) DrawHelp() {
+	lines := g.HelpLines()
+
+	box := BoxLines(lines, 0)
+
+	centerX, centerY := g.Camera.Center()
+	startX := max(0, centerX-BoxWidth(box)/2)
+	startY := max(0, centerY-len(box)/2)
+
+	DrawBox(startX, startY, box, func(i int) term.Attribute {
+		if i == 1 {
+			return term.ColorYellow | term.AttrBold
+		}
+		return term.ColorCyan
+	})
+}
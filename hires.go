@@ -0,0 +1,37 @@
+package main
+
+import "snake/term"
+
+var rendererNames = []string{"texto", "hires"}
+
+func IsValidRenderer(name string) bool {
+	for _, renderer := range rendererNames {
+		if renderer == name {
+			return true
+		}
+	}
+	return false
+}
+
+func SetHalfBlock(x, y int, lower bool, ch rune, fg, bg term.Attribute) {
+	width, height := screen.Size()
+	if x < 0 || y < 0 || x >= width || y >= height {
+		return
+	}
+
+	color := fg
+	if ch == ' ' {
+		color = bg
+	}
+
+	top, bottom := term.ColorDefault, term.ColorDefault
+	if cell := screen.CellBuffer()[y*width+x]; cell.Ch == '▀' {
+		top, bottom = cell.Fg, cell.Bg
+	}
+	if lower {
+		bottom = color &^ term.AttrBold
+	} else {
+		top = color
+	}
+	screen.SetCell(x, y, '▀', top, bottom)
+}
@@ -0,0 +1,75 @@
+package main
+
+import "time"
+
+const (
+	MaxUndoMoves = 5
+	UndoPenalty  = 20
+	UndoFreeze   = 8
+)
+
+type Snapshot struct {
+	Snake     Snake
+	Food      Food
+	Score     int
+	Level     int
+	Speed     time.Duration
+	Obstacles []Obstacle
+	PlayTicks int
+}
+
+func (g *Game) TakeSnapshot() Snapshot {
+	snake := g.Snake
+	snake.Body = g.Snake.Body.Clone()
+
+	return Snapshot{
+		Snake:     snake,
+		Food:      g.Food,
+		Score:     g.Score,
+		Level:     g.Level,
+		Speed:     g.Speed,
+		Obstacles: append([]Obstacle{}, g.Obstacles...),
+		PlayTicks: g.PlayTicks,
+	}
+}
+
+func (g *Game) RestoreSnapshot(snapshot Snapshot) {
+	g.Snake = snapshot.Snake
+	g.Food = snapshot.Food
+	g.Score = snapshot.Score
+	g.Level = snapshot.Level
+	g.Speed = snapshot.Speed
+	g.Obstacles = snapshot.Obstacles
+	g.PlayTicks = snapshot.PlayTicks
+	g.RebuildOccupancy()
+}
+
+func (g *Game) PushHistory() {
+	g.History = append(g.History, g.TakeSnapshot())
+	if len(g.History) > MaxUndoMoves {
+		g.History = g.History[1:]
+	}
+}
+
+func (g *Game) CanUndo() bool {
+	return g.Mode == ModeCasual && g.State == StateGameOver && len(g.History) > 0
+}
+
+func (g *Game) Undo() {
+	if !g.CanUndo() {
+		return
+	}
+
+	g.Twin = Snake{}
+	g.RestoreSnapshot(g.History[0])
+	g.History = nil
+	g.Score = max(0, g.Score-UndoPenalty)
+	g.Undos++
+	g.GameOver = false
+	g.State = StatePlaying
+	g.ShakeFrames = 0
+	g.FlashFrames = 0
+	g.FreezeTicks = UndoFreeze
+	g.Metrics.DeathCause = ""
+	g.ShowSummary = false
+}
@@ -0,0 +1,39 @@
+package main
+
+import "time"
+
+const TurnRepeatGap = 100 * time.Millisecond
+
+type TurnHold struct {
+	Turn      int
+	LastPress time.Time
+	HeldUntil time.Time
+}
+
+func (g *Game) SteerPlayer(direction Direction) {
+	previous := g.Snake.Direction
+	g.TurnPlayer(direction)
+	if g.Snake.Direction != previous {
+		g.RecordTurn(g.Snake.Direction)
+	}
+}
+
+func (g *Game) PressTurn(turn int) bool {
+	h := &g.TurnHold
+	now := time.Now()
+	repeat := turn == h.Turn && now.Sub(h.LastPress) < TurnRepeatGap
+	h.Turn, h.LastPress = turn, now
+	if !repeat {
+		return true
+	}
+	if g.Settings.HoldTurn {
+		h.HeldUntil = now.Add(TurnRepeatGap)
+	}
+	return false
+}
+
+func (g *Game) UpdateTurnHold() {
+	if g.Settings.HoldTurn && time.Now().Before(g.TurnHold.HeldUntil) {
+		g.SteerPlayer(g.Snake.Direction.Rotate(g.TurnHold.Turn))
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	HungerLimit    = 10 * time.Second
+	HungerDrain    = time.Second
+	HungerBarWidth = 10
+)
+
+type Hunger struct {
+	SinceMeal time.Duration
+	Drain     time.Duration
+}
+
+func (g *Game) SubscribeHunger() {
+	g.Events.Subscribe(EventFoodEaten, func(e Event) {
+		g.Hunger.SinceMeal = 0
+	})
+}
+
+func (g *Game) UpdateHunger() {
+	if g.Mode != ModeHunger || g.State != StatePlaying {
+		return
+	}
+
+	elapsed := g.TickInterval()
+	g.Hunger.SinceMeal += elapsed
+	g.Hunger.Drain += elapsed
+
+	for g.Hunger.Drain >= HungerDrain {
+		g.Hunger.Drain -= HungerDrain
+		g.Score = max(0, g.Score-1)
+
+		if g.Hunger.SinceMeal < HungerLimit {
+			continue
+		}
+		if g.Snake.Body.Len() <= 2 {
+			g.Metrics.DeathCause = "fome"
+			g.EndGame(g.Snake.Body.Head())
+			return
+		}
+		g.Occupancy.Remove(LayerPlayer, g.Snake.Body.PopBack())
+	}
+}
+
+func (g *Game) HungerBar() string {
+	left := max(0, HungerLimit-g.Hunger.SinceMeal)
+	filled := int(left * HungerBarWidth / HungerLimit)
+	return strings.Repeat("█", filled) + strings.Repeat("░", HungerBarWidth-filled)
+}
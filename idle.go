@@ -0,0 +1,41 @@
+package main
+
+import (
+	"time"
+
+	"snake/term"
+)
+
+func (g *Game) IdleTimeout() time.Duration {
+	return time.Duration(g.Settings.IdlePauseSeconds) * time.Second
+}
+
+func (g *Game) NoteInput() bool {
+	g.LastInput = time.Now()
+	if !g.IdlePaused {
+		return false
+	}
+	g.IdlePaused = false
+	g.UserPaused = false
+	return true
+}
+
+func (g *Game) CheckIdle() {
+	if g.IdleTimeout() <= 0 || g.State != StatePlaying || g.Paused() || g.PlayerController != nil || g.TurnBased() {
+		return
+	}
+	if time.Since(g.LastInput) >= g.IdleTimeout() {
+		if !g.UsePause() {
+			g.LastInput = time.Now()
+			return
+		}
+		g.IdlePaused = true
+		g.UserPaused = true
+	}
+}
+
+func (g *Game) DimSetter(setCell CellSetter) CellSetter {
+	return func(x, y int, ch rune, fg, bg term.Attribute) {
+		setCell(x, y, ch, term.ColorBlack|term.AttrBold, term.ColorDefault)
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"math/rand"
+
+	"snake/term"
+)
+
+type FoodBehavior interface {
+	Weight() int
+	OnSpawn(g *Game, f *Food)
+	OnEaten(g *Game, f *Food) int
+	Tick(g *Game, f *Food)
+	Render(g *Game, f *Food) (rune, term.Attribute)
+}
+
+type ObstacleType int
+
+const (
+	WallObstacle ObstacleType = iota
+)
+
+type ObstacleState int
+
+const (
+	ObstacleSolid ObstacleState = iota
+	ObstacleTelegraph
+)
+
+const TelegraphTicks = 7
+
+type Obstacle struct {
+	Position Point
+	Type     ObstacleType
+	State    ObstacleState
+	SolidIn  int
+}
+
+func (o *Obstacle) IsSolid() bool {
+	return o.State == ObstacleSolid
+}
+
+type ObstacleBehavior interface {
+	OnSpawn(g *Game, o *Obstacle)
+	OnHit(g *Game, o *Obstacle) bool
+	Tick(g *Game, o *Obstacle)
+	Render(g *Game, o *Obstacle) (rune, term.Attribute)
+}
+
+var (
+	foodBehaviors     = map[FoodType]FoodBehavior{}
+	foodTypes         []FoodType
+	obstacleBehaviors = map[ObstacleType]ObstacleBehavior{}
+)
+
+func RegisterFood(foodType FoodType, behavior FoodBehavior) {
+	if _, exists := foodBehaviors[foodType]; !exists {
+		foodTypes = append(foodTypes, foodType)
+	}
+	foodBehaviors[foodType] = behavior
+}
+
+func RegisterObstacle(obstacleType ObstacleType, behavior ObstacleBehavior) {
+	obstacleBehaviors[obstacleType] = behavior
+}
+
+func RandomFoodType(rng *rand.Rand, weights map[FoodType]int) FoodType {
+	weight := func(foodType FoodType) int {
+		if weights != nil {
+			return weights[foodType]
+		}
+		return foodBehaviors[foodType].Weight()
+	}
+
+	total := 0
+	for _, foodType := range foodTypes {
+		total += weight(foodType)
+	}
+	if total == 0 {
+		return NormalFood
+	}
+
+	roll := rng.Intn(total)
+	for _, foodType := range foodTypes {
+		roll -= weight(foodType)
+		if roll < 0 {
+			return foodType
+		}
+	}
+	return NormalFood
+}
+
+type NormalFoodBehavior struct{}
+
+func (NormalFoodBehavior) Weight() int { return 80 }
+
+func (NormalFoodBehavior) OnSpawn(g *Game, f *Food) {}
+
+func (NormalFoodBehavior) OnEaten(g *Game, f *Food) int { return 10 }
+
+func (NormalFoodBehavior) Tick(g *Game, f *Food) {}
+
+func (NormalFoodBehavior) Render(g *Game, f *Food) (rune, term.Attribute) {
+	return '◆', term.ColorRed
+}
+
+type PowerUpFoodBehavior struct{}
+
+func (PowerUpFoodBehavior) Weight() int { return 20 }
+
+func (PowerUpFoodBehavior) OnSpawn(g *Game, f *Food) {}
+
+func (PowerUpFoodBehavior) OnEaten(g *Game, f *Food) int {
+	g.Emit(Event{Type: EventPowerUpActivated, Position: f.Position, Food: f.Type, Points: 50})
+	return 50
+}
+
+func (PowerUpFoodBehavior) Tick(g *Game, f *Food) {}
+
+func (PowerUpFoodBehavior) Render(g *Game, f *Food) (rune, term.Attribute) {
+	if g.Blink(5) {
+		return '★', term.ColorMagenta
+	}
+	return '★', term.ColorYellow
+}
+
+type WallObstacleBehavior struct{}
+
+func (WallObstacleBehavior) OnSpawn(g *Game, o *Obstacle) {}
+
+func (WallObstacleBehavior) OnHit(g *Game, o *Obstacle) bool { return true }
+
+func (WallObstacleBehavior) Tick(g *Game, o *Obstacle) {}
+
+func (WallObstacleBehavior) Render(g *Game, o *Obstacle) (rune, term.Attribute) {
+	return '▓', term.ColorWhite
+}
+
+func init() {
+	RegisterFood(NormalFood, NormalFoodBehavior{})
+	RegisterFood(PowerUpFood, PowerUpFoodBehavior{})
+	RegisterObstacle(WallObstacle, WallObstacleBehavior{})
+}
+
+func (g *Game) ObstacleAt(p Point) *Obstacle {
+	i := g.Occupancy.ObstacleIndex(p)
+	if i < 0 || i >= len(g.Obstacles) {
+		return nil
+	}
+	return &g.Obstacles[i]
+}
+
+func (g *Game) HitObstacle(p Point) bool {
+	obs := g.ObstacleAt(p)
+	if obs == nil || !obs.IsSolid() {
+		return false
+	}
+	return obstacleBehaviors[obs.Type].OnHit(g, obs)
+}
+
+func (g *Game) TickItems() {
+	if g.Food.TTL > 0 {
+		g.Food.TTL -= g.TickInterval()
+		if g.Food.TTL <= 0 {
+			g.GenerateFood()
+		}
+	}
+
+	foodBehaviors[g.Food.Type].Tick(g, &g.Food)
+	for i := range g.Obstacles {
+		g.UpdateObstacleState(&g.Obstacles[i])
+		obstacleBehaviors[g.Obstacles[i].Type].Tick(g, &g.Obstacles[i])
+	}
+}
+
+func (g *Game) TelegraphObstacles(previous []Obstacle) {
+	existing := map[Point]bool{}
+	for _, obs := range previous {
+		if obs.IsSolid() {
+			existing[obs.Position] = true
+		}
+	}
+
+	for i := range g.Obstacles {
+		obs := &g.Obstacles[i]
+		if obs.IsSolid() && !existing[obs.Position] {
+			obs.State = ObstacleTelegraph
+			obs.SolidIn = TelegraphTicks
+		}
+	}
+}
+
+func (g *Game) UpdateObstacleState(o *Obstacle) {
+	if o.State != ObstacleTelegraph {
+		return
+	}
+	if o.SolidIn > 0 {
+		o.SolidIn--
+		return
+	}
+
+	if g.Occupancy.HasSnake(o.Position) {
+		return
+	}
+
+	o.State = ObstacleSolid
+}
@@ -0,0 +1,387 @@
+package main
+
+//go:generate protoc -I proto --go_out=. --go_opt=module=snake --go-grpc_out=. --go-grpc_opt=module=snake proto/match.proto
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"snake/botpb"
+	"snake/matchpb"
+	"snake/term"
+)
+
+const (
+	DefaultMatchPort  = ":7777"
+	MaxNetPlayers     = 3
+	netOutboxSize     = 8
+	netInputQueueSize = 4
+)
+
+type NetController struct {
+	queue []*matchpb.Input
+	Ack   uint32
+}
+
+func (c *NetController) Push(input *matchpb.Input) {
+	if len(c.queue) < netInputQueueSize {
+		c.queue = append(c.queue, input)
+	}
+}
+
+func (c *NetController) Direction(g *Game, s *Snake) Direction {
+	for len(c.queue) > 0 {
+		input := c.queue[0]
+		c.queue = c.queue[1:]
+		c.Ack = input.GetSeq()
+		if direction := Direction(input.GetDirection()); s.CanTurn(direction) {
+			return direction
+		}
+	}
+	return s.Direction
+}
+
+type NetClient struct {
+	Name    string
+	Role    matchpb.Role
+	RTT     time.Duration
+	Dropped int
+
+	control *NetController
+	rival   *Rival
+	chat    ChatLimiter
+	out     chan *matchpb.ServerMessage
+}
+
+type MatchServer struct {
+	matchpb.UnimplementedMatchServer
+	Address string
+	Clients []*NetClient
+
+	game     *Game
+	actions  chan<- func()
+	closed   chan struct{}
+	grpc     *grpc.Server
+	listener net.Listener
+}
+
+func ServeMatch(g *Game, address string, actions chan<- func()) (*MatchServer, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	s := &MatchServer{
+		Address:  listener.Addr().String(),
+		game:     g,
+		actions:  actions,
+		closed:   make(chan struct{}),
+		grpc:     grpc.NewServer(),
+		listener: listener,
+	}
+	matchpb.RegisterMatchServer(s.grpc, s)
+	go func() {
+		if err := s.grpc.Serve(listener); err != nil {
+			logger.Warn("servidor da partida parou", "erro", err)
+		}
+	}()
+	logger.Info("partida aberta na rede local", "endereco", s.Address)
+	return s, nil
+}
+
+func (s *MatchServer) Close() {
+	close(s.closed)
+	s.grpc.Stop()
+}
+
+func (s *MatchServer) do(action func()) {
+	done := make(chan struct{})
+	select {
+	case s.actions <- func() { action(); close(done) }:
+	case <-s.closed:
+		return
+	}
+	select {
+	case <-done:
+	case <-s.closed:
+	}
+}
+
+func (s *MatchServer) Join(stream matchpb.Match_JoinServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	hello := first.GetHello()
+	if hello == nil {
+		return errors.New("a conexao deve comecar com Hello")
+	}
+
+	client := &NetClient{
+		Name: strings.TrimSpace(hello.GetName()),
+		Role: hello.GetRole(),
+		out:  make(chan *matchpb.ServerMessage, netOutboxSize),
+	}
+	if client.Name == "" {
+		client.Name = "convidado"
+	}
+
+	var welcome *matchpb.Welcome
+	s.do(func() { welcome = s.game.AddNetClient(client) })
+	defer s.do(func() { s.game.RemoveNetClient(client) })
+
+	if err := stream.Send(&matchpb.ServerMessage{Message: &matchpb.ServerMessage_Welcome{Welcome: welcome}}); err != nil {
+		return err
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			s.handle(client, msg)
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-client.out:
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		case err := <-errs:
+			return err
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (s *MatchServer) handle(client *NetClient, msg *matchpb.ClientMessage) {
+	switch m := msg.GetMessage().(type) {
+	case *matchpb.ClientMessage_Input:
+		s.do(func() {
+			if client.control != nil {
+				client.control.Push(m.Input)
+			}
+		})
+	case *matchpb.ClientMessage_Ping:
+		s.do(func() {
+			client.RTT = time.Duration(m.Ping.GetRttMs()) * time.Millisecond
+		})
+		client.send(&matchpb.ServerMessage{Message: &matchpb.ServerMessage_Pong{
+			Pong: &matchpb.Pong{SentUnixNano: m.Ping.GetSentUnixNano()},
+		}})
+	case *matchpb.ClientMessage_Annotation:
+		s.do(func() { s.game.Annotate(client, m.Annotation) })
+	case *matchpb.ClientMessage_Chat:
+		s.do(func() { s.game.ReceiveChat(client, m.Chat.GetText()) })
+	}
+}
+
+func (c *NetClient) send(msg *matchpb.ServerMessage) bool {
+	select {
+	case c.out <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+func (g *Game) AddNetClient(c *NetClient) *matchpb.Welcome {
+	if c.Role == matchpb.Role_ROLE_PLAYER && g.NetPlayers() >= MaxNetPlayers {
+		c.Role = matchpb.Role_ROLE_SPECTATOR
+	}
+	if c.Role == matchpb.Role_ROLE_PLAYER {
+		c.control = &NetController{}
+	}
+	g.Server.Clients = append(g.Server.Clients, c)
+	g.SeatNetPlayers()
+	g.ShowToast(c.Name+" entrou na partida", term.ColorCyan)
+	return &matchpb.Welcome{Role: c.Role}
+}
+
+func (g *Game) RemoveNetClient(c *NetClient) {
+	for i, client := range g.Server.Clients {
+		if client == c {
+			g.Server.Clients = append(g.Server.Clients[:i], g.Server.Clients[i+1:]...)
+			break
+		}
+	}
+	if c.rival != nil {
+		c.rival.Controller = g.RivalController()
+	}
+	g.ShowToast(c.Name+" saiu da partida", term.ColorCyan)
+}
+
+func (g *Game) NetPlayers() int {
+	count := 0
+	for _, c := range g.Server.Clients {
+		if c.control != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func (g *Game) NetSlot(c *NetClient) int {
+	for i, r := range g.Rivals {
+		if c.rival == r {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func (g *Game) SeatNetPlayers() {
+	if g.State != StatePlaying {
+		return
+	}
+	for _, c := range g.Server.Clients {
+		if c.control == nil || g.NetSlot(c) >= 0 {
+			continue
+		}
+		c.rival = nil
+		for _, r := range g.Rivals {
+			if _, taken := r.Controller.(*NetController); !taken {
+				c.rival = r
+				break
+			}
+		}
+		if c.rival == nil {
+			g.SpawnRivals(1)
+			c.rival = g.Rivals[len(g.Rivals)-1]
+		}
+		c.rival.Controller = c.control
+	}
+}
+
+func snakeState(name string, s *Snake, score int, alive bool) *matchpb.SnakeState {
+	state := &matchpb.SnakeState{
+		Name:      name,
+		Direction: botpb.Direction(s.Direction),
+		Score:     int32(score),
+		Alive:     alive,
+	}
+	for _, p := range s.Body.All() {
+		state.Body = append(state.Body, botPoint(p))
+	}
+	return state
+}
+
+func (g *Game) RivalName(r *Rival) string {
+	if g.Server != nil {
+		for _, c := range g.Server.Clients {
+			if c.rival == r {
+				return c.Name
+			}
+		}
+	}
+	return "IA"
+}
+
+func (g *Game) Snapshot() *matchpb.Snapshot {
+	snap := &matchpb.Snapshot{
+		Tick:           int32(g.PlayTicks),
+		Running:        g.State == StatePlaying && !g.Paused() && !g.TurnBased(),
+		GameOver:       g.State == StateGameOver,
+		TickIntervalMs: int32(g.TickInterval().Milliseconds()),
+		Width:          int32(g.Width),
+		Height:         int32(g.Height),
+		Level:          int32(g.Level),
+		Slot:           -1,
+		Mode:           g.Mode.String(),
+		Snakes:         []*matchpb.SnakeState{snakeState("anfitriao", &g.Snake, g.Score, g.State != StateGameOver)},
+		Food:           botPoint(g.Food.Position),
+		Annotations:    g.AnnotationStates(),
+	}
+	for _, r := range g.Rivals {
+		snap.Snakes = append(snap.Snakes, snakeState(g.RivalName(r), &r.Snake, r.Score, r.Alive))
+	}
+	for _, pellet := range g.Pellets {
+		snap.Pellets = append(snap.Pellets, botPoint(pellet.Position))
+	}
+	for _, obs := range g.Obstacles {
+		if obs.IsSolid() {
+			snap.Obstacles = append(snap.Obstacles, botPoint(obs.Position))
+		}
+	}
+	return snap
+}
+
+func (g *Game) PublishSnapshot() {
+	if g.Server == nil || len(g.Server.Clients) == 0 || g.State == StateMenu || g.State == StateEditor {
+		return
+	}
+	g.SeatNetPlayers()
+	base := g.Snapshot()
+	for _, c := range g.Server.Clients {
+		snap := &matchpb.Snapshot{
+			Tick:           base.Tick,
+			Running:        base.Running,
+			GameOver:       base.GameOver,
+			TickIntervalMs: base.TickIntervalMs,
+			Width:          base.Width,
+			Height:         base.Height,
+			Level:          base.Level,
+			Snakes:         base.Snakes,
+			Food:           base.Food,
+			Pellets:        base.Pellets,
+			Obstacles:      base.Obstacles,
+			Slot:           int32(g.NetSlot(c)),
+			Mode:           base.Mode,
+			Annotations:    base.Annotations,
+		}
+		if c.control != nil {
+			snap.Ack = c.control.Ack
+		}
+		if !c.send(&matchpb.ServerMessage{Message: &matchpb.ServerMessage_Snapshot{Snapshot: snap}}) {
+			c.Dropped++
+		}
+	}
+}
+
+func RoleName(role matchpb.Role) string {
+	switch role {
+	case matchpb.Role_ROLE_PLAYER:
+		return "jogador"
+	case matchpb.Role_ROLE_OBSERVER:
+		return "observador"
+	}
+	return "espectador"
+}
+
+func (g *Game) NetHUD() string {
+	switch {
+	case g.Server != nil:
+		return fmt.Sprintf("| Rede: %d ", len(g.Server.Clients))
+	case g.Remote != nil:
+		return g.Remote.HUD()
+	}
+	return ""
+}
+
+func (g *Game) NetDebugLines() []string {
+	switch {
+	case g.Server != nil:
+		return g.Server.DebugLines()
+	case g.Remote != nil:
+		return g.Remote.DebugLines()
+	}
+	return nil
+}
+
+func (s *MatchServer) DebugLines() []string {
+	lines := []string{fmt.Sprintf(" REDE %s ", s.Address)}
+	for _, c := range s.Clients {
+		lines = append(lines, fmt.Sprintf(" %s (%s): rtt %v, perdidos %d ", c.Name, RoleName(c.Role), c.RTT, c.Dropped))
+	}
+	return lines
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"snake/matchpb"
+	"snake/term"
+)
+
+func TestPredictionAppliesPendingInputs(t *testing.T) {
+	p := Prediction{Base: Snake{Body: NewSnakeBody([]Point{{5, 5}, {6, 5}, {7, 5}}), Direction: DirLeft}}
+	p.Input(DirUp)
+	p.Step()
+	p.Step()
+
+	s := p.Snake()
+	if s.Body.Head() != (Point{X: 5, Y: 3}) || s.Direction != DirUp {
+		t.Errorf("previsao ignorou a entrada pendente: %v %v", s.Body.Points(), s.Direction)
+	}
+	if p.Base.Body.Head() != (Point{X: 5, Y: 5}) {
+		t.Errorf("previsao alterou o estado autoritativo: %v", p.Base.Body.Points())
+	}
+}
+
+func TestPredictionStepsAreCapped(t *testing.T) {
+	p := Prediction{Base: Snake{Body: NewSnakeBody([]Point{{20, 5}, {21, 5}, {22, 5}}), Direction: DirLeft}}
+	for range MaxPredictionSteps * 2 {
+		p.Step()
+	}
+	s := p.Snake()
+	if head := s.Body.Head(); head.X != 20-MaxPredictionSteps {
+		t.Errorf("previsao andou ate %v", head)
+	}
+}
+
+func TestPredictionReconcile(t *testing.T) {
+	p := Prediction{Base: Snake{Body: NewSnakeBody([]Point{{5, 5}, {6, 5}, {7, 5}}), Direction: DirLeft}}
+	p.Input(DirUp)
+	second := p.Input(DirLeft)
+	p.Step()
+
+	p.Reconcile(Snake{Body: NewSnakeBody([]Point{{5, 4}, {5, 5}, {6, 5}}), Direction: DirUp}, 1, 1, 0)
+	if len(p.Pending) != 1 || p.Pending[0].Seq != second {
+		t.Fatalf("entradas pendentes apos o ack: %+v", p.Pending)
+	}
+	if p.Corrections != 0 || p.Steps != 0 {
+		t.Errorf("previsao certa contada como correcao: %d (%d passos)", p.Corrections, p.Steps)
+	}
+
+	p.Reconcile(Snake{Body: NewSnakeBody([]Point{{5, 3}, {5, 4}, {5, 5}}), Direction: DirUp}, 2, 1, 2)
+	if p.Corrections != 1 {
+		t.Errorf("correcao nao contada: %d", p.Corrections)
+	}
+	s := p.Snake()
+	if head := s.Body.Head(); head != (Point{X: 5, Y: 1}) || len(p.Pending) != 0 {
+		t.Errorf("reconciliacao nao seguiu o servidor: %v, pendentes %+v", head, p.Pending)
+	}
+}
+
+func pumpActions(t *testing.T, actions <-chan func(), done func() bool) {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	for !done() {
+		select {
+		case action := <-actions:
+			action()
+		case <-deadline:
+			t.Fatal("tempo esgotado esperando a rede")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestMatchLoopback(t *testing.T) {
+	g := newTestGame(t, ModeClassic)
+	clearBoard(g)
+	actions := make(chan func(), 8)
+	server, err := ServeMatch(g, "127.0.0.1:0", actions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+	g.Server = server
+
+	dialed := make(chan *RemoteLink, 1)
+	go func() {
+		link, err := DialMatch(server.Address, "ana", matchpb.Role_ROLE_PLAYER)
+		if err != nil {
+			t.Error(err)
+		}
+		dialed <- link
+	}()
+	var link *RemoteLink
+	pumpActions(t, actions, func() bool {
+		select {
+		case link = <-dialed:
+			return true
+		default:
+			return false
+		}
+	})
+	if link == nil {
+		t.FailNow()
+	}
+	t.Cleanup(func() { link.Close() })
+	if link.Role != matchpb.Role_ROLE_PLAYER || len(server.Clients) != 1 {
+		t.Fatalf("cliente nao entrou como jogador: %v, %d clientes", link.Role, len(server.Clients))
+	}
+
+	mirror := newTestGame(t, ModeClassic)
+	mirror.Remote = link
+	messages := make(chan *matchpb.ServerMessage, 16)
+	go link.Receive(messages, make(chan error, 1))
+	snapshot := func() {
+		t.Helper()
+		for {
+			select {
+			case msg := <-messages:
+				if snap := msg.GetSnapshot(); snap != nil {
+					mirror.ApplySnapshot(snap)
+					return
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("snapshot nao chegou")
+			}
+		}
+	}
+
+	g.PublishSnapshot()
+	if len(g.Rivals) != 1 {
+		t.Fatalf("jogador da rede nao ganhou uma cobra: %d rivais", len(g.Rivals))
+	}
+	rival := g.Rivals[0]
+	rival.Snake = Snake{Body: NewSnakeBody([]Point{{10, 10}, {11, 10}, {12, 10}}), Direction: DirLeft}
+	g.RebuildOccupancy()
+	g.PublishSnapshot()
+	snapshot()
+	snapshot()
+	if link.Slot != 1 || len(mirror.Rivals) != 1 || mirror.Snake.Body.Head() != (Point{X: 10, Y: 10}) {
+		t.Fatalf("espelho fora do estado do anfitriao: slot %d, %d rivais, cabeca %v",
+			link.Slot, len(mirror.Rivals), mirror.Snake.Body.Head())
+	}
+
+	mirror.HandleRemoteKey(term.Event{Type: term.EventKey, Key: term.KeyArrowUp})
+	control := rival.Controller.(*NetController)
+	pumpActions(t, actions, func() bool { return len(control.queue) > 0 })
+
+	g.MoveRivals()
+	g.PublishSnapshot()
+	snapshot()
+	if rival.Snake.Direction != DirUp || control.Ack != 1 {
+		t.Fatalf("entrada nao aplicada no anfitriao: %v, ack %d", rival.Snake.Direction, control.Ack)
+	}
+	if len(link.Prediction.Pending) != 0 || mirror.Snake.Body.Head() != (Point{X: 10, Y: 9}) {
+		t.Errorf("cliente nao reconciliou: %+v, cabeca %v", link.Prediction.Pending, mirror.Snake.Body.Head())
+	}
+	if link.Dropped != 0 || link.Received != 3 {
+		t.Errorf("snapshots recebidos %d, perdidos %d", link.Received, link.Dropped)
+	}
+}
@@ -0,0 +1,400 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"snake/botpb"
+	"snake/matchpb"
+	"snake/term"
+)
+
+const (
+	MaxPredictionSteps = 5
+	netPingInterval    = time.Second
+)
+
+type PendingInput struct {
+	Seq       uint32
+	Direction Direction
+}
+
+type Prediction struct {
+	Base        Snake
+	Pending     []PendingInput
+	Steps       int
+	Corrections int
+
+	seq uint32
+}
+
+func (p *Prediction) Input(direction Direction) uint32 {
+	p.seq++
+	p.Pending = append(p.Pending, PendingInput{Seq: p.seq, Direction: direction})
+	return p.seq
+}
+
+func (p *Prediction) Step() {
+	p.Steps = min(p.Steps+1, MaxPredictionSteps)
+}
+
+func (p *Prediction) Snake() Snake {
+	return p.advance(p.Steps)
+}
+
+func (p *Prediction) advance(steps int) Snake {
+	s := Snake{Body: p.Base.Body.Clone(), Direction: p.Base.Direction}
+	for i := 0; i < steps && s.Body.Len() > 0; i++ {
+		if i < len(p.Pending) && s.CanTurn(p.Pending[i].Direction) {
+			s.Direction = p.Pending[i].Direction
+		}
+		s.Body.PushFront(s.Body.Head().Move(s.Direction))
+		s.Body.PopBack()
+	}
+	return s
+}
+
+func (p *Prediction) Reconcile(base Snake, ack uint32, elapsed, lead int) {
+	if elapsed > 0 && p.Base.Body.Len() > 0 && base.Body.Len() > 0 {
+		predicted := p.advance(elapsed)
+		if predicted.Body.Head() != base.Body.Head() {
+			p.Corrections++
+		}
+	}
+
+	p.Base = base
+	for len(p.Pending) > 0 && p.Pending[0].Seq <= ack {
+		p.Pending = p.Pending[1:]
+	}
+	p.Steps = min(lead, MaxPredictionSteps)
+}
+
+type RemoteLink struct {
+	Address    string
+	Role       matchpb.Role
+	Slot       int
+	Prediction Prediction
+	RTT        time.Duration
+	Interval   time.Duration
+	Tick       int32
+	Received   int
+	Dropped    int
+	Running    bool
+	GameOver   bool
+	Mode       string
+	Cursor     ObserverCursor
+
+	conn   *grpc.ClientConn
+	stream matchpb.Match_JoinClient
+}
+
+func DialMatch(address, name string, role matchpb.Role) (*RemoteLink, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	link := &RemoteLink{Address: address, Slot: -1, Interval: 150 * time.Millisecond, conn: conn}
+	if link.stream, err = matchpb.NewMatchClient(conn).Join(context.Background()); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	hello := &matchpb.Hello{Name: name, Role: role}
+	if err := link.stream.Send(&matchpb.ClientMessage{Message: &matchpb.ClientMessage_Hello{Hello: hello}}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	reply, err := link.stream.Recv()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	welcome := reply.GetWelcome()
+	if welcome == nil {
+		conn.Close()
+		return nil, errors.New("o servidor nao respondeu com Welcome")
+	}
+	link.Role = welcome.GetRole()
+	return link, nil
+}
+
+func (l *RemoteLink) Close() error {
+	return l.conn.Close()
+}
+
+func (l *RemoteLink) Receive(messages chan<- *matchpb.ServerMessage, errs chan<- error) {
+	for {
+		msg, err := l.stream.Recv()
+		if err != nil {
+			errs <- err
+			return
+		}
+		messages <- msg
+	}
+}
+
+func (l *RemoteLink) Send(msg *matchpb.ClientMessage) {
+	if err := l.stream.Send(msg); err != nil {
+		logger.Warn("falha ao enviar para a partida", "endereco", l.Address, "erro", err)
+	}
+}
+
+func (l *RemoteLink) Ping(now time.Time) {
+	l.Send(&matchpb.ClientMessage{Message: &matchpb.ClientMessage_Ping{Ping: &matchpb.Ping{
+		SentUnixNano: now.UnixNano(),
+		RttMs:        int32(l.RTT.Milliseconds()),
+	}}})
+}
+
+func (l *RemoteLink) Playing() bool {
+	return l.Role == matchpb.Role_ROLE_PLAYER && l.Slot >= 0
+}
+
+func (l *RemoteLink) Lead() int {
+	if !l.Running || l.Interval <= 0 {
+		return 0
+	}
+	return min(int((l.RTT/2+l.Interval/2)/l.Interval), MaxPredictionSteps)
+}
+
+func (l *RemoteLink) HUD() string {
+	role := RoleName(l.Role)
+	if l.Role == matchpb.Role_ROLE_PLAYER && !l.Playing() {
+		role = RoleName(matchpb.Role_ROLE_SPECTATOR)
+	}
+	msg := fmt.Sprintf("| Rede: %s %s (%v) ", role, l.Mode, l.RTT.Round(time.Millisecond))
+	if l.Observing() {
+		msg += fmt.Sprintf("| Marca %c [1-%d] ", annotationGlyphs[l.Cursor.Glyph], len(annotationGlyphs))
+	}
+	if l.GameOver {
+		msg += "| FIM - aguardando o anfitriao "
+	}
+	return msg
+}
+
+func (l *RemoteLink) DebugLines() []string {
+	return []string{
+		fmt.Sprintf(" REDE %s ", l.Address),
+		fmt.Sprintf(" rtt: %v ", l.RTT.Round(time.Millisecond)),
+		fmt.Sprintf(" snapshots: %d (perdidos %d) ", l.Received, l.Dropped),
+		fmt.Sprintf(" previsao: %d passos, %d pendentes ", l.Prediction.Steps, len(l.Prediction.Pending)),
+		fmt.Sprintf(" correcoes: %d ", l.Prediction.Corrections),
+	}
+}
+
+func snakeFromState(state *matchpb.SnakeState) Snake {
+	body := make([]Point, 0, len(state.GetBody()))
+	for _, p := range state.GetBody() {
+		body = append(body, pointFromBot(p))
+	}
+	return Snake{Body: NewSnakeBody(body), Direction: Direction(state.GetDirection())}
+}
+
+func pointFromBot(p *botpb.Point) Point {
+	return Point{X: int(p.GetX()), Y: int(p.GetY())}
+}
+
+func (g *Game) ApplySnapshot(snap *matchpb.Snapshot) {
+	l := g.Remote
+	elapsed := 0
+	if l.Received > 0 && snap.GetTick() > l.Tick {
+		elapsed = int(snap.GetTick() - l.Tick)
+		l.Dropped += elapsed - 1
+	}
+	l.Received++
+	l.Tick = snap.GetTick()
+	l.Slot = int(snap.GetSlot())
+	l.Running = snap.GetRunning()
+	l.GameOver = snap.GetGameOver()
+	l.Mode = snap.GetMode()
+	if ms := snap.GetTickIntervalMs(); ms > 0 {
+		l.Interval = time.Duration(ms) * time.Millisecond
+	}
+
+	g.Width, g.Height = int(snap.GetWidth()), int(snap.GetHeight())
+	g.Level = int(snap.GetLevel())
+	g.Food = Food{Position: pointFromBot(snap.GetFood())}
+	g.Pellets = g.Pellets[:0]
+	for _, p := range snap.GetPellets() {
+		g.Pellets = append(g.Pellets, Food{Position: pointFromBot(p), Type: PelletFood})
+	}
+	g.Obstacles = g.Obstacles[:0]
+	for _, p := range snap.GetObstacles() {
+		g.Obstacles = append(g.Obstacles, Obstacle{Position: pointFromBot(p), Type: WallObstacle})
+	}
+
+	own := max(l.Slot, 0)
+	g.Rivals = g.Rivals[:0]
+	for i, state := range snap.GetSnakes() {
+		if i == own {
+			g.Score = int(state.GetScore())
+			if l.Playing() && state.GetAlive() {
+				l.Prediction.Reconcile(snakeFromState(state), snap.GetAck(), elapsed, l.Lead())
+				g.Snake = l.Prediction.Snake()
+			} else {
+				g.Snake = snakeFromState(state)
+			}
+			continue
+		}
+		g.Rivals = append(g.Rivals, &Rival{
+			Snake: snakeFromState(state),
+			Score: int(state.GetScore()),
+			Alive: state.GetAlive(),
+			Color: rivalColors[len(g.Rivals)%len(rivalColors)],
+		})
+	}
+	g.ApplyAnnotations(snap.GetAnnotations())
+	if l.Observing() && g.CheckWallCollision(l.Cursor.Position) {
+		l.Cursor.Position = Point{X: g.Width / 2, Y: g.Height / 2}
+	}
+	g.RebuildOccupancy()
+}
+
+func (g *Game) NetTick() {
+	g.FrameCount++
+	g.UpdateEffects()
+	if l := g.Remote; l.Running && l.Playing() {
+		l.Prediction.Step()
+		g.Snake = l.Prediction.Snake()
+	}
+	g.BeginRender()
+	g.Draw()
+}
+
+func (g *Game) HandleRemoteKey(ev term.Event) (quit bool) {
+	switch {
+	case ev.Key == term.KeyCtrlC:
+		return true
+	case g.Chat.Typing:
+		g.HandleChatKey(ev)
+		return false
+	case g.IsChatKey(ev):
+		g.Chat.Typing = true
+		return false
+	case ev.Key == term.KeyEsc:
+		return true
+	case ev.Key == term.KeyF3:
+		g.Debug.Show = !g.Debug.Show
+	case g.Remote.Observing():
+		g.HandleObserverKey(ev)
+		return false
+	}
+	direction, ok := g.Bindings().Direction(ev)
+	if !ok || !g.Remote.Playing() {
+		return false
+	}
+	seq := g.Remote.Prediction.Input(direction)
+	g.Remote.Send(&matchpb.ClientMessage{Message: &matchpb.ClientMessage_Input{
+		Input: &matchpb.Input{Seq: seq, Direction: botpb.Direction(direction)},
+	}})
+	return false
+}
+
+func JoinMatch(address, name string, role matchpb.Role) error {
+	link, err := DialMatch(address, name, role)
+	if err != nil {
+		return err
+	}
+	defer link.Close()
+
+	if err := term.Init(); err != nil {
+		return err
+	}
+	defer term.Close()
+	term.SetInputMode(term.InputEsc | term.InputMouse)
+
+	g := NewGame(DefaultWidth, DefaultHeight, "normal", nil)
+	g.Settings = LoadSettings()
+	g.State = StatePlaying
+	g.Remote = link
+
+	messages := make(chan *matchpb.ServerMessage, 16)
+	errs := make(chan error, 1)
+	go link.Receive(messages, errs)
+
+	inputs := make(chan term.Event, 16)
+	go func() {
+		for {
+			inputs <- term.PollEvent()
+		}
+	}()
+
+	ticker := time.NewTicker(link.Interval)
+	defer func() { ticker.Stop() }()
+	pings := time.NewTicker(netPingInterval)
+	defer pings.Stop()
+	link.Ping(time.Now())
+
+	interval := link.Interval
+	for {
+		select {
+		case ev := <-inputs:
+			if ev.Type == term.EventKey && g.HandleRemoteKey(ev) {
+				return nil
+			}
+			if ev.Type == term.EventMouse {
+				g.HandleObserverMouse(ev)
+			}
+		case msg := <-messages:
+			switch m := msg.GetMessage().(type) {
+			case *matchpb.ServerMessage_Snapshot:
+				g.ApplySnapshot(m.Snapshot)
+				g.BeginRender()
+				g.Draw()
+			case *matchpb.ServerMessage_Pong:
+				link.RTT = time.Since(time.Unix(0, m.Pong.GetSentUnixNano()))
+			case *matchpb.ServerMessage_Chat:
+				g.AddChat(m.Chat.GetFrom(), m.Chat.GetText())
+			}
+		case err := <-errs:
+			if errors.Is(err, io.EOF) || status.Code(err) == codes.Unavailable {
+				return errors.New("o anfitriao encerrou a partida")
+			}
+			return fmt.Errorf("conexao com %s perdida: %w", address, err)
+		case <-ticker.C:
+			g.NetTick()
+		case now := <-pings.C:
+			link.Ping(now)
+		}
+		if link.Interval != interval {
+			interval = link.Interval
+			ticker.Reset(interval)
+		}
+	}
+}
+
+func MatchAddress(address string) string {
+	if !strings.Contains(address, ":") {
+		return address + DefaultMatchPort
+	}
+	return address
+}
+
+func runJoin(args []string) error {
+	fs := flag.NewFlagSet("join", flag.ExitOnError)
+	name := fs.String("name", "", "nome mostrado aos outros jogadores")
+	spectate := fs.Bool("spectate", false, "entra apenas para assistir")
+	observe := fs.Bool("observe", false, "entra como observador, que assiste e marca casas do tabuleiro para os jogadores")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("uso: snake join [-name N] [-spectate | -observe] endereco[%s]", DefaultMatchPort)
+	}
+	role := matchpb.Role_ROLE_PLAYER
+	switch {
+	case *observe:
+		role = matchpb.Role_ROLE_OBSERVER
+	case *spectate:
+		role = matchpb.Role_ROLE_SPECTATOR
+	}
+	return JoinMatch(MatchAddress(fs.Arg(0)), *name, role)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const LevelsDir = "levels"
+
+var layoutFoodNames = map[string]FoodType{
+	"normal":  NormalFood,
+	"powerup": PowerUpFood,
+}
+
+type Layout struct {
+	Name        string         `json:"name"`
+	Width       int            `json:"width"`
+	Height      int            `json:"height"`
+	Spawn       Point          `json:"spawn"`
+	Walls       []Point        `json:"walls"`
+	FoodWeights map[string]int `json:"food_weights"`
+}
+
+func NewLayout(width, height int) Layout {
+	return Layout{
+		Name:   "Novo nivel",
+		Width:  width,
+		Height: height,
+		Spawn:  Point{X: 10, Y: 10},
+		Walls:  []Point{},
+		FoodWeights: map[string]int{
+			"normal":  80,
+			"powerup": 20,
+		},
+	}
+}
+
+func LoadLayout(path string) (*Layout, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && !strings.ContainsAny(path, `/\.`) {
+		return LoadBundledLayout(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseLayout(data, path)
+}
+
+func ParseLayout(data []byte, path string) (*Layout, error) {
+	data, err := layoutFormat.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("nivel invalido %s: %w", path, err)
+	}
+
+	var layout Layout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("nivel invalido %s: %w", path, err)
+	}
+
+	if err := layout.Validate(); err != nil {
+		return nil, fmt.Errorf("nivel invalido %s: %w", path, err)
+	}
+
+	return &layout, nil
+}
+
+func SaveLayout(path string, layout Layout) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(layout, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return layoutFormat.Write(path, append(data, '\n'))
+}
+
+func (l *Layout) Validate() error {
+	if l.Width < MinWidth || l.Height < MinHeight {
+		return fmt.Errorf("tamanho minimo %dx%d", MinWidth, MinHeight)
+	}
+
+	if l.Spawn.X < 3 || l.Spawn.X >= l.Width-1 || l.Spawn.Y < 1 || l.Spawn.Y >= l.Height-1 {
+		return fmt.Errorf("ponto de partida fora do tabuleiro")
+	}
+
+	for name := range l.FoodWeights {
+		if _, ok := layoutFoodNames[name]; !ok {
+			return fmt.Errorf("comida desconhecida: %s", name)
+		}
+	}
+
+	return nil
+}
+
+func (l *Layout) HasWall(p Point) bool {
+	for _, wall := range l.Walls {
+		if wall == p {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Layout) Weights() map[FoodType]int {
+	if len(l.FoodWeights) == 0 {
+		return nil
+	}
+
+	weights := make(map[FoodType]int)
+	for name, weight := range l.FoodWeights {
+		weights[layoutFoodNames[name]] = weight
+	}
+	return weights
+}
+
+func (g *Game) ApplyLayout(layout *Layout) {
+	g.Layout = layout
+	if layout != nil {
+		g.Width = layout.Width
+		g.Height = layout.Height
+	}
+}
+
+func (g *Game) StartPoint() Point {
+	if g.Mode == ModePuzzle {
+		return g.CurrentPuzzle().Spawn()
+	}
+	if g.Layout != nil {
+		return g.Layout.Spawn
+	}
+	return Point{X: 10, Y: 10}
+}
+
+func (g *Game) NewPlayerSnake() Snake {
+	start := g.StartPoint()
+	return Snake{
+		Body: NewSnakeBody([]Point{
+			start,
+			{X: start.X - 1, Y: start.Y},
+			{X: start.X - 2, Y: start.Y},
+		}),
+		Direction: DirRight,
+	}
+}
+
+func (g *Game) FoodWeights() map[FoodType]int {
+	if g.Layout == nil {
+		return nil
+	}
+	return g.Layout.Weights()
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	LevelPoints   = 50
+	LevelBarWidth = 10
+	LevelBarEase  = 0.35
+)
+
+var partialBlocks = []rune{'▏', '▎', '▍', '▌', '▋', '▊', '▉'}
+
+type LevelBar struct {
+	Level int
+	Shown float64
+}
+
+func (g *Game) LevelProgress() float64 {
+	into := g.Score - (g.Level-1)*LevelPoints
+	return min(1, max(0, float64(into)/LevelPoints))
+}
+
+func (g *Game) UpdateLevelBar() {
+	if g.LevelBar.Level != g.Level {
+		g.LevelBar = LevelBar{Level: g.Level}
+	}
+
+	target := g.LevelProgress()
+	if g.ReducedEffects() || math.Abs(target-g.LevelBar.Shown) < 0.01 {
+		g.LevelBar.Shown = target
+		return
+	}
+	g.LevelBar.Shown += (target - g.LevelBar.Shown) * LevelBarEase
+}
+
+func (g *Game) LevelBarHUD() string {
+	if g.Mode == ModePuzzle {
+		return ""
+	}
+
+	eighths := int(g.LevelBar.Shown * LevelBarWidth * 8)
+	filled := eighths / 8
+	bar := strings.Repeat("█", filled)
+	if part := eighths % 8; filled < LevelBarWidth && part > 0 && !glyphFallback {
+		bar += string(partialBlocks[part-1])
+		filled++
+	}
+	bar += strings.Repeat("░", LevelBarWidth-filled)
+
+	return fmt.Sprintf("%s %d%% ", bar, int(g.LevelProgress()*100))
+}
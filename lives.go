@@ -0,0 +1,53 @@
+package main
+
+const InvulnerableTicks = 15
+
+func (g *Game) LoseLife(position Point) {
+	g.Lives--
+	g.TriggerShake(6, 6)
+	g.Emit(Event{Type: EventDeath, Position: position})
+	g.Unsplit()
+
+	length := max(3, g.Snake.Body.Len()/2)
+	g.Occupancy.RemoveBody(LayerPlayer, &g.Snake.Body)
+	g.Snake.Body.Clear()
+	if body, ok := g.FindSpawn(length); ok {
+		g.Snake.Body = NewSnakeBody(body)
+	} else {
+		g.Snake.Body = g.NewPlayerSnake().Body
+	}
+	g.Occupancy.AddBody(LayerPlayer, &g.Snake.Body)
+	g.Snake.Direction = DirRight
+	g.Snake.Moved = DirNone
+	g.Snake.Invulnerable = InvulnerableTicks
+}
+
+func (g *Game) FindSpawn(length int) ([]Point, bool) {
+	if length+3 >= g.Width-2 {
+		return nil, false
+	}
+
+	for attempts := 0; attempts < 200; attempts++ {
+		head := Point{
+			X: g.Rand.Intn(g.Width-length-4) + length + 1,
+			Y: g.Rand.Intn(g.Height-2) + 1,
+		}
+
+		body := make([]Point, length)
+		safe := true
+		for i := range body {
+			body[i] = Point{X: head.X - i, Y: head.Y}
+			if !g.IsPositionSafe(body[i]) {
+				safe = false
+				break
+			}
+		}
+
+		ahead := head.Move(DirRight)
+		if safe && !g.IsDeadly(ahead) && !g.IsDeadly(ahead.Move(DirRight)) {
+			return body, true
+		}
+	}
+
+	return nil, false
+}
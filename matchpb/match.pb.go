@@ -0,0 +1,1758 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: match.proto
+
+package matchpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	botpb "snake/botpb"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Role int32
+
+const (
+	Role_ROLE_SPECTATOR Role = 0
+	Role_ROLE_PLAYER    Role = 1
+	// Assiste e pode marcar casas do tabuleiro com Annotation.
+	Role_ROLE_OBSERVER Role = 2
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_SPECTATOR",
+		1: "ROLE_PLAYER",
+		2: "ROLE_OBSERVER",
+	}
+	Role_value = map[string]int32{
+		"ROLE_SPECTATOR": 0,
+		"ROLE_PLAYER":    1,
+		"ROLE_OBSERVER":  2,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_match_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_match_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{0}
+}
+
+type Hello struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Role          Role                   `protobuf:"varint,2,opt,name=role,proto3,enum=snake.match.v1.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hello) Reset() {
+	*x = Hello{}
+	mi := &file_match_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hello) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hello) ProtoMessage() {}
+
+func (x *Hello) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hello.ProtoReflect.Descriptor instead.
+func (*Hello) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Hello) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Hello) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_SPECTATOR
+}
+
+type Input struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Numero crescente de cada entrada; o servidor devolve o ultimo aplicado
+	// em Snapshot.ack.
+	Seq           uint32          `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Direction     botpb.Direction `protobuf:"varint,2,opt,name=direction,proto3,enum=snake.bot.v1.Direction" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Input) Reset() {
+	*x = Input{}
+	mi := &file_match_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Input) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Input) ProtoMessage() {}
+
+func (x *Input) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Input.ProtoReflect.Descriptor instead.
+func (*Input) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Input) GetSeq() uint32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Input) GetDirection() botpb.Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return botpb.Direction(0)
+}
+
+type Ping struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SentUnixNano int64                  `protobuf:"varint,1,opt,name=sent_unix_nano,json=sentUnixNano,proto3" json:"sent_unix_nano,omitempty"`
+	// RTT medido pelo cliente no ping anterior, para o painel do servidor.
+	RttMs         int32 `protobuf:"varint,2,opt,name=rtt_ms,json=rttMs,proto3" json:"rtt_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ping) Reset() {
+	*x = Ping{}
+	mi := &file_match_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ping) ProtoMessage() {}
+
+func (x *Ping) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ping.ProtoReflect.Descriptor instead.
+func (*Ping) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Ping) GetSentUnixNano() int64 {
+	if x != nil {
+		return x.SentUnixNano
+	}
+	return 0
+}
+
+func (x *Ping) GetRttMs() int32 {
+	if x != nil {
+		return x.RttMs
+	}
+	return 0
+}
+
+// Chat e uma linha de conversa. O cliente manda so o texto; o servidor
+// preenche from e repassa a todos. Sem from, e um aviso do servidor.
+type Chat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Chat) Reset() {
+	*x = Chat{}
+	mi := &file_match_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chat) ProtoMessage() {}
+
+func (x *Chat) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chat.ProtoReflect.Descriptor instead.
+func (*Chat) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Chat) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Chat) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// Annotation e uma marcacao temporaria de um observador. O cliente manda
+// position e glyph; nos snapshots o servidor preenche from e o tempo que
+// falta para ela sumir.
+type Annotation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      *botpb.Point           `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	Glyph         string                 `protobuf:"bytes,2,opt,name=glyph,proto3" json:"glyph,omitempty"`
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	TtlMs         int32                  `protobuf:"varint,4,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Annotation) Reset() {
+	*x = Annotation{}
+	mi := &file_match_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Annotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Annotation) ProtoMessage() {}
+
+func (x *Annotation) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Annotation.ProtoReflect.Descriptor instead.
+func (*Annotation) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Annotation) GetPosition() *botpb.Point {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Annotation) GetGlyph() string {
+	if x != nil {
+		return x.Glyph
+	}
+	return ""
+}
+
+func (x *Annotation) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Annotation) GetTtlMs() int32 {
+	if x != nil {
+		return x.TtlMs
+	}
+	return 0
+}
+
+type ClientMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*ClientMessage_Hello
+	//	*ClientMessage_Input
+	//	*ClientMessage_Ping
+	//	*ClientMessage_Chat
+	//	*ClientMessage_Annotation
+	Message       isClientMessage_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientMessage) Reset() {
+	*x = ClientMessage{}
+	mi := &file_match_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientMessage) ProtoMessage() {}
+
+func (x *ClientMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientMessage.ProtoReflect.Descriptor instead.
+func (*ClientMessage) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ClientMessage) GetMessage() isClientMessage_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetHello() *Hello {
+	if x != nil {
+		if x, ok := x.Message.(*ClientMessage_Hello); ok {
+			return x.Hello
+		}
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetInput() *Input {
+	if x != nil {
+		if x, ok := x.Message.(*ClientMessage_Input); ok {
+			return x.Input
+		}
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetPing() *Ping {
+	if x != nil {
+		if x, ok := x.Message.(*ClientMessage_Ping); ok {
+			return x.Ping
+		}
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetChat() *Chat {
+	if x != nil {
+		if x, ok := x.Message.(*ClientMessage_Chat); ok {
+			return x.Chat
+		}
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetAnnotation() *Annotation {
+	if x != nil {
+		if x, ok := x.Message.(*ClientMessage_Annotation); ok {
+			return x.Annotation
+		}
+	}
+	return nil
+}
+
+type isClientMessage_Message interface {
+	isClientMessage_Message()
+}
+
+type ClientMessage_Hello struct {
+	Hello *Hello `protobuf:"bytes,1,opt,name=hello,proto3,oneof"`
+}
+
+type ClientMessage_Input struct {
+	Input *Input `protobuf:"bytes,2,opt,name=input,proto3,oneof"`
+}
+
+type ClientMessage_Ping struct {
+	Ping *Ping `protobuf:"bytes,3,opt,name=ping,proto3,oneof"`
+}
+
+type ClientMessage_Chat struct {
+	Chat *Chat `protobuf:"bytes,4,opt,name=chat,proto3,oneof"`
+}
+
+type ClientMessage_Annotation struct {
+	Annotation *Annotation `protobuf:"bytes,5,opt,name=annotation,proto3,oneof"`
+}
+
+func (*ClientMessage_Hello) isClientMessage_Message() {}
+
+func (*ClientMessage_Input) isClientMessage_Message() {}
+
+func (*ClientMessage_Ping) isClientMessage_Message() {}
+
+func (*ClientMessage_Chat) isClientMessage_Message() {}
+
+func (*ClientMessage_Annotation) isClientMessage_Message() {}
+
+type Welcome struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Papel concedido: com a partida cheia, jogadores entram como espectadores.
+	Role          Role `protobuf:"varint,1,opt,name=role,proto3,enum=snake.match.v1.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Welcome) Reset() {
+	*x = Welcome{}
+	mi := &file_match_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Welcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Welcome) ProtoMessage() {}
+
+func (x *Welcome) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Welcome.ProtoReflect.Descriptor instead.
+func (*Welcome) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Welcome) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_SPECTATOR
+}
+
+type SnakeState struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Corpo da cabeca para a cauda.
+	Body          []*botpb.Point  `protobuf:"bytes,2,rep,name=body,proto3" json:"body,omitempty"`
+	Direction     botpb.Direction `protobuf:"varint,3,opt,name=direction,proto3,enum=snake.bot.v1.Direction" json:"direction,omitempty"`
+	Score         int32           `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	Alive         bool            `protobuf:"varint,5,opt,name=alive,proto3" json:"alive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnakeState) Reset() {
+	*x = SnakeState{}
+	mi := &file_match_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnakeState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnakeState) ProtoMessage() {}
+
+func (x *SnakeState) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnakeState.ProtoReflect.Descriptor instead.
+func (*SnakeState) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SnakeState) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SnakeState) GetBody() []*botpb.Point {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *SnakeState) GetDirection() botpb.Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return botpb.Direction(0)
+}
+
+func (x *SnakeState) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SnakeState) GetAlive() bool {
+	if x != nil {
+		return x.Alive
+	}
+	return false
+}
+
+type Snapshot struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tick  int32                  `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	Ack   uint32                 `protobuf:"varint,2,opt,name=ack,proto3" json:"ack,omitempty"`
+	// Falso com o jogo pausado ou parado; o cliente nao preve movimento.
+	Running        bool  `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	GameOver       bool  `protobuf:"varint,4,opt,name=game_over,json=gameOver,proto3" json:"game_over,omitempty"`
+	TickIntervalMs int32 `protobuf:"varint,5,opt,name=tick_interval_ms,json=tickIntervalMs,proto3" json:"tick_interval_ms,omitempty"`
+	Width          int32 `protobuf:"varint,6,opt,name=width,proto3" json:"width,omitempty"`
+	Height         int32 `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	Level          int32 `protobuf:"varint,8,opt,name=level,proto3" json:"level,omitempty"`
+	// A cobra 0 e a do anfitriao; as demais sao os rivais.
+	Snakes    []*SnakeState  `protobuf:"bytes,9,rep,name=snakes,proto3" json:"snakes,omitempty"`
+	Food      *botpb.Point   `protobuf:"bytes,10,opt,name=food,proto3" json:"food,omitempty"`
+	Pellets   []*botpb.Point `protobuf:"bytes,11,rep,name=pellets,proto3" json:"pellets,omitempty"`
+	Obstacles []*botpb.Point `protobuf:"bytes,12,rep,name=obstacles,proto3" json:"obstacles,omitempty"`
+	// Indice da cobra do cliente em snakes, ou -1 para espectadores.
+	Slot          int32         `protobuf:"varint,13,opt,name=slot,proto3" json:"slot,omitempty"`
+	Mode          string        `protobuf:"bytes,14,opt,name=mode,proto3" json:"mode,omitempty"`
+	Annotations   []*Annotation `protobuf:"bytes,15,rep,name=annotations,proto3" json:"annotations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_match_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Snapshot) GetTick() int32 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *Snapshot) GetAck() uint32 {
+	if x != nil {
+		return x.Ack
+	}
+	return 0
+}
+
+func (x *Snapshot) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *Snapshot) GetGameOver() bool {
+	if x != nil {
+		return x.GameOver
+	}
+	return false
+}
+
+func (x *Snapshot) GetTickIntervalMs() int32 {
+	if x != nil {
+		return x.TickIntervalMs
+	}
+	return 0
+}
+
+func (x *Snapshot) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Snapshot) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Snapshot) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Snapshot) GetSnakes() []*SnakeState {
+	if x != nil {
+		return x.Snakes
+	}
+	return nil
+}
+
+func (x *Snapshot) GetFood() *botpb.Point {
+	if x != nil {
+		return x.Food
+	}
+	return nil
+}
+
+func (x *Snapshot) GetPellets() []*botpb.Point {
+	if x != nil {
+		return x.Pellets
+	}
+	return nil
+}
+
+func (x *Snapshot) GetObstacles() []*botpb.Point {
+	if x != nil {
+		return x.Obstacles
+	}
+	return nil
+}
+
+func (x *Snapshot) GetSlot() int32 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *Snapshot) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Snapshot) GetAnnotations() []*Annotation {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+type Pong struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SentUnixNano  int64                  `protobuf:"varint,1,opt,name=sent_unix_nano,json=sentUnixNano,proto3" json:"sent_unix_nano,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pong) Reset() {
+	*x = Pong{}
+	mi := &file_match_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pong) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pong) ProtoMessage() {}
+
+func (x *Pong) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pong.ProtoReflect.Descriptor instead.
+func (*Pong) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Pong) GetSentUnixNano() int64 {
+	if x != nil {
+		return x.SentUnixNano
+	}
+	return 0
+}
+
+type ServerMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*ServerMessage_Welcome
+	//	*ServerMessage_Snapshot
+	//	*ServerMessage_Pong
+	//	*ServerMessage_Chat
+	Message       isServerMessage_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerMessage) Reset() {
+	*x = ServerMessage{}
+	mi := &file_match_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage) ProtoMessage() {}
+
+func (x *ServerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage.ProtoReflect.Descriptor instead.
+func (*ServerMessage) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ServerMessage) GetMessage() isServerMessage_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetWelcome() *Welcome {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Welcome); ok {
+			return x.Welcome
+		}
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetSnapshot() *Snapshot {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Snapshot); ok {
+			return x.Snapshot
+		}
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetPong() *Pong {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Pong); ok {
+			return x.Pong
+		}
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetChat() *Chat {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Chat); ok {
+			return x.Chat
+		}
+	}
+	return nil
+}
+
+type isServerMessage_Message interface {
+	isServerMessage_Message()
+}
+
+type ServerMessage_Welcome struct {
+	Welcome *Welcome `protobuf:"bytes,1,opt,name=welcome,proto3,oneof"`
+}
+
+type ServerMessage_Snapshot struct {
+	Snapshot *Snapshot `protobuf:"bytes,2,opt,name=snapshot,proto3,oneof"`
+}
+
+type ServerMessage_Pong struct {
+	Pong *Pong `protobuf:"bytes,3,opt,name=pong,proto3,oneof"`
+}
+
+type ServerMessage_Chat struct {
+	Chat *Chat `protobuf:"bytes,4,opt,name=chat,proto3,oneof"`
+}
+
+func (*ServerMessage_Welcome) isServerMessage_Message() {}
+
+func (*ServerMessage_Snapshot) isServerMessage_Message() {}
+
+func (*ServerMessage_Pong) isServerMessage_Message() {}
+
+func (*ServerMessage_Chat) isServerMessage_Message() {}
+
+type Entry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entry) Reset() {
+	*x = Entry{}
+	mi := &file_match_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entry) ProtoMessage() {}
+
+func (x *Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entry.ProtoReflect.Descriptor instead.
+func (*Entry) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Entry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type MatchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Match         int32                  `protobuf:"varint,1,opt,name=match,proto3" json:"match,omitempty"`
+	Score         int32                  `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	Ticks         int32                  `protobuf:"varint,3,opt,name=ticks,proto3" json:"ticks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchResult) Reset() {
+	*x = MatchResult{}
+	mi := &file_match_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchResult) ProtoMessage() {}
+
+func (x *MatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchResult.ProtoReflect.Descriptor instead.
+func (*MatchResult) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MatchResult) GetMatch() int32 {
+	if x != nil {
+		return x.Match
+	}
+	return 0
+}
+
+func (x *MatchResult) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *MatchResult) GetTicks() int32 {
+	if x != nil {
+		return x.Ticks
+	}
+	return 0
+}
+
+type TournamentClientMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*TournamentClientMessage_Entry
+	//	*TournamentClientMessage_Result
+	Message       isTournamentClientMessage_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentClientMessage) Reset() {
+	*x = TournamentClientMessage{}
+	mi := &file_match_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentClientMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentClientMessage) ProtoMessage() {}
+
+func (x *TournamentClientMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentClientMessage.ProtoReflect.Descriptor instead.
+func (*TournamentClientMessage) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TournamentClientMessage) GetMessage() isTournamentClientMessage_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *TournamentClientMessage) GetEntry() *Entry {
+	if x != nil {
+		if x, ok := x.Message.(*TournamentClientMessage_Entry); ok {
+			return x.Entry
+		}
+	}
+	return nil
+}
+
+func (x *TournamentClientMessage) GetResult() *MatchResult {
+	if x != nil {
+		if x, ok := x.Message.(*TournamentClientMessage_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isTournamentClientMessage_Message interface {
+	isTournamentClientMessage_Message()
+}
+
+type TournamentClientMessage_Entry struct {
+	Entry *Entry `protobuf:"bytes,1,opt,name=entry,proto3,oneof"`
+}
+
+type TournamentClientMessage_Result struct {
+	Result *MatchResult `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+func (*TournamentClientMessage_Entry) isTournamentClientMessage_Message() {}
+
+func (*TournamentClientMessage_Result) isTournamentClientMessage_Message() {}
+
+type BracketMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Round int32                  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	A     string                 `protobuf:"bytes,3,opt,name=a,proto3" json:"a,omitempty"`
+	// Vazio quando a tem folga (bye) na rodada.
+	B             string `protobuf:"bytes,4,opt,name=b,proto3" json:"b,omitempty"`
+	ScoreA        int32  `protobuf:"varint,5,opt,name=score_a,json=scoreA,proto3" json:"score_a,omitempty"`
+	ScoreB        int32  `protobuf:"varint,6,opt,name=score_b,json=scoreB,proto3" json:"score_b,omitempty"`
+	Winner        string `protobuf:"bytes,7,opt,name=winner,proto3" json:"winner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BracketMatch) Reset() {
+	*x = BracketMatch{}
+	mi := &file_match_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BracketMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BracketMatch) ProtoMessage() {}
+
+func (x *BracketMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BracketMatch.ProtoReflect.Descriptor instead.
+func (*BracketMatch) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BracketMatch) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BracketMatch) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *BracketMatch) GetA() string {
+	if x != nil {
+		return x.A
+	}
+	return ""
+}
+
+func (x *BracketMatch) GetB() string {
+	if x != nil {
+		return x.B
+	}
+	return ""
+}
+
+func (x *BracketMatch) GetScoreA() int32 {
+	if x != nil {
+		return x.ScoreA
+	}
+	return 0
+}
+
+func (x *BracketMatch) GetScoreB() int32 {
+	if x != nil {
+		return x.ScoreB
+	}
+	return 0
+}
+
+func (x *BracketMatch) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+type Standing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Wins          int32                  `protobuf:"varint,2,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses        int32                  `protobuf:"varint,3,opt,name=losses,proto3" json:"losses,omitempty"`
+	Points        int32                  `protobuf:"varint,4,opt,name=points,proto3" json:"points,omitempty"`
+	Eliminated    bool                   `protobuf:"varint,5,opt,name=eliminated,proto3" json:"eliminated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Standing) Reset() {
+	*x = Standing{}
+	mi := &file_match_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Standing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Standing) ProtoMessage() {}
+
+func (x *Standing) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Standing.ProtoReflect.Descriptor instead.
+func (*Standing) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Standing) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Standing) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *Standing) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *Standing) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *Standing) GetEliminated() bool {
+	if x != nil {
+		return x.Eliminated
+	}
+	return false
+}
+
+type Bracket struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Matches   []*BracketMatch        `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	Standings []*Standing            `protobuf:"bytes,2,rep,name=standings,proto3" json:"standings,omitempty"`
+	// Codigo de desafio do tabuleiro do dia, o mesmo em todas as partidas.
+	Board    string `protobuf:"bytes,3,opt,name=board,proto3" json:"board,omitempty"`
+	Entrants int32  `protobuf:"varint,4,opt,name=entrants,proto3" json:"entrants,omitempty"`
+	Capacity int32  `protobuf:"varint,5,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Champion string `protobuf:"bytes,6,opt,name=champion,proto3" json:"champion,omitempty"`
+	// Nome com que o destinatario foi inscrito (repetidos ganham um numero).
+	You           string `protobuf:"bytes,7,opt,name=you,proto3" json:"you,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bracket) Reset() {
+	*x = Bracket{}
+	mi := &file_match_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bracket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bracket) ProtoMessage() {}
+
+func (x *Bracket) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bracket.ProtoReflect.Descriptor instead.
+func (*Bracket) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Bracket) GetMatches() []*BracketMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *Bracket) GetStandings() []*Standing {
+	if x != nil {
+		return x.Standings
+	}
+	return nil
+}
+
+func (x *Bracket) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+func (x *Bracket) GetEntrants() int32 {
+	if x != nil {
+		return x.Entrants
+	}
+	return 0
+}
+
+func (x *Bracket) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Bracket) GetChampion() string {
+	if x != nil {
+		return x.Champion
+	}
+	return ""
+}
+
+func (x *Bracket) GetYou() string {
+	if x != nil {
+		return x.You
+	}
+	return ""
+}
+
+type MatchStart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Match         int32                  `protobuf:"varint,1,opt,name=match,proto3" json:"match,omitempty"`
+	Opponent      string                 `protobuf:"bytes,2,opt,name=opponent,proto3" json:"opponent,omitempty"`
+	Board         string                 `protobuf:"bytes,3,opt,name=board,proto3" json:"board,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchStart) Reset() {
+	*x = MatchStart{}
+	mi := &file_match_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchStart) ProtoMessage() {}
+
+func (x *MatchStart) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchStart.ProtoReflect.Descriptor instead.
+func (*MatchStart) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MatchStart) GetMatch() int32 {
+	if x != nil {
+		return x.Match
+	}
+	return 0
+}
+
+func (x *MatchStart) GetOpponent() string {
+	if x != nil {
+		return x.Opponent
+	}
+	return ""
+}
+
+func (x *MatchStart) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+type TournamentServerMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*TournamentServerMessage_Bracket
+	//	*TournamentServerMessage_Start
+	Message       isTournamentServerMessage_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentServerMessage) Reset() {
+	*x = TournamentServerMessage{}
+	mi := &file_match_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentServerMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentServerMessage) ProtoMessage() {}
+
+func (x *TournamentServerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_match_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentServerMessage.ProtoReflect.Descriptor instead.
+func (*TournamentServerMessage) Descriptor() ([]byte, []int) {
+	return file_match_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TournamentServerMessage) GetMessage() isTournamentServerMessage_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *TournamentServerMessage) GetBracket() *Bracket {
+	if x != nil {
+		if x, ok := x.Message.(*TournamentServerMessage_Bracket); ok {
+			return x.Bracket
+		}
+	}
+	return nil
+}
+
+func (x *TournamentServerMessage) GetStart() *MatchStart {
+	if x != nil {
+		if x, ok := x.Message.(*TournamentServerMessage_Start); ok {
+			return x.Start
+		}
+	}
+	return nil
+}
+
+type isTournamentServerMessage_Message interface {
+	isTournamentServerMessage_Message()
+}
+
+type TournamentServerMessage_Bracket struct {
+	Bracket *Bracket `protobuf:"bytes,1,opt,name=bracket,proto3,oneof"`
+}
+
+type TournamentServerMessage_Start struct {
+	Start *MatchStart `protobuf:"bytes,2,opt,name=start,proto3,oneof"`
+}
+
+func (*TournamentServerMessage_Bracket) isTournamentServerMessage_Message() {}
+
+func (*TournamentServerMessage_Start) isTournamentServerMessage_Message() {}
+
+var File_match_proto protoreflect.FileDescriptor
+
+const file_match_proto_rawDesc = "" +
+	"\n" +
+	"\vmatch.proto\x12\x0esnake.match.v1\x1a\tbot.proto\"E\n" +
+	"\x05Hello\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12(\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x14.snake.match.v1.RoleR\x04role\"P\n" +
+	"\x05Input\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\rR\x03seq\x125\n" +
+	"\tdirection\x18\x02 \x01(\x0e2\x17.snake.bot.v1.DirectionR\tdirection\"C\n" +
+	"\x04Ping\x12$\n" +
+	"\x0esent_unix_nano\x18\x01 \x01(\x03R\fsentUnixNano\x12\x15\n" +
+	"\x06rtt_ms\x18\x02 \x01(\x05R\x05rttMs\".\n" +
+	"\x04Chat\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"~\n" +
+	"\n" +
+	"Annotation\x12/\n" +
+	"\bposition\x18\x01 \x01(\v2\x13.snake.bot.v1.PointR\bposition\x12\x14\n" +
+	"\x05glyph\x18\x02 \x01(\tR\x05glyph\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x15\n" +
+	"\x06ttl_ms\x18\x04 \x01(\x05R\x05ttlMs\"\x8e\x02\n" +
+	"\rClientMessage\x12-\n" +
+	"\x05hello\x18\x01 \x01(\v2\x15.snake.match.v1.HelloH\x00R\x05hello\x12-\n" +
+	"\x05input\x18\x02 \x01(\v2\x15.snake.match.v1.InputH\x00R\x05input\x12*\n" +
+	"\x04ping\x18\x03 \x01(\v2\x14.snake.match.v1.PingH\x00R\x04ping\x12*\n" +
+	"\x04chat\x18\x04 \x01(\v2\x14.snake.match.v1.ChatH\x00R\x04chat\x12<\n" +
+	"\n" +
+	"annotation\x18\x05 \x01(\v2\x1a.snake.match.v1.AnnotationH\x00R\n" +
+	"annotationB\t\n" +
+	"\amessage\"3\n" +
+	"\aWelcome\x12(\n" +
+	"\x04role\x18\x01 \x01(\x0e2\x14.snake.match.v1.RoleR\x04role\"\xac\x01\n" +
+	"\n" +
+	"SnakeState\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12'\n" +
+	"\x04body\x18\x02 \x03(\v2\x13.snake.bot.v1.PointR\x04body\x125\n" +
+	"\tdirection\x18\x03 \x01(\x0e2\x17.snake.bot.v1.DirectionR\tdirection\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x05R\x05score\x12\x14\n" +
+	"\x05alive\x18\x05 \x01(\bR\x05alive\"\xfa\x03\n" +
+	"\bSnapshot\x12\x12\n" +
+	"\x04tick\x18\x01 \x01(\x05R\x04tick\x12\x10\n" +
+	"\x03ack\x18\x02 \x01(\rR\x03ack\x12\x18\n" +
+	"\arunning\x18\x03 \x01(\bR\arunning\x12\x1b\n" +
+	"\tgame_over\x18\x04 \x01(\bR\bgameOver\x12(\n" +
+	"\x10tick_interval_ms\x18\x05 \x01(\x05R\x0etickIntervalMs\x12\x14\n" +
+	"\x05width\x18\x06 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\a \x01(\x05R\x06height\x12\x14\n" +
+	"\x05level\x18\b \x01(\x05R\x05level\x122\n" +
+	"\x06snakes\x18\t \x03(\v2\x1a.snake.match.v1.SnakeStateR\x06snakes\x12'\n" +
+	"\x04food\x18\n" +
+	" \x01(\v2\x13.snake.bot.v1.PointR\x04food\x12-\n" +
+	"\apellets\x18\v \x03(\v2\x13.snake.bot.v1.PointR\apellets\x121\n" +
+	"\tobstacles\x18\f \x03(\v2\x13.snake.bot.v1.PointR\tobstacles\x12\x12\n" +
+	"\x04slot\x18\r \x01(\x05R\x04slot\x12\x12\n" +
+	"\x04mode\x18\x0e \x01(\tR\x04mode\x12<\n" +
+	"\vannotations\x18\x0f \x03(\v2\x1a.snake.match.v1.AnnotationR\vannotations\",\n" +
+	"\x04Pong\x12$\n" +
+	"\x0esent_unix_nano\x18\x01 \x01(\x03R\fsentUnixNano\"\xdf\x01\n" +
+	"\rServerMessage\x123\n" +
+	"\awelcome\x18\x01 \x01(\v2\x17.snake.match.v1.WelcomeH\x00R\awelcome\x126\n" +
+	"\bsnapshot\x18\x02 \x01(\v2\x18.snake.match.v1.SnapshotH\x00R\bsnapshot\x12*\n" +
+	"\x04pong\x18\x03 \x01(\v2\x14.snake.match.v1.PongH\x00R\x04pong\x12*\n" +
+	"\x04chat\x18\x04 \x01(\v2\x14.snake.match.v1.ChatH\x00R\x04chatB\t\n" +
+	"\amessage\"\x1b\n" +
+	"\x05Entry\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"O\n" +
+	"\vMatchResult\x12\x14\n" +
+	"\x05match\x18\x01 \x01(\x05R\x05match\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x05R\x05score\x12\x14\n" +
+	"\x05ticks\x18\x03 \x01(\x05R\x05ticks\"\x8a\x01\n" +
+	"\x17TournamentClientMessage\x12-\n" +
+	"\x05entry\x18\x01 \x01(\v2\x15.snake.match.v1.EntryH\x00R\x05entry\x125\n" +
+	"\x06result\x18\x02 \x01(\v2\x1b.snake.match.v1.MatchResultH\x00R\x06resultB\t\n" +
+	"\amessage\"\x9a\x01\n" +
+	"\fBracketMatch\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\x05R\x05round\x12\f\n" +
+	"\x01a\x18\x03 \x01(\tR\x01a\x12\f\n" +
+	"\x01b\x18\x04 \x01(\tR\x01b\x12\x17\n" +
+	"\ascore_a\x18\x05 \x01(\x05R\x06scoreA\x12\x17\n" +
+	"\ascore_b\x18\x06 \x01(\x05R\x06scoreB\x12\x16\n" +
+	"\x06winner\x18\a \x01(\tR\x06winner\"\x82\x01\n" +
+	"\bStanding\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04wins\x18\x02 \x01(\x05R\x04wins\x12\x16\n" +
+	"\x06losses\x18\x03 \x01(\x05R\x06losses\x12\x16\n" +
+	"\x06points\x18\x04 \x01(\x05R\x06points\x12\x1e\n" +
+	"\n" +
+	"eliminated\x18\x05 \x01(\bR\n" +
+	"eliminated\"\xf5\x01\n" +
+	"\aBracket\x126\n" +
+	"\amatches\x18\x01 \x03(\v2\x1c.snake.match.v1.BracketMatchR\amatches\x126\n" +
+	"\tstandings\x18\x02 \x03(\v2\x18.snake.match.v1.StandingR\tstandings\x12\x14\n" +
+	"\x05board\x18\x03 \x01(\tR\x05board\x12\x1a\n" +
+	"\bentrants\x18\x04 \x01(\x05R\bentrants\x12\x1a\n" +
+	"\bcapacity\x18\x05 \x01(\x05R\bcapacity\x12\x1a\n" +
+	"\bchampion\x18\x06 \x01(\tR\bchampion\x12\x10\n" +
+	"\x03you\x18\a \x01(\tR\x03you\"T\n" +
+	"\n" +
+	"MatchStart\x12\x14\n" +
+	"\x05match\x18\x01 \x01(\x05R\x05match\x12\x1a\n" +
+	"\bopponent\x18\x02 \x01(\tR\bopponent\x12\x14\n" +
+	"\x05board\x18\x03 \x01(\tR\x05board\"\x8d\x01\n" +
+	"\x17TournamentServerMessage\x123\n" +
+	"\abracket\x18\x01 \x01(\v2\x17.snake.match.v1.BracketH\x00R\abracket\x122\n" +
+	"\x05start\x18\x02 \x01(\v2\x1a.snake.match.v1.MatchStartH\x00R\x05startB\t\n" +
+	"\amessage*>\n" +
+	"\x04Role\x12\x12\n" +
+	"\x0eROLE_SPECTATOR\x10\x00\x12\x0f\n" +
+	"\vROLE_PLAYER\x10\x01\x12\x11\n" +
+	"\rROLE_OBSERVER\x10\x022Q\n" +
+	"\x05Match\x12H\n" +
+	"\x04Join\x12\x1d.snake.match.v1.ClientMessage\x1a\x1d.snake.match.v1.ServerMessage(\x010\x012k\n" +
+	"\n" +
+	"Tournament\x12]\n" +
+	"\x05Enter\x12'.snake.match.v1.TournamentClientMessage\x1a'.snake.match.v1.TournamentServerMessage(\x010\x01B\x0fZ\rsnake/matchpbb\x06proto3"
+
+var (
+	file_match_proto_rawDescOnce sync.Once
+	file_match_proto_rawDescData []byte
+)
+
+func file_match_proto_rawDescGZIP() []byte {
+	file_match_proto_rawDescOnce.Do(func() {
+		file_match_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_match_proto_rawDesc), len(file_match_proto_rawDesc)))
+	})
+	return file_match_proto_rawDescData
+}
+
+var file_match_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_match_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_match_proto_goTypes = []any{
+	(Role)(0),                       // 0: snake.match.v1.Role
+	(*Hello)(nil),                   // 1: snake.match.v1.Hello
+	(*Input)(nil),                   // 2: snake.match.v1.Input
+	(*Ping)(nil),                    // 3: snake.match.v1.Ping
+	(*Chat)(nil),                    // 4: snake.match.v1.Chat
+	(*Annotation)(nil),              // 5: snake.match.v1.Annotation
+	(*ClientMessage)(nil),           // 6: snake.match.v1.ClientMessage
+	(*Welcome)(nil),                 // 7: snake.match.v1.Welcome
+	(*SnakeState)(nil),              // 8: snake.match.v1.SnakeState
+	(*Snapshot)(nil),                // 9: snake.match.v1.Snapshot
+	(*Pong)(nil),                    // 10: snake.match.v1.Pong
+	(*ServerMessage)(nil),           // 11: snake.match.v1.ServerMessage
+	(*Entry)(nil),                   // 12: snake.match.v1.Entry
+	(*MatchResult)(nil),             // 13: snake.match.v1.MatchResult
+	(*TournamentClientMessage)(nil), // 14: snake.match.v1.TournamentClientMessage
+	(*BracketMatch)(nil),            // 15: snake.match.v1.BracketMatch
+	(*Standing)(nil),                // 16: snake.match.v1.Standing
+	(*Bracket)(nil),                 // 17: snake.match.v1.Bracket
+	(*MatchStart)(nil),              // 18: snake.match.v1.MatchStart
+	(*TournamentServerMessage)(nil), // 19: snake.match.v1.TournamentServerMessage
+	(botpb.Direction)(0),            // 20: snake.bot.v1.Direction
+	(*botpb.Point)(nil),             // 21: snake.bot.v1.Point
+}
+var file_match_proto_depIdxs = []int32{
+	0,  // 0: snake.match.v1.Hello.role:type_name -> snake.match.v1.Role
+	20, // 1: snake.match.v1.Input.direction:type_name -> snake.bot.v1.Direction
+	21, // 2: snake.match.v1.Annotation.position:type_name -> snake.bot.v1.Point
+	1,  // 3: snake.match.v1.ClientMessage.hello:type_name -> snake.match.v1.Hello
+	2,  // 4: snake.match.v1.ClientMessage.input:type_name -> snake.match.v1.Input
+	3,  // 5: snake.match.v1.ClientMessage.ping:type_name -> snake.match.v1.Ping
+	4,  // 6: snake.match.v1.ClientMessage.chat:type_name -> snake.match.v1.Chat
+	5,  // 7: snake.match.v1.ClientMessage.annotation:type_name -> snake.match.v1.Annotation
+	0,  // 8: snake.match.v1.Welcome.role:type_name -> snake.match.v1.Role
+	21, // 9: snake.match.v1.SnakeState.body:type_name -> snake.bot.v1.Point
+	20, // 10: snake.match.v1.SnakeState.direction:type_name -> snake.bot.v1.Direction
+	8,  // 11: snake.match.v1.Snapshot.snakes:type_name -> snake.match.v1.SnakeState
+	21, // 12: snake.match.v1.Snapshot.food:type_name -> snake.bot.v1.Point
+	21, // 13: snake.match.v1.Snapshot.pellets:type_name -> snake.bot.v1.Point
+	21, // 14: snake.match.v1.Snapshot.obstacles:type_name -> snake.bot.v1.Point
+	5,  // 15: snake.match.v1.Snapshot.annotations:type_name -> snake.match.v1.Annotation
+	7,  // 16: snake.match.v1.ServerMessage.welcome:type_name -> snake.match.v1.Welcome
+	9,  // 17: snake.match.v1.ServerMessage.snapshot:type_name -> snake.match.v1.Snapshot
+	10, // 18: snake.match.v1.ServerMessage.pong:type_name -> snake.match.v1.Pong
+	4,  // 19: snake.match.v1.ServerMessage.chat:type_name -> snake.match.v1.Chat
+	12, // 20: snake.match.v1.TournamentClientMessage.entry:type_name -> snake.match.v1.Entry
+	13, // 21: snake.match.v1.TournamentClientMessage.result:type_name -> snake.match.v1.MatchResult
+	15, // 22: snake.match.v1.Bracket.matches:type_name -> snake.match.v1.BracketMatch
+	16, // 23: snake.match.v1.Bracket.standings:type_name -> snake.match.v1.Standing
+	17, // 24: snake.match.v1.TournamentServerMessage.bracket:type_name -> snake.match.v1.Bracket
+	18, // 25: snake.match.v1.TournamentServerMessage.start:type_name -> snake.match.v1.MatchStart
+	6,  // 26: snake.match.v1.Match.Join:input_type -> snake.match.v1.ClientMessage
+	14, // 27: snake.match.v1.Tournament.Enter:input_type -> snake.match.v1.TournamentClientMessage
+	11, // 28: snake.match.v1.Match.Join:output_type -> snake.match.v1.ServerMessage
+	19, // 29: snake.match.v1.Tournament.Enter:output_type -> snake.match.v1.TournamentServerMessage
+	28, // [28:30] is the sub-list for method output_type
+	26, // [26:28] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_match_proto_init() }
+func file_match_proto_init() {
+	if File_match_proto != nil {
+		return
+	}
+	file_match_proto_msgTypes[5].OneofWrappers = []any{
+		(*ClientMessage_Hello)(nil),
+		(*ClientMessage_Input)(nil),
+		(*ClientMessage_Ping)(nil),
+		(*ClientMessage_Chat)(nil),
+		(*ClientMessage_Annotation)(nil),
+	}
+	file_match_proto_msgTypes[10].OneofWrappers = []any{
+		(*ServerMessage_Welcome)(nil),
+		(*ServerMessage_Snapshot)(nil),
+		(*ServerMessage_Pong)(nil),
+		(*ServerMessage_Chat)(nil),
+	}
+	file_match_proto_msgTypes[13].OneofWrappers = []any{
+		(*TournamentClientMessage_Entry)(nil),
+		(*TournamentClientMessage_Result)(nil),
+	}
+	file_match_proto_msgTypes[18].OneofWrappers = []any{
+		(*TournamentServerMessage_Bracket)(nil),
+		(*TournamentServerMessage_Start)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_match_proto_rawDesc), len(file_match_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_match_proto_goTypes,
+		DependencyIndexes: file_match_proto_depIdxs,
+		EnumInfos:         file_match_proto_enumTypes,
+		MessageInfos:      file_match_proto_msgTypes,
+	}.Build()
+	File_match_proto = out.File
+	file_match_proto_goTypes = nil
+	file_match_proto_depIdxs = nil
+}
@@ -0,0 +1,229 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: match.proto
+
+package matchpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Match_Join_FullMethodName = "/snake.match.v1.Match/Join"
+)
+
+// MatchClient is the client API for Match service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Match liga os clientes da rede local a uma partida hospedada com
+// snake play -serve. O cliente manda Hello e depois entradas e pings; o
+// servidor responde Welcome e passa a mandar um Snapshot por tick.
+type MatchClient interface {
+	Join(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ClientMessage, ServerMessage], error)
+}
+
+type matchClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMatchClient(cc grpc.ClientConnInterface) MatchClient {
+	return &matchClient{cc}
+}
+
+func (c *matchClient) Join(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ClientMessage, ServerMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Match_ServiceDesc.Streams[0], Match_Join_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ClientMessage, ServerMessage]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Match_JoinClient = grpc.BidiStreamingClient[ClientMessage, ServerMessage]
+
+// MatchServer is the server API for Match service.
+// All implementations must embed UnimplementedMatchServer
+// for forward compatibility.
+//
+// Match liga os clientes da rede local a uma partida hospedada com
+// snake play -serve. O cliente manda Hello e depois entradas e pings; o
+// servidor responde Welcome e passa a mandar um Snapshot por tick.
+type MatchServer interface {
+	Join(grpc.BidiStreamingServer[ClientMessage, ServerMessage]) error
+	mustEmbedUnimplementedMatchServer()
+}
+
+// UnimplementedMatchServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMatchServer struct{}
+
+func (UnimplementedMatchServer) Join(grpc.BidiStreamingServer[ClientMessage, ServerMessage]) error {
+	return status.Error(codes.Unimplemented, "method Join not implemented")
+}
+func (UnimplementedMatchServer) mustEmbedUnimplementedMatchServer() {}
+func (UnimplementedMatchServer) testEmbeddedByValue()               {}
+
+// UnsafeMatchServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MatchServer will
+// result in compilation errors.
+type UnsafeMatchServer interface {
+	mustEmbedUnimplementedMatchServer()
+}
+
+func RegisterMatchServer(s grpc.ServiceRegistrar, srv MatchServer) {
+	// If the following call panics, it indicates UnimplementedMatchServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Match_ServiceDesc, srv)
+}
+
+func _Match_Join_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MatchServer).Join(&grpc.GenericServerStream[ClientMessage, ServerMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Match_JoinServer = grpc.BidiStreamingServer[ClientMessage, ServerMessage]
+
+// Match_ServiceDesc is the grpc.ServiceDesc for Match service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Match_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "snake.match.v1.Match",
+	HandlerType: (*MatchServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Join",
+			Handler:       _Match_Join_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "match.proto",
+}
+
+const (
+	Tournament_Enter_FullMethodName = "/snake.match.v1.Tournament/Enter"
+)
+
+// TournamentClient is the client API for Tournament service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Tournament organiza um torneio eliminatorio com snake serve-tournament.
+// Cada inscrito joga sozinho, no proprio computador, o tabuleiro do dia
+// indicado em MatchStart e devolve o resultado; o servidor decide o
+// confronto, monta a proxima rodada e manda a chave atualizada a todos.
+type TournamentClient interface {
+	Enter(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TournamentClientMessage, TournamentServerMessage], error)
+}
+
+type tournamentClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTournamentClient(cc grpc.ClientConnInterface) TournamentClient {
+	return &tournamentClient{cc}
+}
+
+func (c *tournamentClient) Enter(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TournamentClientMessage, TournamentServerMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tournament_ServiceDesc.Streams[0], Tournament_Enter_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TournamentClientMessage, TournamentServerMessage]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tournament_EnterClient = grpc.BidiStreamingClient[TournamentClientMessage, TournamentServerMessage]
+
+// TournamentServer is the server API for Tournament service.
+// All implementations must embed UnimplementedTournamentServer
+// for forward compatibility.
+//
+// Tournament organiza um torneio eliminatorio com snake serve-tournament.
+// Cada inscrito joga sozinho, no proprio computador, o tabuleiro do dia
+// indicado em MatchStart e devolve o resultado; o servidor decide o
+// confronto, monta a proxima rodada e manda a chave atualizada a todos.
+type TournamentServer interface {
+	Enter(grpc.BidiStreamingServer[TournamentClientMessage, TournamentServerMessage]) error
+	mustEmbedUnimplementedTournamentServer()
+}
+
+// UnimplementedTournamentServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTournamentServer struct{}
+
+func (UnimplementedTournamentServer) Enter(grpc.BidiStreamingServer[TournamentClientMessage, TournamentServerMessage]) error {
+	return status.Error(codes.Unimplemented, "method Enter not implemented")
+}
+func (UnimplementedTournamentServer) mustEmbedUnimplementedTournamentServer() {}
+func (UnimplementedTournamentServer) testEmbeddedByValue()                    {}
+
+// UnsafeTournamentServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TournamentServer will
+// result in compilation errors.
+type UnsafeTournamentServer interface {
+	mustEmbedUnimplementedTournamentServer()
+}
+
+func RegisterTournamentServer(s grpc.ServiceRegistrar, srv TournamentServer) {
+	// If the following call panics, it indicates UnimplementedTournamentServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Tournament_ServiceDesc, srv)
+}
+
+func _Tournament_Enter_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TournamentServer).Enter(&grpc.GenericServerStream[TournamentClientMessage, TournamentServerMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tournament_EnterServer = grpc.BidiStreamingServer[TournamentClientMessage, TournamentServerMessage]
+
+// Tournament_ServiceDesc is the grpc.ServiceDesc for Tournament service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Tournament_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "snake.match.v1.Tournament",
+	HandlerType: (*TournamentServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Enter",
+			Handler:       _Tournament_Enter_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "match.proto",
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"time"
+
+	"github.com/mattn/go-runewidth"
+
+	"snake/term"
+)
+
+const (
+	MenuFrameInterval = 120 * time.Millisecond
+	MenuRefresh       = time.Second
+	MenuSnakeLength   = 10
+)
+
+var titleWave = []term.Attribute{
+	term.ColorGreen,
+	term.ColorGreen,
+	term.ColorCyan,
+	term.ColorYellow,
+	term.ColorCyan,
+}
+
+func (g *Game) DrawTitle(x, y int, title []string) {
+	for i, line := range title {
+		column := x
+		for j, char := range []rune(line) {
+			color := term.ColorGreen
+			if !g.ReducedEffects() {
+				color = titleWave[((j+i-g.MenuFrame)%len(titleWave)+len(titleWave))%len(titleWave)]
+			}
+			screen.SetCell(column, y+i, DisplayRune(char), color|term.AttrBold, term.ColorDefault)
+			column += runewidth.RuneWidth(char)
+		}
+	}
+}
+
+func BoxPerimeter(left, top, right, bottom int) []Point {
+	var points []Point
+	for x := left; x < right; x++ {
+		points = append(points, Point{X: x, Y: top})
+	}
+	for y := top; y < bottom; y++ {
+		points = append(points, Point{X: right, Y: y})
+	}
+	for x := right; x > left; x-- {
+		points = append(points, Point{X: x, Y: bottom})
+	}
+	for y := bottom; y > top; y-- {
+		points = append(points, Point{X: left, Y: y})
+	}
+	return points
+}
+
+func (g *Game) DrawMenuSnake(x, y int, box []string) {
+	path := BoxPerimeter(x-1, y-1, x+BoxWidth(box), y+len(box))
+	if len(path) <= MenuSnakeLength {
+		return
+	}
+
+	head := g.MenuFrame % len(path)
+	for i := MenuSnakeLength - 1; i >= 0; i-- {
+		p := path[(head-i+len(path))%len(path)]
+		char, color := '█', term.ColorGreen
+		if i == 0 {
+			char, color = '●', term.ColorYellow
+		}
+		screen.SetCell(p.X, p.Y, DisplayRune(char), color, term.ColorDefault)
+	}
+}
+
+func (g *Game) TickMenu() {
+	if g.State != StateMenu {
+		return
+	}
+	if !g.ReducedEffects() {
+		g.MenuFrame++
+	} else if !g.MenuDirty && time.Since(g.MenuDrawnAt) < MenuRefresh {
+		return
+	}
+	g.MenuDirty = false
+	g.MenuDrawnAt = time.Now()
+	g.DrawMenu()
+}
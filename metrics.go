@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"snake/term"
+)
+
+const (
+	SampleEvery  = 10
+	ComboWindow  = 40
+	GraphWidth   = 40
+	GraphHeight  = 6
+	SummaryWidth = 46
+)
+
+var foodNames = map[FoodType]string{
+	NormalFood:  "Comida normal",
+	PowerUpFood: "Power-up",
+	PelletFood:  "Bolinha",
+	HeartFood:   "Coracao",
+}
+
+type RunMetrics struct {
+	StartedAt    time.Time
+	Duration     time.Duration
+	Ticks        int
+	ScoreSamples []int
+	FoodsEaten   map[FoodType]int
+	Combo        int
+	MaxCombo     int
+	LastEatTick  int
+	LevelTime    []time.Duration
+	DeathCause   string
+}
+
+func NewRunMetrics() RunMetrics {
+	return RunMetrics{
+		StartedAt:    time.Now(),
+		ScoreSamples: []int{0},
+		FoodsEaten:   map[FoodType]int{},
+		LastEatTick:  -ComboWindow,
+	}
+}
+
+func (g *Game) SubscribeMetrics() {
+	g.Events.Subscribe(EventFoodEaten, func(e Event) {
+		m := &g.Metrics
+		m.FoodsEaten[e.Food]++
+
+		if m.Ticks-m.LastEatTick <= ComboWindow {
+			m.Combo++
+		} else {
+			m.Combo = 1
+		}
+		m.LastEatTick = m.Ticks
+		m.MaxCombo = max(m.MaxCombo, m.Combo)
+	})
+}
+
+func (g *Game) CollectMetrics() {
+	m := &g.Metrics
+	m.Ticks++
+
+	for len(m.LevelTime) < g.Level {
+		m.LevelTime = append(m.LevelTime, 0)
+	}
+	m.LevelTime[g.Level-1] += g.TickInterval()
+
+	if m.Ticks%SampleEvery == 0 {
+		m.ScoreSamples = append(m.ScoreSamples, g.Score)
+	}
+}
+
+func (g *Game) CollisionCause(p Point) string {
+	switch {
+	case g.CheckWallCollision(p):
+		return "bateu na parede"
+	case g.CheckSelfCollision(p):
+		return "mordeu a propria cauda"
+	case g.CheckPartnerCollision(p):
+		return "bateu no parceiro"
+	case g.CheckRivalCollision(p):
+		return "bateu em um rival"
+	case g.CheckObstacleCollision(p):
+		return "bateu em um obstaculo"
+	case g.Boss.Contains(p):
+		return "bateu no chefe"
+	}
+	return "desconhecida"
+}
+
+func (m RunMetrics) ScoreGraph(width, height int) []string {
+	samples := m.ScoreSamples
+	peak := 0
+	for _, score := range samples {
+		peak = max(peak, score)
+	}
+
+	rows := make([][]rune, height)
+	for y := range rows {
+		rows[y] = []rune(strings.Repeat(" ", width))
+	}
+
+	for x := 0; x < width; x++ {
+		score := samples[x*len(samples)/width]
+		if peak == 0 {
+			continue
+		}
+		filled := score * height / peak
+		for y := 0; y < filled; y++ {
+			rows[height-1-y][x] = '█'
+		}
+	}
+
+	lines := make([]string, height)
+	for y, row := range rows {
+		lines[y] = string(row)
+	}
+	return lines
+}
+
+func (g *Game) SummaryLines() []string {
+	m := g.Metrics
+
+	lines := []string{
+		"RESUMO DA PARTIDA",
+		"",
+		fmt.Sprintf("Pontos: %d   Duracao: %s", g.Score, m.Duration.Round(time.Second)),
+		fmt.Sprintf("Causa da morte: %s", m.DeathCause),
+		fmt.Sprintf("Maior combo: %d", m.MaxCombo),
+		"",
+		"Pontuacao ao longo do tempo:",
+	}
+	lines = append(lines, m.ScoreGraph(GraphWidth, GraphHeight)...)
+
+	lines = append(lines, "", "Comidas:")
+	for _, foodType := range foodTypes {
+		if count := m.FoodsEaten[foodType]; count > 0 {
+			char, _ := foodBehaviors[foodType].Render(g, &Food{Type: foodType})
+			lines = append(lines, fmt.Sprintf("  %c %-16s %d", char, foodNames[foodType], count))
+		}
+	}
+
+	lines = append(lines, "", "Tempo por nivel:")
+	for i, duration := range m.LevelTime {
+		lines = append(lines, fmt.Sprintf("  Nivel %-3d %s", i+1, duration.Round(time.Second)))
+	}
+
+	return append(lines, "", "ENTER - Continuar   R - Reiniciar")
+}
+
+func (g *Game) DrawSummary() {
+	screen.Clear(term.ColorDefault, term.ColorDefault)
+
+	box := BoxLines(g.SummaryLines(), SummaryWidth)
+
+	screenWidth, screenHeight := screen.Size()
+	startX := max(0, screenWidth/2-BoxWidth(box)/2)
+	startY := max(0, screenHeight/2-len(box)/2)
+
+	DrawBox(startX, startY, box, func(i int) term.Attribute {
+		if i == 1 {
+			return term.ColorYellow | term.AttrBold
+		}
+		return term.ColorCyan
+	})
+
+	g.Flush()
+}
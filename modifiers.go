@@ -0,0 +1,96 @@
+package main
+
+import "strings"
+
+type Modifiers int
+
+const (
+	ModMirror Modifiers = 1 << iota
+	ModDoubleSpeed
+	ModNoWalls
+	ModTinyBoard
+	ModInvisibleTail
+	ModDayNight
+)
+
+var modifierNames = []struct {
+	Mod  Modifiers
+	Key  rune
+	Name string
+}{
+	{ModMirror, '1', "espelho"},
+	{ModDoubleSpeed, '2', "2x"},
+	{ModNoWalls, '3', "sem-parede"},
+	{ModTinyBoard, '4', "mini"},
+	{ModInvisibleTail, '5', "fantasma"},
+	{ModDayNight, '6', "dia-noite"},
+}
+
+func (m Modifiers) Has(mod Modifiers) bool {
+	return m&mod != 0
+}
+
+func (m Modifiers) Names() []string {
+	var names []string
+	for _, info := range modifierNames {
+		if m.Has(info.Mod) {
+			names = append(names, info.Name)
+		}
+	}
+	return names
+}
+
+func (m Modifiers) String() string {
+	if m == 0 {
+		return "nenhum"
+	}
+	return strings.Join(m.Names(), "+")
+}
+
+func (m Modifiers) Code() string {
+	return strings.Join(m.Names(), "-")
+}
+
+func (g *Game) ToggleModifier(key rune) bool {
+	if g.Mode == ModeWeekly {
+		return false
+	}
+	for _, info := range modifierNames {
+		if info.Key == key {
+			g.Challenge = nil
+			g.Modifiers ^= info.Mod
+			g.HighScore = LoadHighScore(g.Mode, g.Modifiers)
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) ApplyBoardSize() {
+	switch {
+	case g.Mode == ModePuzzle:
+		g.Width, g.Height = g.CurrentPuzzle().Size()
+	case g.Challenge != nil:
+		g.Width, g.Height = g.Challenge.Width, g.Challenge.Height
+	case g.Layout != nil:
+		g.Width, g.Height = g.Layout.Width, g.Layout.Height
+	case g.Modifiers.Has(ModTinyBoard):
+		g.Width, g.Height = MinWidth, MinHeight
+	default:
+		g.Width, g.Height = g.BaseWidth, g.BaseHeight
+	}
+}
+
+func (g *Game) WrapWalls(p Point) Point {
+	if g.Modifiers.Has(ModNoWalls) {
+		return g.Wrap(p)
+	}
+	return p
+}
+
+func (g *Game) MirrorDirection(direction Direction) Direction {
+	if g.Modifiers.Has(ModMirror) && (direction == DirLeft || direction == DirRight) {
+		return direction.Opposite()
+	}
+	return direction
+}
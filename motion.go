@@ -0,0 +1,24 @@
+package main
+
+import "snake/term"
+
+func (g *Game) ReducedMotion() bool {
+	return g.Settings.ReducedMotion
+}
+
+func (g *Game) Steady(period int) bool {
+	return g.ReducedEffects() || (g.FrameCount/period)%2 == 0
+}
+
+func (g *Game) DrawFoodMarker(setCell CellSetter) {
+	if !g.ReducedMotion() || g.Food.Type != PowerUpFood {
+		return
+	}
+	for _, dx := range []int{1, -1} {
+		p := Point{X: g.Food.Position.X + dx, Y: g.Food.Position.Y}
+		if p.X >= 0 && p.X < g.Width && !g.IsDeadly(p) && !g.Occupancy.HasSnake(p) {
+			setCell(p.X, p.Y, 'P', term.ColorYellow|term.AttrBold, term.ColorDefault)
+			return
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"snake/term"
+)
+
+const (
+	musicDir        = "music"
+	MusicVolume     = 0.3
+	midiDrumChannel = 9
+)
+
+var ErrUnsupportedMIDI = errors.New("MIDI nao suportado")
+
+type MusicNote struct {
+	At   time.Duration
+	Note Note
+}
+
+type Track struct {
+	Name   string
+	Notes  []MusicNote
+	Length time.Duration
+}
+
+type MusicPlayer struct {
+	mu     sync.Mutex
+	Tracks []Track
+	stop   chan struct{}
+}
+
+func LoadMusic(dir string) ([]Track, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var tracks []Track
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		var track Track
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json":
+			track, err = LoadTrackJSON(path)
+		case ".mid", ".midi":
+			track, err = LoadTrackMIDI(path)
+		default:
+			continue
+		}
+		if err != nil {
+			logger.Warn("musica ignorada", "arquivo", path, "erro", err)
+			continue
+		}
+		if len(track.Notes) == 0 || track.Length <= 0 {
+			continue
+		}
+		tracks = append(tracks, track)
+	}
+	return tracks, nil
+}
+
+func trackName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+func LoadTrackJSON(path string) (Track, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Track{}, err
+	}
+
+	var file struct {
+		Name  string `json:"name"`
+		Notes []Note `json:"notes"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Track{}, err
+	}
+
+	track := Track{Name: file.Name}
+	if track.Name == "" {
+		track.Name = trackName(path)
+	}
+	for i, note := range file.Notes {
+		if err := note.Validate(); err != nil {
+			return Track{}, fmt.Errorf("nota %d: %w", i+1, err)
+		}
+		note.Volume = note.Gain() * MusicVolume
+		track.Notes = append(track.Notes, MusicNote{At: track.Length, Note: note})
+		track.Length += note.Wait()
+	}
+	return track, nil
+}
+
+func LoadTrackMIDI(path string) (Track, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Track{}, err
+	}
+	track, err := ParseMIDI(data)
+	if err != nil {
+		return Track{}, err
+	}
+	if track.Name == "" {
+		track.Name = trackName(path)
+	}
+	return track, nil
+}
+
+type midiEvent struct {
+	tick     int
+	kind     byte
+	channel  byte
+	key      byte
+	velocity byte
+	tempo    int
+}
+
+func readVarLen(r *bytes.Reader) (int, error) {
+	value := 0
+	for i := 0; i < 4; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		value = value<<7 | int(b&0x7F)
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: tamanho variavel invalido", ErrUnsupportedMIDI)
+}
+
+func readBytes(r *bytes.Reader, length int) ([]byte, error) {
+	if length < 0 || length > r.Len() {
+		return nil, fmt.Errorf("%w: tamanho %d alem do fim do arquivo", ErrUnsupportedMIDI, length)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func readChunk(r *bytes.Reader, id string) ([]byte, error) {
+	header, err := readBytes(r, 8)
+	if err != nil {
+		return nil, err
+	}
+	if string(header[:4]) != id {
+		return nil, fmt.Errorf("%w: esperado %s, encontrado %q", ErrUnsupportedMIDI, id, header[:4])
+	}
+	return readBytes(r, int(binary.BigEndian.Uint32(header[4:])))
+}
+
+func parseMIDITrack(data []byte) ([]midiEvent, string, error) {
+	r := bytes.NewReader(data)
+	var events []midiEvent
+	var name string
+	var status byte
+	tick := 0
+
+	for r.Len() > 0 {
+		delta, err := readVarLen(r)
+		if err != nil {
+			return nil, "", err
+		}
+		tick += delta
+
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, "", err
+		}
+		if b < 0x80 {
+			if status == 0 {
+				return nil, "", fmt.Errorf("%w: running status sem status anterior", ErrUnsupportedMIDI)
+			}
+			r.UnreadByte()
+		} else {
+			status = b
+		}
+
+		switch {
+		case status == 0xFF:
+			kind, err := r.ReadByte()
+			if err != nil {
+				return nil, "", err
+			}
+			length, err := readVarLen(r)
+			if err != nil {
+				return nil, "", err
+			}
+			payload, err := readBytes(r, length)
+			if err != nil {
+				return nil, "", err
+			}
+			switch {
+			case kind == 0x03 && name == "":
+				name = string(payload)
+			case kind == 0x51 && length == 3:
+				events = append(events, midiEvent{tick: tick, kind: 0xFF,
+					tempo: int(payload[0])<<16 | int(payload[1])<<8 | int(payload[2])})
+			case kind == 0x2F:
+				return events, name, nil
+			}
+			status = 0
+		case status == 0xF0 || status == 0xF7:
+			length, err := readVarLen(r)
+			if err != nil {
+				return nil, "", err
+			}
+			if _, err := readBytes(r, length); err != nil {
+				return nil, "", err
+			}
+			status = 0
+		default:
+			kind, channel := status&0xF0, status&0x0F
+			size := 2
+			if kind == 0xC0 || kind == 0xD0 {
+				size = 1
+			}
+			params, err := readBytes(r, size)
+			if err != nil {
+				return nil, "", err
+			}
+			first := params[0]
+			var second byte
+			if size == 2 {
+				second = params[1]
+			}
+			if kind == 0x90 && second == 0 {
+				kind = 0x80
+			}
+			if (kind == 0x80 || kind == 0x90) && channel != midiDrumChannel {
+				events = append(events, midiEvent{tick: tick, kind: kind, channel: channel, key: first, velocity: second})
+			}
+		}
+	}
+	return events, name, nil
+}
+
+func ParseMIDI(data []byte) (Track, error) {
+	r := bytes.NewReader(data)
+	header, err := readChunk(r, "MThd")
+	if err != nil {
+		return Track{}, err
+	}
+	if len(header) < 6 {
+		return Track{}, fmt.Errorf("%w: cabecalho curto", ErrUnsupportedMIDI)
+	}
+	format := binary.BigEndian.Uint16(header[0:])
+	tracks := int(binary.BigEndian.Uint16(header[2:]))
+	division := int(binary.BigEndian.Uint16(header[4:]))
+	if format > 1 {
+		return Track{}, fmt.Errorf("%w: formato %d", ErrUnsupportedMIDI, format)
+	}
+	if division&0x8000 != 0 || division == 0 {
+		return Track{}, fmt.Errorf("%w: divisao SMPTE", ErrUnsupportedMIDI)
+	}
+
+	var events []midiEvent
+	var name string
+	for i := 0; i < tracks; i++ {
+		chunk, err := readChunk(r, "MTrk")
+		if err != nil {
+			return Track{}, err
+		}
+		trackEvents, trackName, err := parseMIDITrack(chunk)
+		if err != nil {
+			return Track{}, err
+		}
+		events = append(events, trackEvents...)
+		if name == "" {
+			name = trackName
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].tick < events[j].tick })
+
+	track := Track{Name: name}
+	tempo, lastTick := 500000, 0
+	var now time.Duration
+	started := map[[2]byte]midiEvent{}
+	startedAt := map[[2]byte]time.Duration{}
+
+	for _, e := range events {
+		now += time.Duration(e.tick-lastTick) * time.Duration(tempo) * time.Microsecond / time.Duration(division)
+		lastTick = e.tick
+
+		key := [2]byte{e.channel, e.key}
+		switch e.kind {
+		case 0xFF:
+			tempo = e.tempo
+		case 0x90:
+			started[key] = e
+			startedAt[key] = now
+		case 0x80:
+			on, ok := started[key]
+			if !ok {
+				continue
+			}
+			delete(started, key)
+			duration := now - startedAt[key]
+			if duration < time.Millisecond {
+				continue
+			}
+			track.Notes = append(track.Notes, MusicNote{At: startedAt[key], Note: Note{
+				Freq:      440 * math.Pow(2, float64(int(on.key)-69)/12),
+				Ms:        int(min(duration, 5*time.Second) / time.Millisecond),
+				ReleaseMs: 10,
+				Volume:    float64(on.velocity) / 127 * MusicVolume,
+			}})
+			track.Length = max(track.Length, now)
+		}
+	}
+
+	sort.SliceStable(track.Notes, func(i, j int) bool { return track.Notes[i].At < track.Notes[j].At })
+	return track, nil
+}
+
+func (m *MusicPlayer) Play(track Track) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.stopLocked()
+	stop := make(chan struct{})
+	m.stop = stop
+
+	go func() {
+		for {
+			start := time.Now()
+			for _, n := range track.Notes {
+				select {
+				case <-stop:
+					return
+				case <-time.After(time.Until(start.Add(n.At))):
+				}
+				playTone(n.Note, 0)
+			}
+			select {
+			case <-stop:
+				return
+			case <-time.After(time.Until(start.Add(track.Length))):
+			}
+		}
+	}()
+}
+
+func (m *MusicPlayer) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopLocked()
+}
+
+func (m *MusicPlayer) stopLocked() {
+	if m.stop != nil {
+		close(m.stop)
+		m.stop = nil
+	}
+}
+
+func (g *Game) PlayLevelTrack() {
+	if g.Mode == ModeZen {
+		g.Music.Play(AmbientTrack(g.Seed))
+		return
+	}
+	tracks := g.Music.Tracks
+	if len(tracks) == 0 {
+		return
+	}
+	track := tracks[(g.Level-1)%len(tracks)]
+	g.Music.Play(track)
+	g.ShowToast("Musica: "+track.Name, term.ColorMagenta)
+}
+
+func (g *Game) SubscribeMusic() {
+	if !g.Settings.Music || !soundInitialized {
+		return
+	}
+	tracks, err := LoadMusic(musicDir)
+	if err != nil {
+		logger.Error("falha ao carregar musicas", "erro", err)
+		return
+	}
+	g.Music.Tracks = tracks
+
+	g.Events.Subscribe(EventGameStart, func(e Event) {
+		g.PlayLevelTrack()
+	})
+	g.Events.Subscribe(EventLevelUp, func(e Event) {
+		g.PlayLevelTrack()
+	})
+	g.Events.Subscribe(EventDeath, func(e Event) {
+		if g.State == StateGameOver {
+			g.Music.Stop()
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"time"
+
+	"snake/matchpb"
+	"snake/term"
+)
+
+const (
+	AnnotationTTL  = 5 * time.Second
+	MaxAnnotations = 5
+)
+
+var annotationGlyphs = []rune{'!', '?', '*', 'x', 'o'}
+
+type Annotation struct {
+	Position Point
+	Glyph    rune
+	From     string
+	Expires  time.Time
+}
+
+type ObserverCursor struct {
+	Position Point
+	Glyph    int
+}
+
+func AnnotationGlyph(glyph string) rune {
+	for _, r := range glyph {
+		for _, allowed := range annotationGlyphs {
+			if r == allowed {
+				return r
+			}
+		}
+		break
+	}
+	return annotationGlyphs[0]
+}
+
+func (g *Game) Annotate(c *NetClient, a *matchpb.Annotation) {
+	if c.Role != matchpb.Role_ROLE_OBSERVER {
+		return
+	}
+	p := pointFromBot(a.GetPosition())
+	if g.CheckWallCollision(p) {
+		return
+	}
+
+	now := time.Now()
+	g.PruneAnnotations(now)
+	mine := 0
+	for _, other := range g.Annotations {
+		if other.From == c.Name {
+			mine++
+		}
+	}
+	if mine >= MaxAnnotations {
+		for i, other := range g.Annotations {
+			if other.From == c.Name {
+				g.Annotations = append(g.Annotations[:i], g.Annotations[i+1:]...)
+				break
+			}
+		}
+	}
+	g.Annotations = append(g.Annotations, Annotation{
+		Position: p,
+		Glyph:    AnnotationGlyph(a.GetGlyph()),
+		From:     c.Name,
+		Expires:  now.Add(AnnotationTTL),
+	})
+}
+
+func (g *Game) PruneAnnotations(now time.Time) {
+	live := g.Annotations[:0]
+	for _, a := range g.Annotations {
+		if now.Before(a.Expires) {
+			live = append(live, a)
+		}
+	}
+	g.Annotations = live
+}
+
+func (g *Game) AnnotationStates() []*matchpb.Annotation {
+	now := time.Now()
+	g.PruneAnnotations(now)
+	var states []*matchpb.Annotation
+	for _, a := range g.Annotations {
+		states = append(states, &matchpb.Annotation{
+			Position: botPoint(a.Position),
+			Glyph:    string(a.Glyph),
+			From:     a.From,
+			TtlMs:    int32(a.Expires.Sub(now).Milliseconds()),
+		})
+	}
+	return states
+}
+
+func (g *Game) ApplyAnnotations(states []*matchpb.Annotation) {
+	now := time.Now()
+	g.Annotations = g.Annotations[:0]
+	for _, a := range states {
+		g.Annotations = append(g.Annotations, Annotation{
+			Position: pointFromBot(a.GetPosition()),
+			Glyph:    AnnotationGlyph(a.GetGlyph()),
+			From:     a.GetFrom(),
+			Expires:  now.Add(time.Duration(a.GetTtlMs()) * time.Millisecond),
+		})
+	}
+}
+
+func (g *Game) DrawAnnotations(setCell CellSetter) {
+	now := time.Now()
+	for _, a := range g.Annotations {
+		if now.Before(a.Expires) {
+			setCell(a.Position.X, a.Position.Y, a.Glyph, term.ColorYellow|term.AttrBold, term.ColorMagenta)
+		}
+	}
+	if l := g.Remote; l != nil && l.Observing() && g.Steady(3) {
+		setCell(l.Cursor.Position.X, l.Cursor.Position.Y, annotationGlyphs[l.Cursor.Glyph], term.ColorMagenta|term.AttrBold, term.ColorDefault)
+	}
+}
+
+func (l *RemoteLink) Observing() bool {
+	return l.Role == matchpb.Role_ROLE_OBSERVER
+}
+
+func (l *RemoteLink) Annotate(p Point) {
+	l.Send(&matchpb.ClientMessage{Message: &matchpb.ClientMessage_Annotation{Annotation: &matchpb.Annotation{
+		Position: botPoint(p),
+		Glyph:    string(annotationGlyphs[l.Cursor.Glyph]),
+	}}})
+}
+
+func (g *Game) HandleObserverKey(ev term.Event) {
+	cursor := &g.Remote.Cursor
+	if direction, ok := g.Bindings().Direction(ev); ok {
+		if next := cursor.Position.Move(direction); !g.CheckWallCollision(next) {
+			cursor.Position = next
+		}
+		return
+	}
+	switch {
+	case ev.Key == term.KeySpace || ev.Key == term.KeyEnter:
+		g.Remote.Annotate(cursor.Position)
+	case ev.Ch >= '1' && int(ev.Ch-'1') < len(annotationGlyphs):
+		cursor.Glyph = int(ev.Ch - '1')
+	}
+}
+
+func (g *Game) HandleObserverMouse(ev term.Event) {
+	if !g.Remote.Observing() || ev.Key != term.MouseLeft {
+		return
+	}
+	p := g.Camera.ToBoard(ev.MouseX, ev.MouseY)
+	if g.CheckWallCollision(p) {
+		return
+	}
+	g.Remote.Cursor.Position = p
+	g.Remote.Annotate(p)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"snake/botpb"
+	"snake/matchpb"
+	"snake/term"
+)
+
+func annotation(x, y int, glyph string) *matchpb.Annotation {
+	return &matchpb.Annotation{Position: &botpb.Point{X: int32(x), Y: int32(y)}, Glyph: glyph}
+}
+
+func TestAnnotate(t *testing.T) {
+	g := newTestGame(t, ModeClassic)
+	coach := &NetClient{Name: "tecnico", Role: matchpb.Role_ROLE_OBSERVER}
+	viewer := &NetClient{Name: "torcida", Role: matchpb.Role_ROLE_SPECTATOR}
+
+	g.Annotate(viewer, annotation(5, 5, "!"))
+	g.Annotate(coach, annotation(0, 0, "!"))
+	if len(g.Annotations) != 0 {
+		t.Fatalf("marcacao aceita de espectador ou na parede: %+v", g.Annotations)
+	}
+
+	g.Annotate(coach, annotation(5, 5, "?"))
+	g.Annotate(coach, annotation(6, 5, "#"))
+	if len(g.Annotations) != 2 || g.Annotations[0].Glyph != '?' || g.Annotations[1].Glyph != annotationGlyphs[0] {
+		t.Fatalf("marcacoes: %+v", g.Annotations)
+	}
+	for i := range MaxAnnotations {
+		g.Annotate(coach, annotation(5, 6+i, "*"))
+	}
+	if len(g.Annotations) != MaxAnnotations || g.Annotations[0].Position != (Point{X: 5, Y: 6}) {
+		t.Errorf("limite de marcacoes por observador: %+v", g.Annotations)
+	}
+
+	g.Annotations[0].Expires = time.Now().Add(-time.Second)
+	states := g.Snapshot().GetAnnotations()
+	if len(states) != MaxAnnotations-1 || states[0].GetFrom() != "tecnico" || states[0].GetTtlMs() <= 0 {
+		t.Errorf("marcacoes no snapshot: %v", states)
+	}
+}
+
+func TestDrawAnnotations(t *testing.T) {
+	g := newTestGame(t, ModeClassic)
+	g.Remote = &RemoteLink{Role: matchpb.Role_ROLE_OBSERVER, Slot: -1}
+	g.ApplyAnnotations([]*matchpb.Annotation{
+		{Position: &botpb.Point{X: 3, Y: 4}, Glyph: "x", TtlMs: 1000},
+		{Position: &botpb.Point{X: 7, Y: 4}, Glyph: "o", TtlMs: 0},
+	})
+	g.Remote.Cursor = ObserverCursor{Position: Point{X: 9, Y: 9}, Glyph: 1}
+
+	cells := map[Point]rune{}
+	g.DrawAnnotations(func(x, y int, ch rune, fg, bg term.Attribute) { cells[Point{X: x, Y: y}] = ch })
+	if cells[Point{X: 3, Y: 4}] != 'x' || cells[Point{X: 7, Y: 4}] != 0 || cells[Point{X: 9, Y: 9}] != '?' {
+		t.Errorf("camada de marcacoes: %v", cells)
+	}
+
+	g.HandleObserverKey(term.Event{Type: term.EventKey, Ch: '3'})
+	g.HandleObserverKey(term.Event{Type: term.EventKey, Key: term.KeyArrowLeft})
+	if g.Remote.Cursor.Glyph != 2 || g.Remote.Cursor.Position != (Point{X: 8, Y: 9}) {
+		t.Errorf("cursor do observador: %+v", g.Remote.Cursor)
+	}
+}
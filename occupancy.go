@@ -0,0 +1,128 @@
+package main
+
+type OccupantLayer int
+
+const (
+	LayerPlayer OccupantLayer = iota
+	LayerPartner
+	LayerRival
+	layerCount
+)
+
+type occupancyCell struct {
+	snakes   [layerCount]uint16
+	obstacle int
+}
+
+type OccupancyGrid struct {
+	Width  int
+	Height int
+	cells  []occupancyCell
+}
+
+func NewOccupancyGrid(width, height int) *OccupancyGrid {
+	return &OccupancyGrid{
+		Width:  width,
+		Height: height,
+		cells:  make([]occupancyCell, width*height),
+	}
+}
+
+func (o *OccupancyGrid) cell(p Point) *occupancyCell {
+	if p.X < 0 || p.X >= o.Width || p.Y < 0 || p.Y >= o.Height {
+		return nil
+	}
+	return &o.cells[p.Y*o.Width+p.X]
+}
+
+func (o *OccupancyGrid) Add(layer OccupantLayer, p Point) {
+	if c := o.cell(p); c != nil {
+		c.snakes[layer]++
+	}
+}
+
+func (o *OccupancyGrid) Remove(layer OccupantLayer, p Point) {
+	if c := o.cell(p); c != nil && c.snakes[layer] > 0 {
+		c.snakes[layer]--
+	}
+}
+
+func (o *OccupancyGrid) AddBody(layer OccupantLayer, body *SnakeBody) {
+	for _, p := range body.All() {
+		o.Add(layer, p)
+	}
+}
+
+func (o *OccupancyGrid) RemoveBody(layer OccupantLayer, body *SnakeBody) {
+	for _, p := range body.All() {
+		o.Remove(layer, p)
+	}
+}
+
+func (o *OccupancyGrid) Has(layer OccupantLayer, p Point) bool {
+	c := o.cell(p)
+	return c != nil && c.snakes[layer] > 0
+}
+
+func (o *OccupancyGrid) HasSnake(p Point) bool {
+	c := o.cell(p)
+	if c == nil {
+		return false
+	}
+	for _, count := range c.snakes {
+		if count > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *OccupancyGrid) SetObstacle(p Point, index int) {
+	if c := o.cell(p); c != nil {
+		c.obstacle = index + 1
+	}
+}
+
+func (o *OccupancyGrid) ObstacleIndex(p Point) int {
+	c := o.cell(p)
+	if c == nil {
+		return -1
+	}
+	return c.obstacle - 1
+}
+
+func (o *OccupancyGrid) ClearObstacles() {
+	for i := range o.cells {
+		o.cells[i].obstacle = 0
+	}
+}
+
+func (g *Game) LayerOf(s *Snake) OccupantLayer {
+	if s == &g.Coop.Partner {
+		return LayerPartner
+	}
+	return LayerPlayer
+}
+
+func (g *Game) IndexObstacles() {
+	g.Occupancy.ClearObstacles()
+	for i, obs := range g.Obstacles {
+		g.Occupancy.SetObstacle(obs.Position, i)
+	}
+}
+
+func (g *Game) RebuildOccupancy() {
+	if g.Occupancy == nil || g.Occupancy.Width != g.Width || g.Occupancy.Height != g.Height {
+		g.Occupancy = NewOccupancyGrid(g.Width, g.Height)
+	} else {
+		clear(g.Occupancy.cells)
+	}
+
+	g.Occupancy.AddBody(LayerPlayer, &g.Snake.Body)
+	g.Occupancy.AddBody(LayerPlayer, &g.Twin.Body)
+	g.Occupancy.AddBody(LayerPartner, &g.Coop.Partner.Body)
+	for _, r := range g.Rivals {
+		g.Occupancy.AddBody(LayerRival, &r.Snake.Body)
+	}
+	g.IndexObstacles()
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"image/color"
+
+	"snake/term"
+)
+
+var CellBackground = color.RGBA{18, 18, 24, 255}
+
+var cellPalette = [...]color.RGBA{
+	term.ColorDefault:      {204, 204, 204, 255},
+	term.ColorBlack:        {0, 0, 0, 255},
+	term.ColorRed:          {205, 49, 49, 255},
+	term.ColorGreen:        {13, 188, 121, 255},
+	term.ColorYellow:       {229, 229, 16, 255},
+	term.ColorBlue:         {36, 114, 200, 255},
+	term.ColorMagenta:      {188, 63, 188, 255},
+	term.ColorCyan:         {17, 168, 205, 255},
+	term.ColorWhite:        {229, 229, 229, 255},
+	term.ColorDarkGray:     {102, 102, 102, 255},
+	term.ColorLightRed:     {241, 76, 76, 255},
+	term.ColorLightGreen:   {35, 209, 139, 255},
+	term.ColorLightYellow:  {245, 245, 67, 255},
+	term.ColorLightBlue:    {59, 142, 234, 255},
+	term.ColorLightMagenta: {214, 112, 214, 255},
+	term.ColorLightCyan:    {41, 184, 219, 255},
+	term.ColorLightGray:    {255, 255, 255, 255},
+}
+
+func attrColor(attr term.Attribute) color.RGBA {
+	base := attr & 0x1FF
+	if int(base) >= len(cellPalette) {
+		return cellPalette[term.ColorDefault]
+	}
+	if attr&term.AttrBold != 0 && base >= term.ColorBlack && base <= term.ColorWhite {
+		base += term.ColorDarkGray - term.ColorBlack
+	}
+	c := cellPalette[base]
+	if attr&term.AttrDim != 0 {
+		c.R, c.G, c.B = c.R/2, c.G/2, c.B/2
+	}
+	return c
+}
+
+func CellColors(cell term.Cell) (fg, bg color.RGBA) {
+	fg, bg = attrColor(cell.Fg), CellBackground
+	if cell.Bg&0x1FF != term.ColorDefault {
+		bg = attrColor(cell.Bg)
+	}
+	if cell.Fg&term.AttrReverse != 0 {
+		fg, bg = bg, fg
+	}
+	return fg, bg
+}
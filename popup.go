@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"snake/term"
+)
+
+const (
+	PopupFrames      = 15
+	PopupRiseEvery   = 5
+	MaxDistanceBonus = 2.0
+)
+
+type Popup struct {
+	Position  Point
+	Text      string
+	SpawnedAt int
+}
+
+func (g *Game) SubscribePopups() {
+	g.Events.Subscribe(EventFoodEaten, func(e Event) {
+		g.Popups = append(g.Popups, Popup{
+			Position:  e.Position,
+			Text:      fmt.Sprintf("+%d", e.Points),
+			SpawnedAt: g.FrameCount,
+		})
+	})
+}
+
+func (g *Game) FoodPoints(base int, f *Food) int {
+	if f.SpawnDistance == 0 {
+		return base
+	}
+
+	ticks := max(1, g.PlayTicks-f.SpawnTick)
+	efficiency := float64(f.SpawnDistance) / float64(max(ticks, f.SpawnDistance))
+	distance := float64(f.SpawnDistance) / float64(g.Width+g.Height)
+
+	bonus := float64(base) * distance * efficiency * MaxDistanceBonus
+	return base + int(math.Round(bonus))
+}
+
+func (g *Game) ExpirePopups() {
+	live := g.Popups[:0]
+	for _, popup := range g.Popups {
+		if g.FrameCount-popup.SpawnedAt < PopupFrames {
+			live = append(live, popup)
+		}
+	}
+	g.Popups = live
+}
+
+func (g *Game) DrawPopups(setCell CellSetter) {
+	for _, popup := range g.Popups {
+		y := popup.Position.Y - 1 - (g.FrameCount-popup.SpawnedAt)/PopupRiseEvery
+		for i, char := range popup.Text {
+			setCell(popup.Position.X+i, y, char, term.ColorYellow|term.AttrBold, term.ColorDefault)
+		}
+	}
+}
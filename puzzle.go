@@ -0,0 +1,216 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+
+	"snake/term"
+)
+
+//go:embed assets/puzzles/*.json
+var bundledPuzzles embed.FS
+
+var puzzleLevels = mustLoadPuzzles()
+
+type PuzzleLevel struct {
+	Name  string   `json:"name"`
+	Moves int      `json:"moves"`
+	Map   []string `json:"map"`
+}
+
+type Puzzle struct {
+	Index  int
+	Moves  int
+	Left   int
+	Solved bool
+}
+
+func mustLoadPuzzles() []PuzzleLevel {
+	entries, err := bundledPuzzles.ReadDir("assets/puzzles")
+	if err != nil {
+		panic("quebra-cabecas embutidos ausentes: " + err.Error())
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	var levels []PuzzleLevel
+	for _, name := range names {
+		data, err := bundledPuzzles.ReadFile(path.Join("assets/puzzles", name))
+		if err != nil {
+			panic(err)
+		}
+		var level PuzzleLevel
+		if err := json.Unmarshal(data, &level); err != nil {
+			panic(fmt.Sprintf("quebra-cabeca invalido %s: %v", name, err))
+		}
+		if err := level.Validate(); err != nil {
+			panic(fmt.Sprintf("quebra-cabeca invalido %s: %v", name, err))
+		}
+		levels = append(levels, level)
+	}
+	return levels
+}
+
+func (p PuzzleLevel) Size() (int, int) {
+	return len([]rune(p.Map[0])) + 2, len(p.Map) + 2
+}
+
+func (p PuzzleLevel) cells(char rune) []Point {
+	var points []Point
+	for y, row := range p.Map {
+		for x, c := range []rune(row) {
+			if c == char {
+				points = append(points, Point{X: x + 1, Y: y + 1})
+			}
+		}
+	}
+	return points
+}
+
+func (p PuzzleLevel) Spawn() Point {
+	return p.cells('S')[0]
+}
+
+func (p PuzzleLevel) Walls() []Point {
+	return p.cells('#')
+}
+
+func (p PuzzleLevel) Foods() []Point {
+	return p.cells('*')
+}
+
+func (p PuzzleLevel) Validate() error {
+	if len(p.Map) == 0 {
+		return fmt.Errorf("mapa vazio")
+	}
+	width := len([]rune(p.Map[0]))
+	for i, row := range p.Map {
+		if len([]rune(row)) != width {
+			return fmt.Errorf("linha %d com largura diferente", i+1)
+		}
+	}
+	if len(p.cells('S')) != 1 {
+		return fmt.Errorf("o mapa precisa de exatamente um S")
+	}
+	spawn := p.Spawn()
+	for dx := 1; dx <= 2; dx++ {
+		x, y := spawn.X-dx-1, spawn.Y-1
+		if x < 0 || []rune(p.Map[y])[x] != '.' {
+			return fmt.Errorf("as duas casas a esquerda do S precisam estar livres")
+		}
+	}
+	if len(p.Foods()) == 0 {
+		return fmt.Errorf("o mapa precisa de pelo menos uma comida (*)")
+	}
+	if p.Moves <= 0 {
+		return fmt.Errorf("moves precisa ser positivo")
+	}
+	return nil
+}
+
+func (g *Game) TurnBased() bool {
+	return g.Mode == ModePuzzle
+}
+
+func (g *Game) RequestStep(direction Direction) {
+	if !g.TurnBased() || g.Snake.Direction != g.MirrorDirection(direction) {
+		return
+	}
+	select {
+	case g.Steps <- struct{}{}:
+	default:
+	}
+}
+
+func (g *Game) CurrentPuzzle() PuzzleLevel {
+	return puzzleLevels[g.Puzzle.Index%len(puzzleLevels)]
+}
+
+func (g *Game) SubscribePuzzle() {
+	g.Events.Subscribe(EventFoodEaten, func(e Event) {
+		if g.Mode == ModePuzzle {
+			g.Puzzle.Left--
+		}
+	})
+}
+
+func (g *Game) StartPuzzle() {
+	level := g.CurrentPuzzle()
+	g.Level = g.Puzzle.Index + 1
+	g.Puzzle.Moves = 0
+	g.Puzzle.Solved = false
+
+	foods := level.Foods()
+	g.Puzzle.Left = len(foods)
+	g.Pellets = nil
+	for _, p := range foods {
+		g.Pellets = append(g.Pellets, Food{Position: p, Type: NormalFood})
+	}
+	g.promotePuzzleFood()
+}
+
+func (g *Game) promotePuzzleFood() {
+	g.Food = g.Pellets[0]
+	g.Pellets = g.Pellets[1:]
+}
+
+func (g *Game) NextPuzzleFood() {
+	if len(g.Pellets) > 0 && g.Occupancy.HasSnake(g.Food.Position) {
+		g.promotePuzzleFood()
+	}
+}
+
+func (g *Game) PlacePuzzleWalls() {
+	g.Obstacles = []Obstacle{}
+	g.Occupancy.ClearObstacles()
+	for _, wall := range g.CurrentPuzzle().Walls() {
+		g.Obstacles = append(g.Obstacles, Obstacle{Position: wall, Type: WallObstacle})
+	}
+	g.IndexObstacles()
+}
+
+func (g *Game) LoadNextPuzzle() {
+	g.Puzzle.Index++
+	g.ApplyBoardSize()
+	g.Snake = g.NewPlayerSnake()
+	g.RebuildOccupancy()
+	g.PlacePuzzleWalls()
+	g.StartPuzzle()
+	g.Emit(Event{Type: EventLevelUp, Position: g.FocusPoint()})
+}
+
+func (g *Game) UpdatePuzzle() {
+	g.Puzzle.Moves++
+	switch {
+	case g.Puzzle.Left <= 0 && g.Puzzle.Index+1 < len(puzzleLevels):
+		g.ShowToast(fmt.Sprintf("Resolvido em %d movimentos!", g.Puzzle.Moves), term.ColorGreen)
+		g.LoadNextPuzzle()
+	case g.Puzzle.Left <= 0:
+		g.Puzzle.Solved = true
+		g.Metrics.DeathCause = "resolveu todos os quebra-cabecas"
+		g.EndGame(g.Snake.Body.Head())
+		g.Puzzle.Index = 0
+	case g.Puzzle.Moves >= g.CurrentPuzzle().Moves:
+		g.Metrics.DeathCause = "acabaram os movimentos"
+		g.EndGame(g.Snake.Body.Head())
+	}
+}
+
+func (g *Game) PuzzleResult() string {
+	if g.Puzzle.Solved {
+		return fmt.Sprintf("Todos os %d quebra-cabecas resolvidos!", len(puzzleLevels))
+	}
+	return fmt.Sprintf("Quebra-cabeca %d/%d: %s", g.Puzzle.Index+1, len(puzzleLevels), g.CurrentPuzzle().Name)
+}
+
+func (g *Game) PuzzleHUD() string {
+	level := g.CurrentPuzzle()
+	return fmt.Sprintf("| %s | Movimentos: %d/%d | Comida: %d ", level.Name, g.Puzzle.Moves, level.Moves, g.Puzzle.Left)
+}
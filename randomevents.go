@@ -0,0 +1,213 @@
+package main
+
+import (
+	"math/rand"
+	"time"
+
+	"snake/term"
+)
+
+const (
+	RandomEventMinGap = 30 * time.Second
+	RandomEventMaxGap = 60 * time.Second
+	RandomEventBanner = 3 * time.Second
+	FoodRainCount     = 5
+	BlackoutDuration  = 5 * time.Second
+)
+
+const (
+	EventFoodRain   = "chuva"
+	EventBlackout   = "apagao"
+	EventEarthquake = "terremoto"
+)
+
+type RandomEvent interface {
+	Banner() string
+	Duration() time.Duration
+	Available(g *Game) bool
+	Start(g *Game)
+}
+
+type RandomEvents struct {
+	Next       time.Duration
+	Active     string
+	Left       time.Duration
+	Banner     string
+	BannerLeft time.Duration
+	rng        *rand.Rand
+}
+
+var randomEvents = map[string]RandomEvent{}
+
+var randomEventModes = map[GameMode][]string{
+	ModeClassic:  {EventFoodRain, EventBlackout, EventEarthquake},
+	ModeBattle:   {EventFoodRain, EventBlackout, EventEarthquake},
+	ModeCoop:     {EventFoodRain, EventBlackout},
+	ModeLives:    {EventFoodRain, EventBlackout, EventEarthquake},
+	ModeCasual:   {EventFoodRain, EventBlackout, EventEarthquake},
+	ModeFog:      {EventFoodRain, EventEarthquake},
+	ModeHunger:   {EventFoodRain, EventBlackout, EventEarthquake},
+	ModeDebris:   {EventFoodRain, EventBlackout, EventEarthquake},
+	ModeShrink:   {EventFoodRain, EventBlackout},
+	ModeHardcore: {EventFoodRain, EventBlackout, EventEarthquake},
+}
+
+func RegisterRandomEvent(name string, event RandomEvent) {
+	randomEvents[name] = event
+}
+
+func init() {
+	RegisterRandomEvent(EventFoodRain, FoodRainEvent{})
+	RegisterRandomEvent(EventBlackout, BlackoutEvent{})
+	RegisterRandomEvent(EventEarthquake, EarthquakeEvent{})
+}
+
+func (g *Game) SubscribeRandomEvents() {
+	g.Events.Subscribe(EventGameStart, func(e Event) {
+		g.RandomEvents = RandomEvents{rng: rand.New(rand.NewSource(g.Seed))}
+		g.RandomEvents.Next = g.RandomEvents.gap()
+	})
+	g.Events.Subscribe(EventTick, func(e Event) {
+		if g.State == StatePlaying {
+			g.UpdateRandomEvents()
+		}
+	})
+	g.Events.Subscribe(EventRandom, func(e Event) {
+		g.RandomEvents.Banner = randomEvents[e.Name].Banner()
+		g.RandomEvents.BannerLeft = RandomEventBanner
+	})
+}
+
+func (r *RandomEvents) gap() time.Duration {
+	return RandomEventMinGap + time.Duration(r.rng.Int63n(int64(RandomEventMaxGap-RandomEventMinGap)))
+}
+
+func (g *Game) RandomEventsEnabled() bool {
+	return g.Settings.RandomEvents && len(randomEventModes[g.Mode]) > 0 && !g.Demo
+}
+
+func (g *Game) UpdateRandomEvents() {
+	if !g.RandomEventsEnabled() || g.RandomEvents.rng == nil {
+		return
+	}
+
+	tick := g.TickInterval()
+	r := &g.RandomEvents
+	r.BannerLeft = max(0, r.BannerLeft-tick)
+	if r.Active != "" {
+		r.Left -= tick
+		if r.Left <= 0 {
+			r.Active = ""
+		}
+		return
+	}
+
+	r.Next -= tick
+	if r.Next > 0 {
+		return
+	}
+	r.Next = r.gap()
+
+	var candidates []string
+	for _, name := range randomEventModes[g.Mode] {
+		if randomEvents[name].Available(g) {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+	g.TriggerRandomEvent(candidates[r.rng.Intn(len(candidates))])
+}
+
+func (g *Game) TriggerRandomEvent(name string) {
+	event := randomEvents[name]
+	event.Start(g)
+	if event.Duration() > 0 {
+		g.RandomEvents.Active = name
+		g.RandomEvents.Left = event.Duration()
+	}
+	g.Emit(Event{Type: EventRandom, Position: g.FocusPoint(), Name: name})
+}
+
+func (g *Game) RandomEventActive(name string) bool {
+	return g.RandomEvents.Active == name
+}
+
+func (g *Game) DrawRandomEventBanner() {
+	if g.RandomEvents.BannerLeft <= 0 || g.State != StatePlaying {
+		return
+	}
+
+	banner := " " + g.RandomEvents.Banner + " "
+	centerX, _ := g.Camera.Center()
+	startX := max(0, centerX-len([]rune(banner))/2)
+	DrawText(startX, g.Camera.OffsetY+1, banner, term.ColorBlack|term.AttrBold, term.ColorMagenta)
+}
+
+type FoodRainEvent struct{}
+
+func (FoodRainEvent) Banner() string { return "CHUVA DE COMIDA!" }
+
+func (FoodRainEvent) Duration() time.Duration { return 0 }
+
+func (FoodRainEvent) Available(g *Game) bool { return true }
+
+func (FoodRainEvent) Start(g *Game) {
+	for i := 0; i < FoodRainCount; i++ {
+		for attempts := 0; attempts < 50; attempts++ {
+			pos := g.RandomCell()
+			if g.IsPositionSafe(pos) {
+				g.Pellets = append(g.Pellets, Food{Position: pos, Type: NormalFood, SpawnTick: g.PlayTicks})
+				break
+			}
+		}
+	}
+}
+
+type BlackoutEvent struct{}
+
+func (BlackoutEvent) Banner() string { return "APAGAO!" }
+
+func (BlackoutEvent) Duration() time.Duration { return BlackoutDuration }
+
+func (BlackoutEvent) Available(g *Game) bool { return true }
+
+func (BlackoutEvent) Start(g *Game) {}
+
+type EarthquakeEvent struct{}
+
+func (EarthquakeEvent) Banner() string { return "TERREMOTO!" }
+
+func (EarthquakeEvent) Duration() time.Duration { return 0 }
+
+func (EarthquakeEvent) Available(g *Game) bool {
+	if g.Layout != nil {
+		return false
+	}
+	for _, obs := range g.Obstacles {
+		if obs.Type == WallObstacle && obs.IsSolid() {
+			return true
+		}
+	}
+	return false
+}
+
+func (EarthquakeEvent) Start(g *Game) {
+	for i := range g.Obstacles {
+		obs := &g.Obstacles[i]
+		if obs.Type != WallObstacle || !obs.IsSolid() {
+			continue
+		}
+		for attempts := 0; attempts < 50; attempts++ {
+			pos := g.RandomCell()
+			if g.IsPositionSafe(pos) && !g.IsAheadOfPlayers(pos) {
+				obs.Position = pos
+				g.IndexObstacles()
+				break
+			}
+		}
+	}
+	g.TriggerShake(4, 3)
+	g.GrantSpawnGrace()
+}
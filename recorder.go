@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"snake/term"
+)
+
+const castVersion = 2
+
+const (
+	deathMarker    = "morte"
+	fairPlayMarker = "entradas "
+)
+
+type Recorder struct {
+	mu        sync.Mutex
+	file      *os.File
+	writer    *bufio.Writer
+	start     time.Time
+	lastFrame string
+	err       error
+}
+
+func NewRecorder(path string, width, height int) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{
+		file:   file,
+		writer: bufio.NewWriter(file),
+		start:  time.Now(),
+	}
+
+	header, err := json.Marshal(map[string]any{
+		"version":       castVersion,
+		"snake_version": replayFormat.Version,
+		"width":         width,
+		"height":        height,
+		"timestamp":     r.start.Unix(),
+		"title":         "Snake Game",
+	})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	r.writeLine(header)
+
+	return r, r.err
+}
+
+func (r *Recorder) Capture() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+
+	width, height := screen.Size()
+	frame := encodeFrame(screen.CellBuffer(), width, height)
+	if frame == r.lastFrame {
+		return
+	}
+	r.lastFrame = frame
+	r.writeEvent("o", frame)
+}
+
+func (r *Recorder) Mark(label string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.writeEvent("m", label)
+}
+
+func (r *Recorder) Input(data string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.writeEvent("i", data)
+}
+
+func (r *Recorder) writeEvent(code, data string) {
+	event, err := json.Marshal([]any{time.Since(r.start).Seconds(), code, data})
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.writeLine(event)
+}
+
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.writer.Flush(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if err := r.file.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	return r.err
+}
+
+func (r *Recorder) writeLine(line []byte) {
+	if _, err := r.writer.Write(append(line, '\n')); err != nil {
+		r.err = err
+	}
+}
+
+func encodeFrame(cells []term.Cell, width, height int) string {
+	var sb strings.Builder
+	sb.WriteString("\x1b[H\x1b[2J")
+
+	lastStyle := ""
+	for y := 0; y < height; y++ {
+		if y > 0 {
+			sb.WriteString("\r\n")
+		}
+		for x := 0; x < width; x++ {
+			cell := cells[y*width+x]
+
+			style := ansiStyle(cell.Fg, cell.Bg)
+			if style != lastStyle {
+				sb.WriteString(style)
+				lastStyle = style
+			}
+
+			ch := cell.Ch
+			if ch == 0 {
+				ch = ' '
+			}
+			sb.WriteRune(ch)
+		}
+	}
+	sb.WriteString("\x1b[0m")
+
+	return sb.String()
+}
+
+func ansiStyle(fg, bg term.Attribute) string {
+	codes := []string{"0"}
+
+	if fg&term.AttrBold != 0 {
+		codes = append(codes, "1")
+	}
+	if color := fg & 0x1FF; color != term.ColorDefault {
+		codes = append(codes, fmt.Sprintf("%d", 30+int(color)-1))
+	}
+	if color := bg & 0x1FF; color != term.ColorDefault {
+		codes = append(codes, fmt.Sprintf("%d", 40+int(color)-1))
+	}
+
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
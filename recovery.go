@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"snake/term"
+)
+
+const (
+	recoveryFile     = "recovery.json"
+	AutosaveInterval = 5 * time.Second
+)
+
+type Recovery struct {
+	SavedAt   time.Time `json:"saved_at"`
+	Mode      GameMode  `json:"mode"`
+	Modifiers Modifiers `json:"modifiers"`
+	Lives     int       `json:"lives"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+	Snapshot  Snapshot  `json:"snapshot"`
+}
+
+func LoadRecovery() (*Recovery, bool) {
+	data, err := recoveryFormat.Read(recoveryFile)
+	if os.IsNotExist(err) {
+		return nil, false
+	}
+
+	var recovery Recovery
+	if err == nil {
+		err = json.Unmarshal(data, &recovery)
+	}
+	switch {
+	case err != nil:
+	case int(recovery.Mode) < 0 || int(recovery.Mode) >= len(modeNames):
+		err = fmt.Errorf("modo desconhecido: %d", recovery.Mode)
+	case recovery.Width != 0 && (recovery.Width < MinWidth || recovery.Height < MinHeight):
+		err = fmt.Errorf("tabuleiro invalido: %dx%d", recovery.Width, recovery.Height)
+	case recovery.Snapshot.Snake.Body.Len() == 0:
+		err = fmt.Errorf("partida sem cobra")
+	}
+	if err != nil {
+		SetAsideBroken(recoveryFile, err)
+		return nil, false
+	}
+	return &recovery, true
+}
+
+func SaveRecovery(recovery Recovery) error {
+	data, err := json.Marshal(recovery)
+	if err != nil {
+		return err
+	}
+
+	return recoveryFormat.Write(recoveryFile, data)
+}
+
+func RemoveRecovery() {
+	if err := os.Remove(recoveryFile); err != nil && !os.IsNotExist(err) {
+		logger.Error("falha ao remover arquivo de recuperacao", "erro", err)
+	}
+}
+
+func (g *Game) Autosave() {
+	if !g.RecoveryDirty || g.State != StatePlaying || g.Mode == ModeTutorial || g.Mode == ModePuzzle || g.Mode == ModeHardcore || !g.Persists() ||
+		time.Since(g.LastAutosave) < AutosaveInterval {
+		return
+	}
+
+	err := SaveRecovery(Recovery{
+		SavedAt:   time.Now(),
+		Mode:      g.Mode,
+		Modifiers: g.Modifiers,
+		Lives:     g.Lives,
+		Width:     g.Width,
+		Height:    g.Height,
+		Snapshot:  g.TakeSnapshot(),
+	})
+	if err != nil {
+		logger.Error("falha ao salvar recuperacao", "erro", err)
+	}
+	g.RecoveryDirty = false
+	g.LastAutosave = time.Now()
+}
+
+func (g *Game) ResumeRecovery() {
+	recovery := g.Recovery
+	g.Recovery = nil
+
+	g.SelectMode(recovery.Mode)
+	g.Modifiers = recovery.Modifiers
+	g.Reset()
+	if recovery.Width > 0 {
+		g.Width, g.Height = recovery.Width, recovery.Height
+	}
+	g.RestoreSnapshot(recovery.Snapshot)
+	g.Lives = recovery.Lives
+	g.SelectEnvironment()
+	g.GrantSpawnGrace()
+}
+
+func (g *Game) DiscardRecovery() {
+	g.Recovery = nil
+	RemoveRecovery()
+}
+
+func (g *Game) DrawRecoveryDialog() {
+	box := BoxLines([]string{
+		"Retomar partida interrompida?",
+		"",
+		fmt.Sprintf("Modo: %s", g.Recovery.Mode),
+		fmt.Sprintf("Pontos: %d  Nivel: %d", g.Recovery.Snapshot.Score, g.Recovery.Snapshot.Level),
+		fmt.Sprintf("Salva em %s", g.Recovery.SavedAt.Format("02/01 15:04")),
+		"",
+		"S - Retomar   N - Descartar",
+	}, 0)
+
+	screenWidth, screenHeight := screen.Size()
+	DrawBox(screenWidth/2-BoxWidth(box)/2, screenHeight/2-len(box)/2, box, func(i int) term.Attribute {
+		if i == 1 {
+			return term.ColorYellow | term.AttrBold
+		}
+		return term.ColorYellow
+	})
+}
+
+func (g *Game) HasRecovery() bool {
+	recovery, ok := LoadRecovery()
+	g.Recovery = recovery
+	return ok
+}
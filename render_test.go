@@ -0,0 +1,133 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "regrava os quadros em testdata/golden")
+
+var goldenDir, _ = filepath.Abs(filepath.Join("testdata", "golden"))
+
+func (b *CellBuffer) String() string {
+	var sb strings.Builder
+	for y := 0; y < b.Height; y++ {
+		line := make([]rune, b.Width)
+		for x := range line {
+			line[x] = b.Cells[y*b.Width+x].Ch
+		}
+		sb.WriteString(strings.TrimRight(string(line), " "))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func renderBoard(g *Game) string {
+	buffer := NewCellBuffer(g.Width, g.Height)
+	setCell := buffer.SetCell
+	if g.Foggy() {
+		setCell = g.FogSetter(buffer.SetCell)
+	}
+	g.DrawBoard(setCell, buffer.SetCell)
+	return buffer.String()
+}
+
+func useScreen(t testing.TB, width, height int) *CellBuffer {
+	t.Helper()
+	buffer := NewCellBuffer(width, height)
+	previous := screen
+	screen = buffer
+	t.Cleanup(func() { screen = previous })
+	return buffer
+}
+
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join(goldenDir, name+".txt")
+
+	if *updateGolden {
+		if err := os.MkdirAll(goldenDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (rode go test -run %s -update)", err, t.Name())
+	}
+	if got != string(want) {
+		t.Errorf("quadro diferente de %s:\n%s\nquer:\n%s", path, got, want)
+	}
+}
+
+func TestGoldenFrames(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  GameMode
+		setup func(g *Game)
+	}{
+		{"classico-inicio", ModeClassic, func(g *Game) {}},
+		{"classico-cobra-longa", ModeClassic, func(g *Game) {
+			clearBoard(g)
+			placeSnake(g, DirUp, Point{X: 10, Y: 5}, Point{X: 10, Y: 6}, Point{X: 10, Y: 7},
+				Point{X: 11, Y: 7}, Point{X: 12, Y: 7}, Point{X: 12, Y: 8})
+		}},
+		{"obstaculo-surgindo", ModeClassic, func(g *Game) {
+			clearBoard(g)
+			previous := []Obstacle{{Position: Point{X: 20, Y: 5}, Type: WallObstacle}}
+			g.Obstacles = append(previous, Obstacle{Position: Point{X: 22, Y: 5}, Type: WallObstacle})
+			g.TelegraphObstacles(previous)
+			g.FrameCount = 2
+			g.RebuildOccupancy()
+		}},
+		{"neblina", ModeFog, func(g *Game) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, tt.mode)
+			g.Settings.Ambience = false
+			tt.setup(g)
+			checkGolden(t, tt.name, renderBoard(g))
+		})
+	}
+}
+
+func TestGoldenScreens(t *testing.T) {
+	tests := []struct {
+		name string
+		draw func(g *Game)
+	}{
+		{"tela-menu", func(g *Game) {
+			g.State = StateMenu
+			g.Challenge = &ChallengeCode{Mode: ModeClassic, Width: DefaultWidth, Height: DefaultHeight, Seed: 1}
+			g.DrawMenu()
+		}},
+		{"tela-partida", func(g *Game) {
+			g.Score, g.Level = 70, 2
+			g.Draw()
+		}},
+		{"tela-fim-de-jogo", func(g *Game) {
+			g.Score, g.HighScore = 40, 120
+			g.State = StateGameOver
+			g.DrawGameOver()
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, ModeClassic)
+			g.Settings.Ambience = false
+			buffer := useScreen(t, 140, 48)
+			tt.draw(g)
+			checkGolden(t, tt.name, buffer.String())
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"snake/term"
+)
+
+const replayFrameInterval = 16 * time.Millisecond
+
+var replaySpeeds = []float64{0.5, 1, 2, 4}
+
+type CastFrame struct {
+	At   float64
+	Data string
+}
+
+type Cast struct {
+	Width   int
+	Height  int
+	Frames  []CastFrame
+	Markers []float64
+}
+
+func LoadCast(path string) (*Cast, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCast(path, data)
+}
+
+func ParseCast(path string, data []byte) (*Cast, error) {
+	line, payload, _ := bytes.Cut(data, []byte("\n"))
+	if len(bytes.TrimSpace(line)) == 0 {
+		return nil, fmt.Errorf("%s: gravacao vazia", path)
+	}
+
+	var header struct {
+		Version      int `json:"version"`
+		SnakeVersion int `json:"snake_version"`
+		Width        int `json:"width"`
+		Height       int `json:"height"`
+	}
+	if err := json.Unmarshal(line, &header); err != nil {
+		return nil, fmt.Errorf("%s: cabecalho invalido: %w", path, err)
+	}
+	if header.Version != castVersion {
+		return nil, fmt.Errorf("%s: formato de gravacao nao suportado (versao %d)", path, header.Version)
+	}
+	payload, err := replayFormat.Migrate(header.SnakeVersion, payload)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	cast := &Cast{Width: header.Width, Height: header.Height}
+	scanner := bufio.NewScanner(bytes.NewReader(payload))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var event []any
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(event) != 3 {
+			continue
+		}
+
+		at, _ := event[0].(float64)
+		code, _ := event[1].(string)
+		data, _ := event[2].(string)
+		switch code {
+		case "o":
+			cast.Frames = append(cast.Frames, CastFrame{At: at, Data: data})
+		case "m":
+			if data == deathMarker {
+				cast.Markers = append(cast.Markers, at)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(cast.Frames) == 0 {
+		return nil, fmt.Errorf("%s: gravacao sem quadros", path)
+	}
+	return cast, nil
+}
+
+func (c *Cast) Duration() float64 {
+	return c.Frames[len(c.Frames)-1].At
+}
+
+func (c *Cast) FrameAt(at float64) int {
+	i := sort.Search(len(c.Frames), func(i int) bool { return c.Frames[i].At > at })
+	return max(0, i-1)
+}
+
+func decodeFrame(data string, width, height int) []term.Cell {
+	cells := make([]term.Cell, width*height)
+	var fg, bg term.Attribute
+	x, y := 0, 0
+
+	for i := 0; i < len(data); {
+		if data[i] == '\x1b' && i+1 < len(data) && data[i+1] == '[' {
+			end := strings.IndexFunc(data[i+2:], func(r rune) bool { return r >= '@' && r <= '~' })
+			if end < 0 {
+				break
+			}
+			if data[i+2+end] == 'm' {
+				fg, bg = applySGR(data[i+2:i+2+end], fg, bg)
+			}
+			i += end + 3
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(data[i:])
+		i += size
+		switch r {
+		case '\r':
+			x = 0
+		case '\n':
+			y++
+		default:
+			if x < width && y < height {
+				cells[y*width+x] = term.Cell{Ch: r, Fg: fg, Bg: bg}
+			}
+			x++
+		}
+	}
+	return cells
+}
+
+func applySGR(params string, fg, bg term.Attribute) (term.Attribute, term.Attribute) {
+	for _, param := range strings.Split(params, ";") {
+		code, err := strconv.Atoi(param)
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			fg, bg = term.ColorDefault, term.ColorDefault
+		case code == 1:
+			fg |= term.AttrBold
+		case code >= 30 && code <= 37:
+			fg = fg&term.AttrBold | term.Attribute(code-29)
+		case code >= 40 && code <= 47:
+			bg = term.Attribute(code - 39)
+		}
+	}
+	return fg, bg
+}
+
+type ReplayPlayer struct {
+	Cast   *Cast
+	Clock  float64
+	Speed  float64
+	Paused bool
+}
+
+func (p *ReplayPlayer) Advance(elapsed time.Duration) {
+	if p.Paused {
+		return
+	}
+	p.Clock = min(p.Cast.Duration(), p.Clock+elapsed.Seconds()*p.Speed)
+}
+
+func (p *ReplayPlayer) Step(delta int) {
+	p.Paused = true
+	frame := clamp(p.Cast.FrameAt(p.Clock)+delta, 0, len(p.Cast.Frames)-1)
+	p.Clock = p.Cast.Frames[frame].At
+}
+
+func (p *ReplayPlayer) JumpToDeath() bool {
+	if len(p.Cast.Markers) == 0 {
+		return false
+	}
+
+	next := p.Cast.Markers[0]
+	for _, at := range p.Cast.Markers {
+		if at > p.Clock {
+			next = at
+			break
+		}
+	}
+	p.Clock = next
+	p.Paused = true
+	return true
+}
+
+func (p *ReplayPlayer) HandleKey(ev term.Event) bool {
+	switch {
+	case ev.Key == term.KeyEsc || ev.Key == term.KeyCtrlC || ev.Ch == 'q' || ev.Ch == 'Q':
+		return false
+	case ev.Ch >= '1' && int(ev.Ch-'1') < len(replaySpeeds):
+		p.Speed = replaySpeeds[ev.Ch-'1']
+	case ev.Key == term.KeySpace:
+		if p.Clock >= p.Cast.Duration() {
+			p.Clock = 0
+		}
+		p.Paused = !p.Paused
+	case ev.Key == term.KeyArrowRight || ev.Ch == '.':
+		p.Step(1)
+	case ev.Key == term.KeyArrowLeft || ev.Ch == ',':
+		p.Step(-1)
+	case ev.Ch == 'm' || ev.Ch == 'M':
+		p.JumpToDeath()
+	case ev.Key == term.KeyHome:
+		p.Clock = 0
+	}
+	return true
+}
+
+func (p *ReplayPlayer) Draw() {
+	screen.Clear(term.ColorDefault, term.ColorDefault)
+	width, height := screen.Size()
+
+	frame := p.Cast.Frames[p.Cast.FrameAt(p.Clock)]
+	cells := decodeFrame(frame.Data, p.Cast.Width, p.Cast.Height)
+	for y := 0; y < min(p.Cast.Height, height-2); y++ {
+		for x := 0; x < min(p.Cast.Width, width); x++ {
+			cell := cells[y*p.Cast.Width+x]
+			screen.SetCell(x, y, cell.Ch, cell.Fg, cell.Bg)
+		}
+	}
+
+	p.DrawTimeline(height-2, width)
+
+	state := "tocando"
+	if p.Paused {
+		state = "pausado"
+	}
+	status := fmt.Sprintf(" %s %gx  %s / %s  [1-4] velocidade  [espaco] pausa  [←/→] quadro  [m] morte  [q] sair",
+		state, p.Speed,
+		(time.Duration(p.Clock * float64(time.Second))).Round(time.Second),
+		(time.Duration(p.Cast.Duration() * float64(time.Second))).Round(time.Second))
+	DrawText(0, height-1, status, term.ColorWhite, term.ColorDefault)
+
+	screen.Flush()
+}
+
+func (p *ReplayPlayer) DrawTimeline(y, width int) {
+	duration := max(p.Cast.Duration(), 0.001)
+	position := func(at float64) int {
+		return clamp(int(at/duration*float64(width-1)), 0, width-1)
+	}
+
+	cursor := position(p.Clock)
+	for x := 0; x < width; x++ {
+		char, color := '─', term.ColorWhite
+		if x <= cursor {
+			char, color = '━', term.ColorGreen
+		}
+		screen.SetCell(x, y, char, color, term.ColorDefault)
+	}
+	for _, at := range p.Cast.Markers {
+		screen.SetCell(position(at), y, 'x', term.ColorRed|term.AttrBold, term.ColorDefault)
+	}
+	screen.SetCell(cursor, y, '●', term.ColorYellow|term.AttrBold, term.ColorDefault)
+}
+
+func PlayCast(cast *Cast, speed float64) error {
+	if err := term.Init(); err != nil {
+		return err
+	}
+	defer term.Close()
+	term.SetInputMode(term.InputEsc)
+
+	keys := make(chan term.Event)
+	go func() {
+		for {
+			keys <- term.PollEvent()
+		}
+	}()
+	player := &ReplayPlayer{Cast: cast, Speed: speed}
+	player.Run(keys)
+	return nil
+}
+
+func (p *ReplayPlayer) Run(keys <-chan term.Event) {
+	ticker := time.NewTicker(replayFrameInterval)
+	defer ticker.Stop()
+	last := time.Now()
+	for {
+		select {
+		case ev := <-keys:
+			if ev.Type == term.EventKey && !p.HandleKey(ev) {
+				return
+			}
+		case now := <-ticker.C:
+			p.Advance(now.Sub(last))
+			last = now
+		}
+		p.Draw()
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import "snake/term"
+
+const (
+	RiskZoneCount      = 2
+	RiskZoneWidth      = 8
+	RiskZoneHeight     = 5
+	RiskMultiplier     = 3
+	RiskCloseEvery     = 25
+	RiskExtraObstacles = 2
+)
+
+type RiskZone struct {
+	Min Point
+	Max Point
+}
+
+func (z RiskZone) Contains(p Point) bool {
+	return p.X >= z.Min.X && p.X <= z.Max.X && p.Y >= z.Min.Y && p.Y <= z.Max.Y
+}
+
+func (z RiskZone) Edge() []Point {
+	var cells []Point
+	for x := z.Min.X; x <= z.Max.X; x++ {
+		cells = append(cells, Point{X: x, Y: z.Min.Y}, Point{X: x, Y: z.Max.Y})
+	}
+	for y := z.Min.Y + 1; y < z.Max.Y; y++ {
+		cells = append(cells, Point{X: z.Min.X, Y: y}, Point{X: z.Max.X, Y: y})
+	}
+	return cells
+}
+
+func (g *Game) InRiskZone(p Point) bool {
+	for _, zone := range g.RiskZones {
+		if zone.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) RiskPoints(points int, p Point) int {
+	if g.InRiskZone(p) {
+		return points * RiskMultiplier
+	}
+	return points
+}
+
+func (g *Game) GenerateRiskZones() {
+	g.RiskZones = nil
+	g.RiskTicks = 0
+	if g.Mode != ModeRisk {
+		return
+	}
+
+	width := min(RiskZoneWidth, g.Width/3)
+	height := min(RiskZoneHeight, g.Height/3)
+	for i := 0; i < RiskZoneCount; i++ {
+		corner := Point{
+			X: g.Rand.Intn(g.Width-1-width) + 1,
+			Y: g.Rand.Intn(g.Height-1-height) + 1,
+		}
+		g.RiskZones = append(g.RiskZones, RiskZone{
+			Min: corner,
+			Max: Point{X: corner.X + width - 1, Y: corner.Y + height - 1},
+		})
+	}
+}
+
+func (g *Game) SpawnRiskObstacles() {
+	for _, zone := range g.RiskZones {
+		count := (g.Level + 1) * RiskExtraObstacles
+		for i := 0; i < count; i++ {
+			for attempts := 0; attempts < 50; attempts++ {
+				pos := Point{
+					X: zone.Min.X + g.Rand.Intn(zone.Max.X-zone.Min.X+1),
+					Y: zone.Min.Y + g.Rand.Intn(zone.Max.Y-zone.Min.Y+1),
+				}
+				if g.IsPositionSafe(pos) && !g.IsAheadOfPlayers(pos) {
+					g.Obstacles = append(g.Obstacles, Obstacle{Position: pos, Type: WallObstacle})
+					g.Occupancy.SetObstacle(pos, len(g.Obstacles)-1)
+					break
+				}
+			}
+		}
+	}
+}
+
+func (g *Game) UpdateRiskZones() {
+	if len(g.RiskZones) == 0 {
+		return
+	}
+
+	g.RiskTicks++
+	if g.RiskTicks%RiskCloseEvery != 0 {
+		return
+	}
+
+	for _, zone := range g.RiskZones {
+		edge := zone.Edge()
+		g.Rand.Shuffle(len(edge), func(i, j int) { edge[i], edge[j] = edge[j], edge[i] })
+		for _, pos := range edge {
+			if g.IsPositionSafe(pos) && !g.IsAheadOfPlayers(pos) {
+				g.Obstacles = append(g.Obstacles, Obstacle{
+					Position: pos,
+					Type:     WallObstacle,
+					State:    ObstacleTelegraph,
+					SolidIn:  TelegraphTicks,
+				})
+				g.Occupancy.SetObstacle(pos, len(g.Obstacles)-1)
+				break
+			}
+		}
+	}
+}
+
+func (g *Game) DrawRiskZones(setCell CellSetter) {
+	color := term.ColorRed
+	if g.Blink(5) {
+		color = term.ColorYellow
+	}
+
+	for _, zone := range g.RiskZones {
+		for y := zone.Min.Y; y <= zone.Max.Y; y++ {
+			for x := zone.Min.X; x <= zone.Max.X; x++ {
+				setCell(x, y, '·', color, term.ColorDefault)
+			}
+		}
+	}
+}
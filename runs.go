@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	runsFile    = "runs.ndjson"
+	runsVersion = 1
+)
+
+type RunRecord struct {
+	Version    int       `json:"version"`
+	Timestamp  time.Time `json:"timestamp"`
+	Mode       string    `json:"mode"`
+	Seed       int64     `json:"seed"`
+	Score      int       `json:"score"`
+	Level      int       `json:"level"`
+	Length     int       `json:"length"`
+	Duration   float64   `json:"duration_seconds"`
+	DeathCause string    `json:"death_cause"`
+	Modifiers  string    `json:"modifiers,omitempty"`
+	Challenge  string    `json:"challenge,omitempty"`
+
+	InputHistogram []int  `json:"input_histogram,omitempty"`
+	FairPlayFlag   string `json:"fair_play_flag,omitempty"`
+	Replay         string `json:"replay,omitempty"`
+}
+
+func (g *Game) RunRecord() RunRecord {
+	return RunRecord{
+		Version:    runsVersion,
+		Timestamp:  time.Now(),
+		Mode:       g.Mode.String(),
+		Seed:       g.Seed,
+		Score:      g.Score,
+		Level:      g.Level,
+		Length:     g.Snake.Body.Len(),
+		Duration:   g.Metrics.Duration.Seconds(),
+		DeathCause: g.Metrics.DeathCause,
+		Modifiers:  g.Modifiers.Code(),
+		Challenge:  g.ChallengeID(),
+
+		InputHistogram: g.FairPlay.HistogramSlice(),
+		FairPlayFlag:   g.FairPlay.Flag(),
+		Replay:         g.Hardcore.Replay,
+	}
+}
+
+func AppendRun(record RunRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := runsFormat.Append(runsFile, append(data, '\n')); err != nil {
+		return fmt.Errorf("%s: %w", runsFile, err)
+	}
+	return nil
+}
+
+func LoadRuns() ([]RunRecord, error) {
+	payload, err := runsFormat.Read(runsFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", runsFile, err)
+	}
+
+	var runs []RunRecord
+	scanner := bufio.NewScanner(bytes.NewReader(payload))
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record RunRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s: %w", runsFile, err)
+		}
+		if record.Version > runsVersion {
+			continue
+		}
+		runs = append(runs, record)
+	}
+	return runs, scanner.Err()
+}
+
+func ExportRuns(w io.Writer, format string) error {
+	runs, err := LoadRuns()
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if runs == nil {
+			runs = []RunRecord{}
+		}
+		return encoder.Encode(runs)
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"timestamp", "mode", "seed", "score", "level", "length", "duration_seconds", "death_cause", "modifiers", "challenge", "fair_play_flag", "replay"})
+		for _, run := range runs {
+			writer.Write([]string{
+				run.Timestamp.Format(time.RFC3339),
+				run.Mode,
+				strconv.FormatInt(run.Seed, 10),
+				strconv.Itoa(run.Score),
+				strconv.Itoa(run.Level),
+				strconv.Itoa(run.Length),
+				strconv.FormatFloat(run.Duration, 'f', 1, 64),
+				run.DeathCause,
+				run.Modifiers,
+				run.Challenge,
+				run.FairPlayFlag,
+				run.Replay,
+			})
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	return fmt.Errorf("formato de exportacao desconhecido: %s (use csv ou json)", format)
+}
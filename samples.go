@@ -0,0 +1,136 @@
+package main
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/beep/effects"
+	"github.com/faiface/beep/speaker"
+	"github.com/faiface/beep/vorbis"
+	"github.com/faiface/beep/wav"
+)
+
+//go:embed assets/sounds
+var soundAssets embed.FS
+
+const (
+	embeddedSamplesDir = "assets/sounds"
+	sampleRate         = beep.SampleRate(44100)
+)
+
+var soundSamples = map[string]*beep.Buffer{}
+
+var sampleExtensions = []string{".wav", ".ogg"}
+
+func decodeSample(r io.ReadCloser, path string) (*beep.Buffer, error) {
+	var stream beep.StreamSeekCloser
+	var format beep.Format
+	var err error
+	if filepath.Ext(path) == ".ogg" {
+		stream, format, err = vorbis.Decode(r)
+	} else {
+		stream, format, err = wav.Decode(r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	buffer := beep.NewBuffer(beep.Format{SampleRate: sampleRate, NumChannels: 2, Precision: 2})
+	if format.SampleRate == sampleRate {
+		buffer.Append(stream)
+	} else {
+		buffer.Append(beep.Resample(4, format.SampleRate, sampleRate, stream))
+	}
+	return buffer, nil
+}
+
+func openSample(dir, name string) (io.ReadCloser, string, error) {
+	if dir != "" {
+		for _, ext := range sampleExtensions {
+			path := filepath.Join(dir, name+ext)
+			if f, err := os.Open(path); err == nil {
+				return f, path, nil
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return nil, path, err
+			}
+		}
+	}
+
+	for _, ext := range sampleExtensions {
+		path := embeddedSamplesDir + "/" + name + ext
+		if f, err := soundAssets.Open(path); err == nil {
+			return f, path, nil
+		}
+	}
+	return nil, "", fs.ErrNotExist
+}
+
+func CheckSamples(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || !slices.Contains(sampleExtensions, ext) {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		if name := strings.TrimSuffix(entry.Name(), ext); soundPack[name] == nil {
+			return count, fmt.Errorf("%s: efeito desconhecido: %s", path, name)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return count, err
+		}
+		_, err = decodeSample(f, path)
+		f.Close()
+		if err != nil {
+			return count, fmt.Errorf("%s: %w", path, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+func LoadSamples(dir string) map[string]*beep.Buffer {
+	samples := map[string]*beep.Buffer{}
+	for name := range soundPack {
+		f, path, err := openSample(dir, name)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				logger.Warn("amostra ignorada", "arquivo", path, "erro", err)
+			}
+			continue
+		}
+		buffer, err := decodeSample(f, path)
+		f.Close()
+		if err != nil {
+			logger.Warn("amostra ignorada", "arquivo", path, "erro", err)
+			continue
+		}
+		samples[name] = buffer
+	}
+	return samples
+}
+
+func playSample(name string, pan float64) bool {
+	buffer := soundSamples[name]
+	if buffer == nil || !soundInitialized {
+		return false
+	}
+	speaker.Play(&effects.Pan{Streamer: buffer.Streamer(0, buffer.Len()), Pan: pan})
+	return true
+}
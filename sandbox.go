@@ -0,0 +1,107 @@
+package main
+
+import "snake/term"
+
+type Sandbox struct {
+	Cursor        Point
+	CollisionsOff bool
+	CursorVisible bool
+}
+
+func (g *Game) CollisionsDisabled() bool {
+	return g.Mode == ModeSandbox && g.Sandbox.CollisionsOff || g.Mode == ModeZen
+}
+
+func (g *Game) Wrap(p Point) Point {
+	inset := g.Shrink.Rings
+	if p.X <= inset {
+		p.X = g.Width - 2 - inset
+	} else if p.X >= g.Width-1-inset {
+		p.X = 1 + inset
+	}
+	if p.Y <= inset {
+		p.Y = g.Height - 2 - inset
+	} else if p.Y >= g.Height-1-inset {
+		p.Y = 1 + inset
+	}
+	return p
+}
+
+func (g *Game) ToggleObstacle(p Point) {
+	if g.CheckWallCollision(p) {
+		return
+	}
+
+	if i := g.Occupancy.ObstacleIndex(p); i >= 0 {
+		g.Obstacles = append(g.Obstacles[:i], g.Obstacles[i+1:]...)
+		g.IndexObstacles()
+		return
+	}
+
+	if g.Occupancy.HasSnake(p) || p == g.Food.Position {
+		return
+	}
+
+	g.Obstacles = append(g.Obstacles, Obstacle{Position: p, Type: WallObstacle})
+	g.Occupancy.SetObstacle(p, len(g.Obstacles)-1)
+}
+
+func (g *Game) PlaceFood(p Point) {
+	if g.CheckWallCollision(p) || g.ObstacleAt(p) != nil || g.CheckSelfCollision(p) {
+		return
+	}
+	g.Food.Position = p
+}
+
+func (g *Game) HandleSandboxKey(ev term.Event) bool {
+	moves := map[rune]Direction{
+		'i': DirUp, 'I': DirUp,
+		'k': DirDown, 'K': DirDown,
+		'j': DirLeft, 'J': DirLeft,
+		'l': DirRight, 'L': DirRight,
+	}
+
+	if direction, ok := moves[ev.Ch]; ok {
+		next := g.Sandbox.Cursor.Move(direction)
+		if !g.CheckWallCollision(next) {
+			g.Sandbox.Cursor = next
+		}
+		g.Sandbox.CursorVisible = true
+		return true
+	}
+
+	switch ev.Ch {
+	case 'o', 'O':
+		g.ToggleObstacle(g.Sandbox.Cursor)
+	case 'f', 'F':
+		g.PlaceFood(g.Sandbox.Cursor)
+	case 'x', 'X':
+		g.Sandbox.CollisionsOff = !g.Sandbox.CollisionsOff
+	default:
+		return false
+	}
+	return true
+}
+
+func (g *Game) HandleSandboxMouse(ev term.Event) {
+	p := g.Camera.ToBoard(ev.MouseX, ev.MouseY)
+	g.Sandbox.Cursor = p
+	g.Sandbox.CursorVisible = true
+
+	switch ev.Key {
+	case term.MouseLeft:
+		g.ToggleObstacle(p)
+	case term.MouseRight:
+		g.PlaceFood(p)
+	}
+}
+
+func (g *Game) DrawSandbox(setCell CellSetter) {
+	if g.Mode != ModeSandbox || !g.Sandbox.CursorVisible {
+		return
+	}
+
+	if g.Steady(3) {
+		setCell(g.Sandbox.Cursor.X, g.Sandbox.Cursor.Y, '+', term.ColorMagenta|term.AttrBold, term.ColorDefault)
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const saveMagic = "SNAKE"
+
+var ErrNewerFormat = errors.New("arquivo gravado por uma versao mais nova do jogo")
+
+type Migration func(payload []byte) ([]byte, error)
+
+type SaveFormat struct {
+	Kind       string
+	Version    int
+	Migrations []Migration
+}
+
+func keepPayload(payload []byte) ([]byte, error) {
+	return payload, nil
+}
+
+var (
+	highScoreFormat  = SaveFormat{Kind: "highscore", Version: 1, Migrations: []Migration{keepPayload}}
+	bestLengthFormat = SaveFormat{Kind: "bestlength", Version: 1, Migrations: []Migration{keepPayload}}
+	statsFormat      = SaveFormat{Kind: "stats", Version: 1, Migrations: []Migration{keepPayload}}
+	recoveryFormat   = SaveFormat{Kind: "recovery", Version: 1, Migrations: []Migration{keepPayload}}
+	settingsFormat   = SaveFormat{Kind: "settings", Version: 1, Migrations: []Migration{keepPayload}}
+	layoutFormat     = SaveFormat{Kind: "layout", Version: 1, Migrations: []Migration{keepPayload}}
+	runsFormat       = SaveFormat{Kind: "runs", Version: 1, Migrations: []Migration{keepPayload}}
+	replayFormat     = SaveFormat{Kind: "replay", Version: 1, Migrations: []Migration{keepPayload}}
+	syncStateFormat  = SaveFormat{Kind: "sync-state", Version: 1, Migrations: []Migration{keepPayload}}
+)
+
+func (f SaveFormat) Header() string {
+	return fmt.Sprintf("%s %s %d\n", saveMagic, f.Kind, f.Version)
+}
+
+func (f SaveFormat) split(data []byte) (int, []byte, error) {
+	if !bytes.HasPrefix(data, []byte(saveMagic+" ")) {
+		return 0, data, nil
+	}
+
+	line, payload, _ := bytes.Cut(data, []byte("\n"))
+	fields := strings.Fields(string(line))
+	if len(fields) != 3 || fields[1] != f.Kind {
+		return 0, nil, fmt.Errorf("cabecalho invalido: %q", line)
+	}
+
+	version, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return 0, nil, fmt.Errorf("versao invalida: %q", fields[2])
+	}
+	return version, payload, nil
+}
+
+func (f SaveFormat) Decode(data []byte) ([]byte, error) {
+	version, payload, err := f.split(data)
+	if err != nil {
+		return nil, err
+	}
+	return f.Migrate(version, payload)
+}
+
+func (f SaveFormat) Migrate(version int, payload []byte) ([]byte, error) {
+	var err error
+	if version > f.Version {
+		return nil, fmt.Errorf("%w (%s versao %d)", ErrNewerFormat, f.Kind, version)
+	}
+
+	for v := version; v < f.Version; v++ {
+		if payload, err = f.Migrations[v](payload); err != nil {
+			return nil, fmt.Errorf("migrando %s da versao %d: %w", f.Kind, v, err)
+		}
+	}
+	return payload, nil
+}
+
+func (f SaveFormat) Read(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return f.Decode(data)
+}
+
+func (f SaveFormat) Write(path string, payload []byte) error {
+	if data, err := os.ReadFile(path); err == nil {
+		if version, _, err := f.split(data); err == nil && version > f.Version {
+			return fmt.Errorf("%s: %w", path, ErrNewerFormat)
+		}
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append([]byte(f.Header()), payload...), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (f SaveFormat) Append(path string, record []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		record = append([]byte(f.Header()), record...)
+	} else if err := f.checkVersion(path); err != nil {
+		return err
+	}
+
+	if _, err := file.Write(record); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func (f SaveFormat) checkVersion(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	line, err := bufio.NewReader(file).ReadBytes('\n')
+	if err != nil && len(line) == 0 {
+		return err
+	}
+	version, _, err := f.split(line)
+	if err != nil {
+		return err
+	}
+	if version > f.Version {
+		return fmt.Errorf("%w (%s versao %d)", ErrNewerFormat, f.Kind, version)
+	}
+	return nil
+}
+
+func SetAsideBroken(path string, err error) {
+	if errors.Is(err, ErrNewerFormat) {
+		logger.Warn("mantendo arquivo de uma versao mais nova", "arquivo", path, "erro", err)
+		return
+	}
+
+	backup := path + ".corrompido"
+	logger.Error("arquivo invalido, guardado como copia", "arquivo", path, "copia", backup, "erro", err)
+	if err := os.Rename(path, backup); err != nil {
+		logger.Error("falha ao guardar copia", "arquivo", path, "erro", err)
+	}
+}
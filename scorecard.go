@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+func (g *Game) BoardSnapshot(maxWidth, maxHeight int) []string {
+	width := min(g.Width, maxWidth)
+	height := min(g.Height, maxHeight)
+
+	grid := make([][]rune, height)
+	for y := range grid {
+		grid[y] = []rune(strings.Repeat(" ", width))
+	}
+
+	put := func(p Point, ch rune) {
+		x, y := p.X*width/g.Width, p.Y*height/g.Height
+		grid[y][x] = ch
+	}
+
+	for x := 0; x < width; x++ {
+		grid[0][x] = '═'
+		grid[height-1][x] = '═'
+	}
+	for y := 0; y < height; y++ {
+		grid[y][0] = '║'
+		grid[y][width-1] = '║'
+	}
+	grid[0][0], grid[0][width-1] = '╔', '╗'
+	grid[height-1][0], grid[height-1][width-1] = '╚', '╝'
+
+	for _, obs := range g.Obstacles {
+		put(obs.Position, '▓')
+	}
+	foodChar, _ := foodBehaviors[g.Food.Type].Render(g, &g.Food)
+	put(g.Food.Position, foodChar)
+	for i := g.Snake.Body.Len() - 1; i >= 0; i-- {
+		char := '█'
+		if i == 0 {
+			char = '●'
+		}
+		put(g.Snake.Body.At(i), char)
+	}
+
+	lines := make([]string, height)
+	for y, row := range grid {
+		lines[y] = string(row)
+	}
+	return lines
+}
+
+func (g *Game) ScoreSummary() string {
+	if g.Mode == ModePuzzle {
+		return fmt.Sprintf("Snake: %d pontos | %s", g.Score, g.PuzzleResult())
+	}
+	return fmt.Sprintf("Snake: %d pontos | Nivel %d | Tamanho %d | Desafio %s",
+		g.Score, g.Level, g.Snake.Body.Len(), g.ShareCode())
+}
+
+func (g *Game) ScoreCard(date time.Time) string {
+	var sb strings.Builder
+
+	sb.WriteString("🐍 SNAKE GAME - SCORE CARD\n\n")
+	fmt.Fprintf(&sb, "Pontos:  %d\n", g.Score)
+	fmt.Fprintf(&sb, "Recorde: %d\n", g.HighScore)
+	fmt.Fprintf(&sb, "Nivel:   %d\n", g.Level)
+	fmt.Fprintf(&sb, "Tamanho: %d\n", g.Snake.Body.Len())
+	fmt.Fprintf(&sb, "Data:    %s\n", date.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&sb, "Seed:    %d\n\n", g.Seed)
+
+	for _, line := range g.BoardSnapshot(DefaultWidth, DefaultHeight) {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func (g *Game) ExportScoreCard() (string, error) {
+	now := time.Now()
+	path := fmt.Sprintf("scorecard-%s.txt", now.Format("20060102-150405"))
+	if err := os.WriteFile(path, []byte(g.ScoreCard(now)), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func CopyToClipboard(text string) error {
+	commands := [][]string{
+		{"pbcopy"},
+		{"wl-copy"},
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+		{"clip.exe"},
+		{"termux-clipboard-set"},
+	}
+
+	for _, args := range commands {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+
+	return errors.New("nenhuma ferramenta de area de transferencia encontrada")
+}
@@ -0,0 +1,61 @@
+package main
+
+import "snake/term"
+
+type Screen interface {
+	SetCell(x, y int, ch rune, fg, bg term.Attribute)
+	Clear(fg, bg term.Attribute) error
+	Size() (int, int)
+	CellBuffer() []term.Cell
+	Flush() error
+}
+
+type termScreen struct{}
+
+func (termScreen) SetCell(x, y int, ch rune, fg, bg term.Attribute) {
+	term.SetCell(x, y, ch, fg, bg)
+}
+
+func (termScreen) Clear(fg, bg term.Attribute) error { return term.Clear(fg, bg) }
+
+func (termScreen) Size() (int, int) { return term.Size() }
+
+func (termScreen) CellBuffer() []term.Cell { return term.CellBuffer() }
+
+func (termScreen) Flush() error { return term.Flush() }
+
+type CellBuffer struct {
+	Width  int
+	Height int
+	Cells  []term.Cell
+}
+
+func NewCellBuffer(width, height int) *CellBuffer {
+	cells := make([]term.Cell, width*height)
+	for i := range cells {
+		cells[i].Ch = ' '
+	}
+	return &CellBuffer{Width: width, Height: height, Cells: cells}
+}
+
+func (b *CellBuffer) SetCell(x, y int, ch rune, fg, bg term.Attribute) {
+	if x < 0 || x >= b.Width || y < 0 || y >= b.Height {
+		return
+	}
+	b.Cells[y*b.Width+x] = term.Cell{Ch: ch, Fg: fg, Bg: bg}
+}
+
+func (b *CellBuffer) Clear(fg, bg term.Attribute) error {
+	for i := range b.Cells {
+		b.Cells[i] = term.Cell{Ch: ' ', Fg: fg, Bg: bg}
+	}
+	return nil
+}
+
+func (b *CellBuffer) Size() (int, int) { return b.Width, b.Height }
+
+func (b *CellBuffer) CellBuffer() []term.Cell { return b.Cells }
+
+func (b *CellBuffer) Flush() error { return nil }
+
+var screen Screen = termScreen{}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+
+	"snake/term"
+)
+
+const (
+	ModsDir       = "mods"
+	ModCallBudget = 20 * time.Millisecond
+)
+
+var modFoodKinds = map[FoodType]string{
+	NormalFood:  "normal",
+	PowerUpFood: "powerup",
+	PelletFood:  "pellet",
+	HeartFood:   "heart",
+	BossFood:    "boss",
+	SplitFood:   "split",
+}
+
+type Mod struct {
+	Name   string
+	state  *lua.LState
+	failed bool
+}
+
+type Scripts struct {
+	Mods []*Mod
+}
+
+func (s Scripts) Active() bool {
+	return len(s.Mods) > 0
+}
+
+func (g *Game) LoadMods(dir string) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.lua"))
+	if err != nil {
+		logger.Error("falha ao listar mods", "erro", err)
+		return
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		mod, err := g.loadMod(path)
+		if err != nil {
+			logger.Warn("mod ignorado", "arquivo", path, "erro", err)
+			continue
+		}
+		g.Scripts.Mods = append(g.Scripts.Mods, mod)
+		logger.Info("mod carregado", "mod", mod.Name)
+	}
+}
+
+func (g *Game) loadMod(path string) (*Mod, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	for _, lib := range []struct {
+		name string
+		open lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.open))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	for _, name := range []string{"dofile", "loadfile", "load", "loadstring"} {
+		L.SetGlobal(name, lua.LNil)
+	}
+	L.SetGlobal("snake", L.SetFuncs(L.NewTable(), g.modAPI()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), ModCallBudget)
+	defer cancel()
+	L.SetContext(ctx)
+	defer L.RemoveContext()
+
+	if err := L.DoString(string(source)); err != nil {
+		L.Close()
+		return nil, err
+	}
+	return &Mod{Name: strings.TrimSuffix(filepath.Base(path), ".lua"), state: L}, nil
+}
+
+func (g *Game) modAPI() map[string]lua.LGFunction {
+	return map[string]lua.LGFunction{
+		"score": func(L *lua.LState) int {
+			L.Push(lua.LNumber(g.Score))
+			return 1
+		},
+		"add_score": func(L *lua.LState) int {
+			g.ModScore(L.CheckInt(1))
+			return 0
+		},
+		"level": func(L *lua.LState) int {
+			L.Push(lua.LNumber(g.Level))
+			return 1
+		},
+		"length": func(L *lua.LState) int {
+			L.Push(lua.LNumber(g.Snake.Body.Len()))
+			return 1
+		},
+		"mode": func(L *lua.LState) int {
+			L.Push(lua.LString(g.Mode.String()))
+			return 1
+		},
+		"tick": func(L *lua.LState) int {
+			L.Push(lua.LNumber(g.PlayTicks))
+			return 1
+		},
+		"size": func(L *lua.LState) int {
+			L.Push(lua.LNumber(g.Width))
+			L.Push(lua.LNumber(g.Height))
+			return 2
+		},
+		"head": func(L *lua.LState) int {
+			head := g.FocusPoint()
+			L.Push(lua.LNumber(head.X))
+			L.Push(lua.LNumber(head.Y))
+			return 2
+		},
+		"food": func(L *lua.LState) int {
+			L.Push(lua.LNumber(g.Food.Position.X))
+			L.Push(lua.LNumber(g.Food.Position.Y))
+			L.Push(lua.LString(modFoodKinds[g.Food.Type]))
+			return 3
+		},
+		"toast": func(L *lua.LState) int {
+			g.ShowToast(L.CheckString(1), term.ColorMagenta)
+			return 0
+		},
+	}
+}
+
+func (g *Game) ModScore(points int) {
+	oldLevel := g.Level
+	g.Score = max(0, g.Score+points)
+	g.UpdateLevel()
+	if g.Level > oldLevel {
+		g.Emit(Event{Type: EventLevelUp, Position: g.FocusPoint()})
+	}
+}
+
+func (g *Game) callMod(mod *Mod, hook string, args ...lua.LValue) lua.LValue {
+	fn := mod.state.GetGlobal(hook)
+	if mod.failed || fn.Type() != lua.LTFunction {
+		return lua.LNil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ModCallBudget)
+	defer cancel()
+	mod.state.SetContext(ctx)
+	defer mod.state.RemoveContext()
+
+	if err := mod.state.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, args...); err != nil {
+		mod.failed = true
+		logger.Warn("mod desativado", "mod", mod.Name, "gancho", hook, "erro", err)
+		g.ShowToast("Mod "+mod.Name+" desativado", term.ColorRed)
+		return lua.LNil
+	}
+	ret := mod.state.Get(-1)
+	mod.state.Pop(1)
+	return ret
+}
+
+func (g *Game) RunModHook(hook string, args ...lua.LValue) {
+	for _, mod := range g.Scripts.Mods {
+		g.callMod(mod, hook, args...)
+	}
+}
+
+func (g *Game) SubscribeMods() {
+	g.Events.Subscribe(EventTick, func(e Event) {
+		g.RunModHook("on_tick")
+	})
+	g.Events.Subscribe(EventFoodEaten, func(e Event) {
+		g.RunModHook("on_eat", lua.LString(modFoodKinds[e.Food]), lua.LNumber(e.Points),
+			lua.LNumber(e.Position.X), lua.LNumber(e.Position.Y))
+	})
+	g.Events.Subscribe(EventLevelUp, func(e Event) {
+		g.RunModHook("on_level_up", lua.LNumber(g.Level))
+	})
+}
+
+func (g *Game) ModAllowsSpawn(p Point) bool {
+	for _, mod := range g.Scripts.Mods {
+		if g.callMod(mod, "spawn_allowed", lua.LNumber(p.X), lua.LNumber(p.Y)) == lua.LFalse {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *Game) ModSpawnKind(p Point, food FoodType) FoodType {
+	for _, mod := range g.Scripts.Mods {
+		ret := g.callMod(mod, "spawn_kind", lua.LString(modFoodKinds[food]), lua.LNumber(p.X), lua.LNumber(p.Y))
+		if kind, ok := layoutFoodNames[lua.LVAsString(ret)]; ok {
+			food = kind
+		}
+	}
+	return food
+}
+
+func (g *Game) CloseMods() {
+	for _, mod := range g.Scripts.Mods {
+		mod.state.Close()
+	}
+	g.Scripts = Scripts{}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func loadTestMod(t *testing.T, g *Game, source string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "teste.lua"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	g.LoadMods(dir)
+	t.Cleanup(g.CloseMods)
+	g.SubscribeMods()
+	if !g.Scripts.Active() {
+		t.Fatal("mod nao carregou")
+	}
+}
+
+func TestModHooks(t *testing.T) {
+	g := newTestGame(t, ModeClassic)
+	loadTestMod(t, g, `
+		ticks = 0
+		function on_tick() ticks = ticks + 1 end
+		function on_eat(kind, points) if kind == "normal" then snake.add_score(points) end end
+		function spawn_allowed(x, y) return x % 2 == 0 end
+		function spawn_kind(kind) return "powerup" end
+	`)
+
+	g.AddScore(10, g.Snake.Body.Head(), NormalFood)
+	if g.Score != 20 {
+		t.Errorf("on_eat: placar %d, quer 20", g.Score)
+	}
+
+	for range 5 {
+		g.GenerateFood()
+		if g.Food.Position.X%2 != 0 || g.Food.Type != PowerUpFood {
+			t.Fatalf("regras de surgimento ignoradas: (%d,%d) tipo %d", g.Food.Position.X, g.Food.Position.Y, g.Food.Type)
+		}
+	}
+
+	g.Emit(Event{Type: EventTick})
+	if got := g.Scripts.Mods[0].state.GetGlobal("ticks").String(); got != "1" {
+		t.Errorf("on_tick chamado %s vezes, quer 1", got)
+	}
+}
+
+func TestModDisabledOnError(t *testing.T) {
+	g := newTestGame(t, ModeClassic)
+	loadTestMod(t, g, `function on_tick() while true do end end`)
+
+	g.Emit(Event{Type: EventTick})
+	if !g.Scripts.Mods[0].failed {
+		t.Error("mod em laco infinito continuou ativo")
+	}
+}
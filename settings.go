@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+
+	"snake/term"
+)
+
+const settingsFile = "settings.json"
+
+type Settings struct {
+	SpeedUpKey       string `json:"speed_up_key"`
+	SpeedDownKey     string `json:"speed_down_key"`
+	Bindings         string `json:"bindings"`
+	HoldTurn         bool   `json:"hold_turn"`
+	BoostKey         string `json:"boost_key"`
+	RivalAI          string `json:"rival_ai"`
+	VenomKey         string `json:"venom_key"`
+	BulletTime       bool   `json:"bullet_time"`
+	CollisionWarning bool   `json:"collision_warning"`
+	TutorialDone     bool   `json:"tutorial_done"`
+
+	DiscordPresence bool   `json:"discord_presence"`
+	DiscordClientID string `json:"discord_client_id"`
+
+	GamepadStartButton int  `json:"gamepad_start_button"`
+	MouseSteering      bool `json:"mouse_steering"`
+	SmoothRender       bool `json:"smooth_render"`
+	ScaleCells         bool `json:"scale_cells"`
+	SquareCells        bool `json:"square_cells"`
+	ASCIIGlyphs        bool `json:"ascii_glyphs"`
+	IdlePauseSeconds   int  `json:"idle_pause_seconds"`
+	AdaptiveDifficulty bool `json:"adaptive_difficulty"`
+	ScoreDecay         bool `json:"score_decay"`
+	RandomEvents       bool `json:"random_events"`
+	ReducedEffects     bool `json:"reduced_effects"`
+	ReducedMotion      bool `json:"reduced_motion"`
+	Ambience           bool `json:"ambience"`
+	SnakeTrail         bool `json:"snake_trail"`
+
+	SoundPack     string `json:"sound_pack"`
+	SampleDir     string `json:"sample_dir"`
+	Music         bool   `json:"music"`
+	MoveTickSound bool   `json:"move_tick_sound"`
+	Announcements bool   `json:"announcements"`
+	LuaMods       bool   `json:"lua_mods"`
+
+	SyncURL   string `json:"sync_url"`
+	SyncToken string `json:"sync_token"`
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		SpeedUpKey:   "+",
+		SpeedDownKey: "-",
+		Bindings:     "arrows",
+		BoostKey:     " ",
+		RivalAI:      "guloso",
+		VenomKey:     " ",
+
+		GamepadStartButton: 7,
+		IdlePauseSeconds:   30,
+		SampleDir:          "sounds",
+		Music:              true,
+		RandomEvents:       true,
+		Ambience:           true,
+	}
+}
+
+func LoadSettings() Settings {
+	settings := DefaultSettings()
+
+	data, err := settingsFormat.Read(settingsFile)
+	if err != nil {
+		return settings
+	}
+
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return DefaultSettings()
+	}
+
+	return settings
+}
+
+func SaveSettings(settings Settings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return settingsFormat.Write(settingsFile, append(data, '\n'))
+}
+
+func matchesKey(ch rune, key string) bool {
+	return key != "" && string(ch) == key
+}
+
+func eventRune(ev term.Event) rune {
+	if ev.Key == term.KeySpace {
+		return ' '
+	}
+	return ev.Ch
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"snake/term"
+)
+
+const (
+	ShrinkInterval = 20 * time.Second
+	ShrinkWarning  = 5 * time.Second
+	ShrinkMinSize  = 6
+)
+
+type Shrink struct {
+	Rings    int
+	TimeLeft time.Duration
+}
+
+func (g *Game) RandomCell() Point {
+	inset := g.Shrink.Rings
+	return Point{
+		X: g.Rand.Intn(g.Width-2-2*inset) + 1 + inset,
+		Y: g.Rand.Intn(g.Height-2-2*inset) + 1 + inset,
+	}
+}
+
+func (g *Game) CanShrink() bool {
+	rings := g.Shrink.Rings + 1
+	return g.Width-2-2*rings >= ShrinkMinSize && g.Height-2-2*rings >= ShrinkMinSize
+}
+
+func (g *Game) ShrinkWarningActive() bool {
+	return g.Mode == ModeShrink && g.CanShrink() && g.Shrink.TimeLeft <= ShrinkWarning
+}
+
+func (g *Game) UpdateShrink() {
+	if g.Mode != ModeShrink || g.State != StatePlaying || !g.CanShrink() {
+		return
+	}
+
+	g.Shrink.TimeLeft -= g.TickInterval()
+	if g.Shrink.TimeLeft > 0 {
+		return
+	}
+
+	g.Shrink.Rings++
+	g.Shrink.TimeLeft = ShrinkInterval
+	g.TriggerShake(3, 3)
+	g.CrushRing()
+}
+
+func (g *Game) CrushRing() {
+	kept := g.Obstacles[:0]
+	for _, obs := range g.Obstacles {
+		if !g.CheckWallCollision(obs.Position) {
+			kept = append(kept, obs)
+		}
+	}
+	g.Obstacles = kept
+	g.IndexObstacles()
+
+	debris := g.Debris[:0]
+	for _, p := range g.Debris {
+		if !g.CheckWallCollision(p) {
+			debris = append(debris, p)
+		}
+	}
+	g.Debris = debris
+
+	if g.CheckWallCollision(g.Food.Position) {
+		g.GenerateFood()
+	}
+
+	for _, s := range g.PlayerSnakes() {
+		if s.Body.Len() == 0 {
+			continue
+		}
+		if g.CheckWallCollision(s.Body.Head()) {
+			g.Metrics.DeathCause = "esmagado pelo cerco"
+			g.EndGame(s.Body.Head())
+			return
+		}
+
+		cut := s.Body.Len()
+		for i, p := range s.Body.All() {
+			if g.CheckWallCollision(p) {
+				cut = i
+				break
+			}
+		}
+		for s.Body.Len() > cut {
+			g.Occupancy.Remove(g.LayerOf(s), s.Body.PopBack())
+		}
+	}
+}
+
+func (g *Game) DrawShrink(setCell CellSetter) {
+	for ring := 0; ring < g.Shrink.Rings; ring++ {
+		g.drawRing(setCell, ring, '░', term.ColorBlack|term.AttrBold)
+	}
+	if g.ShrinkWarningActive() && !g.Blink(3) {
+		g.drawRing(setCell, g.Shrink.Rings+1, '·', term.ColorRed|term.AttrBold)
+	}
+}
+
+func (g *Game) drawRing(setCell CellSetter, ring int, char rune, color term.Attribute) {
+	left, right := ring, g.Width-1-ring
+	top, bottom := ring, g.Height-1-ring
+	for x := left; x <= right; x++ {
+		setCell(x, top, char, color, term.ColorDefault)
+		setCell(x, bottom, char, color, term.ColorDefault)
+	}
+	for y := top; y <= bottom; y++ {
+		setCell(left, y, char, color, term.ColorDefault)
+		setCell(right, y, char, color, term.ColorDefault)
+	}
+}
+
+func (g *Game) ShrinkHUD() string {
+	if !g.CanShrink() {
+		return "| Cerco: minimo "
+	}
+	return fmt.Sprintf("| Cerco em %ds ", int(g.Shrink.TimeLeft.Seconds())+1)
+}
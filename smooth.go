@@ -0,0 +1,40 @@
+package main
+
+import (
+	"time"
+
+	"snake/term"
+)
+
+const SmoothFrameInterval = 33 * time.Millisecond
+
+var halfBlocks = map[Direction]rune{
+	DirUp:    '▄',
+	DirDown:  '▀',
+	DirLeft:  '▐',
+	DirRight: '▌',
+}
+
+func (g *Game) MoveProgress() float64 {
+	if g.LastTick.IsZero() {
+		return 0
+	}
+	return min(1, float64(time.Since(g.LastTick))/float64(g.TickInterval()))
+}
+
+func (g *Game) DrawSmoothHead(setCell CellSetter) {
+	if !g.SmoothRender() || g.Paused() || g.Snake.Body.Len() == 0 || g.MoveProgress() < 0.5 {
+		return
+	}
+
+	char, ok := halfBlocks[g.Snake.Direction]
+	if !ok {
+		return
+	}
+
+	ahead := g.WrapWalls(g.Snake.Body.Head().Move(g.Snake.Direction))
+	if g.IsDeadly(ahead) || ahead == g.Food.Position {
+		return
+	}
+	setCell(ahead.X, ahead.Y, char, term.ColorYellow, term.ColorDefault)
+}
@@ -5,23 +5,40 @@ import (
 	"math"
 	"math/rand"
 	"os"
+	"os/signal"
+	"runtime/debug"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/faiface/beep"
+	"github.com/faiface/beep/effects"
 	"github.com/faiface/beep/speaker"
-	"github.com/nsf/termbox-go"
+	"github.com/mattn/go-runewidth"
+
+	"snake/term"
 )
 
 type Point struct {
-	X int
-	Y int
+	X int `json:"x"`
+	Y int `json:"y"`
 }
 
 type Snake struct {
-	Body      []Point
-	Direction string
+	Body         SnakeBody
+	Direction    Direction
+	Moved        Direction
+	Slid         bool
+	Invulnerable int
+}
+
+func (s *Snake) CanTurn(direction Direction) bool {
+	last := s.Moved
+	if last == DirNone {
+		last = s.Direction
+	}
+	return direction != DirNone && direction != last.Opposite()
 }
 
 type FoodType int
@@ -32,8 +49,11 @@ const (
 )
 
 type Food struct {
-	Position Point
-	Type     FoodType
+	Position      Point
+	Type          FoodType
+	TTL           time.Duration
+	SpawnTick     int
+	SpawnDistance int
 }
 
 type GameState int
@@ -42,39 +62,190 @@ const (
 	StateMenu GameState = iota
 	StatePlaying
 	StateGameOver
+	StateEditor
+)
+
+const (
+	DefaultWidth  = 40
+	DefaultHeight = 20
+	MinWidth      = 20
+	MinHeight     = 15
+
+	SpeedStep      = 10 * time.Millisecond
+	MaxSpeedOffset = 3
+
+	SpawnClearance     = 5
+	SpawnGraceDistance = 3
+	SpawnGraceTicks    = 8
 )
 
+type GameMode int
+
+const (
+	ModeClassic GameMode = iota
+	ModeBattle
+	ModeCoop
+	ModeLives
+	ModeSandbox
+	ModeCasual
+	ModeTutorial
+	ModeFog
+	ModeWeekly
+	ModeHunger
+	ModeDebris
+	ModeRisk
+	ModeShrink
+	ModePuzzle
+	ModeZen
+	ModeHardcore
+)
+
+var modeNames = []string{"Classico", "Batalha", "Cooperativo", "Vidas", "Treino", "Casual", "Tutorial", "Neblina", "Semanal", "Fome", "Entulho", "Risco", "Cerco", "Quebra-cabeca", "Zen", "Hardcore"}
+
+func (m GameMode) IsRanked() bool {
+	return m != ModeSandbox && m != ModeCasual && m != ModeTutorial && m != ModePuzzle && m != ModeZen
+}
+
+func (m GameMode) String() string {
+	return modeNames[m]
+}
+
 type Game struct {
-	Snake      Snake
-	Food       Food
-	Score      int
-	HighScore  int
-	GameOver   bool
-	Width      int
-	Height     int
-	State      GameState
-	Level      int
-	Speed      time.Duration
-	FrameCount int
-	Obstacles  []Point
+	Snake              Snake
+	Food               Food
+	Score              int
+	HighScore          int
+	GameOver           bool
+	Width              int
+	Height             int
+	State              GameState
+	Level              int
+	Speed              time.Duration
+	FrameCount         int
+	PlayTicks          int
+	Obstacles          []Obstacle
+	ShakeFrames        int
+	FlashFrames        int
+	Camera             Camera
+	Events             *EventBus
+	Recorder           *Recorder
+	Seed               int64
+	Rand               *rand.Rand
+	StatusMsg          string
+	Mode               GameMode
+	Rivals             []*Rival
+	Pellets            []Food
+	Coop               Coop
+	Lives              int
+	MaxLives           int
+	Settings           Settings
+	SpeedOffset        int
+	Theme              string
+	Environment        BoardEnvironment
+	Sandbox            Sandbox
+	Layout             *Layout
+	Editor             Editor
+	History            []Snapshot
+	Undos              int
+	FreezeTicks        int
+	BulletTime         int
+	BulletTimeCooldown int
+	Debug              DebugInfo
+	Occupancy          *OccupancyGrid
+	ConfirmQuit        bool
+	Tutorial           Tutorial
+	ShowHelp           bool
+	Toasts             []Toast
+	BestLength         int
+	BestLengthBeaten   bool
+	Popups             []Popup
+	Stats              Stats
+	ShowHeatmap        bool
+	Metrics            RunMetrics
+	ShowSummary        bool
+	PlayerController   Controller
+	UserPaused         bool
+	Boost              Boost
+	Modifiers          Modifiers
+	BaseWidth          int
+	BaseHeight         int
+	LastTick           time.Time
+	Renderer           string
+	Recovery           *Recovery
+	RecoveryDirty      bool
+	LastAutosave       time.Time
+	LastInput          time.Time
+	IdlePaused         bool
+	Hunger             Hunger
+	Decay              Decay
+	FairPlay           FairPlay
+	Render             RenderBudget
+	MenuFrame          int
+	MenuDirty          bool
+	MenuDrawnAt        time.Time
+	Music              MusicPlayer
+	Announcer          Announcer
+	TurnHold           TurnHold
+	Puzzle             Puzzle
+	Hardcore           Hardcore
+	RandomEvents       RandomEvents
+	DayNight           DayNight
+	Trail              Trail
+	LevelBar           LevelBar
+	Steps              chan struct{}
+	Debris             []Point
+	Twin               Snake
+	Boss               Boss
+	Venom              int
+	Projectiles        []Projectile
+	RiskZones          []RiskZone
+	RiskTicks          int
+	Shrink             Shrink
+	Checkpoint         *Snapshot
+	NextCheckpoint     int
+	Adaptive           Adaptive
+	Scripts            Scripts
+	Headless           bool
+	Demo               bool
+	Challenge          *ChallengeCode
+	CodeEntry          CodeEntry
+	DemoWait           int
+	Server             *MatchServer
+	Remote             *RemoteLink
+	Chat               Chat
+	Annotations        []Annotation
 }
 
 type ToneGenerator struct {
-	freq float64
-	pos  float64
-	sr   beep.SampleRate
+	note  Note
+	wave  func(phase float64) float64
+	pos   float64
+	total float64
+	sr    beep.SampleRate
 }
 
-func NewTone(sr beep.SampleRate, freq float64) *ToneGenerator {
+func NewTone(sr beep.SampleRate, note Note) *ToneGenerator {
 	return &ToneGenerator{
-		freq: freq,
-		sr:   sr,
+		note:  note,
+		wave:  note.Wave(),
+		total: float64(sr.N(note.Duration())),
+		sr:    sr,
 	}
 }
 
 func (t *ToneGenerator) Stream(samples [][2]float64) (n int, ok bool) {
+	attack := float64(t.sr.N(time.Duration(t.note.AttackMs) * time.Millisecond))
+	release := float64(t.sr.N(time.Duration(t.note.ReleaseMs) * time.Millisecond))
 	for i := range samples {
-		v := math.Sin(t.pos * 2 * math.Pi * t.freq / float64(t.sr))
+		_, phase := math.Modf(t.pos * t.note.Freq / float64(t.sr))
+		envelope := 1.0
+		if attack > 0 {
+			envelope = min(envelope, t.pos/attack)
+		}
+		if release > 0 {
+			envelope = min(envelope, (t.total-t.pos)/release)
+		}
+		v := t.wave(phase) * t.note.Gain() * max(0, envelope)
 		samples[i][0] = v
 		samples[i][1] = v
 		t.pos++
@@ -88,25 +259,41 @@ func (t *ToneGenerator) Err() error {
 
 var soundInitialized = false
 
+var soundFallback = false
+
 func initSound() {
 	if !soundInitialized {
-		sr := beep.SampleRate(44100)
-		speaker.Init(sr, sr.N(time.Second/10))
+		sr := sampleRate
+		if err := speaker.Init(sr, sr.N(time.Second/10)); err != nil {
+			logger.Error("falha ao iniciar audio, usando o bipe do console", "erro", err)
+			soundFallback = true
+			return
+		}
 		soundInitialized = true
 	}
 }
 
-func playTone(freq float64, duration time.Duration) {
+func closeSound() {
+	if soundInitialized {
+		speaker.Close()
+		soundInitialized = false
+	}
+}
+
+func playTone(note Note, pan float64) {
 	if !soundInitialized {
+		if soundFallback {
+			consoleBeep(note.Freq, note.Duration())
+		}
 		return
 	}
 
-	sr := beep.SampleRate(44100)
-	tone := NewTone(sr, freq)
-	sound := beep.Take(sr.N(duration), tone)
+	sr := sampleRate
+	tone := NewTone(sr, note)
+	sound := beep.Take(sr.N(note.Duration()), tone)
 
 	done := make(chan bool)
-	speaker.Play(beep.Seq(sound, beep.Callback(func() {
+	speaker.Play(beep.Seq(&effects.Pan{Streamer: sound, Pan: pan}, beep.Callback(func() {
 		done <- true
 	})))
 
@@ -115,142 +302,274 @@ func playTone(freq float64, duration time.Duration) {
 	}()
 }
 
-func soundEat() {
-	go playTone(800, 50*time.Millisecond)
-}
-
-func soundPowerUp() {
-	go func() {
-		playTone(600, 100*time.Millisecond)
-		time.Sleep(50 * time.Millisecond)
-		playTone(800, 100*time.Millisecond)
-		time.Sleep(50 * time.Millisecond)
-		playTone(1000, 100*time.Millisecond)
-	}()
-}
-
-func soundLevelUp() {
-	go func() {
-		playTone(1000, 100*time.Millisecond)
-		time.Sleep(80 * time.Millisecond)
-		playTone(1200, 100*time.Millisecond)
-	}()
+func (g *Game) SubscribeSounds() {
+	bus := g.Events
+	bus.Subscribe(EventFoodEaten, func(e Event) {
+		if e.Food != PowerUpFood {
+			PlayEffectAt("eat", g.SoundPan(e.Position))
+		}
+	})
+	bus.Subscribe(EventPowerUpActivated, func(e Event) {
+		PlayEffectAt("powerup", g.SoundPan(e.Position))
+	})
+	bus.Subscribe(EventLevelUp, func(e Event) {
+		PlayEffect("levelup")
+	})
+	bus.Subscribe(EventDeath, func(e Event) {
+		PlayEffectAt("gameover", g.SoundPan(e.Position))
+	})
+	bus.Subscribe(EventBossSpawned, func(e Event) {
+		PlayEffectAt("boss", g.SoundPan(e.Position))
+	})
+	bus.Subscribe(EventBossDefeated, func(e Event) {
+		PlayEffectAt("boss_defeated", g.SoundPan(e.Position))
+	})
+	bus.Subscribe(EventTick, func(e Event) {
+		if g.Settings.MoveTickSound && soundInitialized && g.State == StatePlaying {
+			PlayEffect("tick")
+		}
+	})
+	g.SubscribeHeartbeat()
 }
 
-func soundGameOver() {
-	go func() {
-		playTone(400, 200*time.Millisecond)
-		time.Sleep(100 * time.Millisecond)
-		playTone(300, 200*time.Millisecond)
-		time.Sleep(100 * time.Millisecond)
-		playTone(200, 300*time.Millisecond)
-	}()
+func highScoreFile(mode GameMode, mods Modifiers) string {
+	if mode == ModeWeekly {
+		return "highscore-semanal-" + CurrentChallenge(time.Now()).ID() + ".txt"
+	}
+	if mods != 0 {
+		return "highscore-" + strings.ToLower(mode.String()) + "-" + mods.Code() + ".txt"
+	}
+	if mode == ModeClassic {
+		return "highscore.txt"
+	}
+	return "highscore-" + strings.ToLower(mode.String()) + ".txt"
 }
 
-func LoadHighScore() int {
-	data, err := os.ReadFile("highscore.txt")
-	if err != nil {
+func LoadHighScore(mode GameMode, mods Modifiers) int {
+	path := highScoreFile(mode, mods)
+	data, err := highScoreFormat.Read(path)
+	if os.IsNotExist(err) {
 		return 0
 	}
 
-	score, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	score := 0
+	if err == nil {
+		score, err = strconv.Atoi(strings.TrimSpace(string(data)))
+	}
 	if err != nil {
+		SetAsideBroken(path, err)
 		return 0
 	}
 
 	return score
 }
 
-func SaveHighScore(score int) error {
-	return os.WriteFile("highscore.txt", []byte(fmt.Sprintf("%d", score)), 0644)
+func SaveHighScore(mode GameMode, mods Modifiers, score int) error {
+	return highScoreFormat.Write(highScoreFile(mode, mods), []byte(fmt.Sprintf("%d", score)))
 }
 
-func NewGame() *Game {
+func NewGame(width, height int, theme string, layout *Layout) *Game {
 	game := &Game{
-		Snake: Snake{
-			Body: []Point{
-				{X: 10, Y: 10},
-				{X: 9, Y: 10},
-				{X: 8, Y: 10},
-			},
-			Direction: "right",
-		},
 		Score:      0,
-		HighScore:  LoadHighScore(),
+		HighScore:  LoadHighScore(ModeClassic, 0),
 		GameOver:   false,
-		Width:      40,
-		Height:     20,
+		Width:      width,
+		Height:     height,
 		State:      StateMenu,
 		Level:      1,
 		Speed:      150 * time.Millisecond,
 		FrameCount: 0,
-		Obstacles:  []Point{},
+		Obstacles:  []Obstacle{},
+		Events:     NewEventBus(),
+		Theme:      theme,
+		Metrics:    NewRunMetrics(),
+		Adaptive:   NewAdaptive(),
+		Steps:      make(chan struct{}, 1),
 	}
+	game.SubscribeTutorial()
+	game.SubscribeMilestones()
+	game.SubscribePopups()
+	game.SubscribeStats()
+	game.SubscribeMetrics()
+	game.SubscribeBoost()
+	game.SubscribeHunger()
+	game.SubscribeDebris()
+	game.SubscribeBoss()
+	game.SubscribeVenom()
+	game.SubscribeAdaptive()
+	game.SubscribeDecay()
+	game.SubscribePuzzle()
+	game.SubscribeHardcore()
+	game.SubscribeRandomEvents()
+	game.SubscribeDayNight()
+	game.BaseWidth, game.BaseHeight = width, height
+	game.ApplyLayout(layout)
+	game.Snake = game.NewPlayerSnake()
+	game.RebuildOccupancy()
+	game.Reseed(time.Now().UnixNano())
+	game.SelectEnvironment()
 	game.GenerateFood()
 	game.GenerateObstacles()
 	return game
 }
 
 func (g *Game) Reset() {
-	g.Snake = Snake{
-		Body: []Point{
-			{X: 10, Y: 10},
-			{X: 9, Y: 10},
-			{X: 8, Y: 10},
-		},
-		Direction: "right",
+	if g.Mode == ModeWeekly && g.Challenge == nil {
+		g.Modifiers = CurrentChallenge(time.Now()).Modifiers
 	}
+	g.ApplyBoardSize()
+	g.Snake = g.NewPlayerSnake()
 	g.Score = 0
 	g.GameOver = false
 	g.State = StatePlaying
 	g.Level = 1
-	g.Speed = 150 * time.Millisecond
+	g.ApplySpeed()
 	g.FrameCount = 0
-	g.Obstacles = []Point{}
+	g.PlayTicks = 0
+	g.Obstacles = []Obstacle{}
+	g.ShakeFrames = 0
+	g.FlashFrames = 0
+	g.StatusMsg = ""
+	g.Reseed(g.RunSeed())
+	g.Rivals = nil
+	g.Pellets = nil
+	g.Coop = Coop{}
+	g.Sandbox = Sandbox{Cursor: Point{X: g.Width / 2, Y: g.Height / 2}}
+	g.Lives = g.MaxLives
+	g.History = nil
+	g.Undos = 0
+	g.FreezeTicks = 0
+	g.BulletTime = 0
+	g.BulletTimeCooldown = 0
+	g.ConfirmQuit = false
+	g.ShowHelp = false
+	g.Toasts = nil
+	g.Popups = nil
+	g.ShowHeatmap = false
+	g.Metrics = NewRunMetrics()
+	g.ShowSummary = false
+	g.UserPaused = false
+	g.IdlePaused = false
+	g.Hunger = Hunger{}
+	g.Decay = Decay{}
+	g.FairPlay = FairPlay{}
+	g.Debris = nil
+	g.Trail = Trail{}
+	g.LevelBar = LevelBar{Level: 1}
+	g.Twin = Snake{}
+	g.Boss = Boss{}
+	g.Venom = 0
+	g.Projectiles = nil
+	g.RiskZones = nil
+	g.Shrink = Shrink{TimeLeft: ShrinkInterval}
+	g.Checkpoint = nil
+	g.NextCheckpoint = CheckpointEvery
+	g.LastInput = time.Now()
+	g.Boost = Boost{Stamina: MaxStamina}
+	g.BestLength = LoadBestLength()
+	g.BestLengthBeaten = false
+	g.HighScore = LoadHighScore(g.Mode, g.Modifiers)
+	if g.Mode == ModeCoop {
+		g.StartCoop()
+	}
+	if g.Mode == ModeTutorial {
+		g.StartTutorial()
+	}
+	g.RebuildOccupancy()
+	g.SelectEnvironment()
 	g.GenerateFood()
 	g.GenerateObstacles()
+	if g.Mode == ModeBattle {
+		g.SpawnRivals(2)
+	}
+	if g.Mode == ModePuzzle {
+		g.StartPuzzle()
+	}
+	g.Emit(Event{Type: EventGameStart, Position: g.FocusPoint()})
+}
+
+func (g *Game) Reseed(seed int64) {
+	g.Seed = seed
+	g.Rand = rand.New(rand.NewSource(seed))
 }
 
 func (g *Game) UpdateLevel() {
-	newLevel := (g.Score / 50) + 1
+	if g.Mode == ModePuzzle || g.Mode == ModeZen {
+		return
+	}
+	newLevel := (g.Score / LevelPoints) + 1
 
 	if newLevel > g.Level {
 		g.Level = newLevel
-		g.Speed = time.Duration(150-((g.Level-1)*10)) * time.Millisecond
-		if g.Speed < 50*time.Millisecond {
-			g.Speed = 50 * time.Millisecond
-		}
+		g.ApplySpeed()
+		g.SelectEnvironment()
+		previous := g.Obstacles
 		g.GenerateObstacles()
+		g.TelegraphObstacles(previous)
+		g.GrantSpawnGrace()
+	}
+}
+
+func (g *Game) BaseSpeed() time.Duration {
+	speed := time.Duration(150-((g.Level-1)*10)) * time.Millisecond
+	if speed < 50*time.Millisecond {
+		speed = 50 * time.Millisecond
+	}
+	return speed
+}
+
+func (g *Game) ApplySpeed() {
+	g.Speed = g.BaseSpeed() - time.Duration(g.SpeedOffset)*SpeedStep
+	if g.Modifiers.Has(ModDoubleSpeed) {
+		g.Speed /= 2
 	}
+	g.Speed = time.Duration(float64(g.Speed) / g.DifficultyFactor())
+	if g.Speed < 30*time.Millisecond {
+		g.Speed = 30 * time.Millisecond
+	}
+}
+
+func (g *Game) AdjustSpeed(delta int) {
+	g.SpeedOffset = clamp(g.SpeedOffset+delta, -MaxSpeedOffset, MaxSpeedOffset)
+	g.ApplySpeed()
+}
+
+func (g *Game) SpeedMultiplier() float64 {
+	return 1 + 0.25*float64(g.SpeedOffset)
 }
 
 func (g *Game) CheckAndSaveHighScore() bool {
+	if !g.Mode.IsRanked() || g.Scripts.Active() {
+		return false
+	}
+
 	if g.Score > g.HighScore {
 		g.HighScore = g.Score
-		SaveHighScore(g.HighScore)
+		SaveHighScore(g.Mode, g.Modifiers, g.HighScore)
 		return true
 	}
 	return false
 }
 
 func (g *Game) IsPositionSafe(pos Point) bool {
-	for _, chunk := range g.Snake.Body {
-		if pos.X == chunk.X && pos.Y == chunk.Y {
-			return false
-		}
+	if g.CheckWallCollision(pos) || g.CheckSelfCollision(pos) {
+		return false
 	}
 
 	if pos.X == g.Food.Position.X && pos.Y == g.Food.Position.Y {
 		return false
 	}
 
-	for _, obs := range g.Obstacles {
-		if pos.X == obs.X && pos.Y == obs.Y {
-			return false
-		}
+	if g.ObstacleAt(pos) != nil || g.CheckRivalCollision(pos) || g.PelletAt(pos) >= 0 || g.Boss.Contains(pos) {
+		return false
+	}
+
+	if g.CheckPartnerCollision(pos) || (g.Coop.Down > 0 && pos == g.Coop.Heart.Position) {
+		return false
 	}
 
-	startX, startY := 10, 10
+	start := g.StartPoint()
+	startX, startY := start.X, start.Y
 	if pos.X >= startX-2 && pos.X <= startX+2 &&
 		pos.Y >= startY-2 && pos.Y <= startY+2 {
 		return false
@@ -260,22 +579,90 @@ func (g *Game) IsPositionSafe(pos Point) bool {
 }
 
 func (g *Game) GenerateObstacles() {
-	g.Obstacles = []Point{}
+	if g.Mode == ModeSandbox || g.Mode == ModeTutorial || g.Mode == ModeZen {
+		return
+	}
+	if g.Mode == ModePuzzle {
+		g.PlacePuzzleWalls()
+		return
+	}
 
-	numObstacles := g.Level * 2
-	if numObstacles > 20 {
-		numObstacles = 20
+	g.Obstacles = []Obstacle{}
+	g.Occupancy.ClearObstacles()
+	g.RestoreDebris()
+	g.GenerateRiskZones()
+
+	if g.Layout != nil {
+		for _, wall := range g.Layout.Walls {
+			g.Obstacles = append(g.Obstacles, Obstacle{Position: wall, Type: WallObstacle})
+		}
+		g.IndexObstacles()
+		g.SpawnRiskObstacles()
+		return
 	}
 
+	areaScale := max(1, (g.Width*g.Height)/(DefaultWidth*DefaultHeight))
+
+	numObstacles := g.Level * 2 * areaScale
+	if numObstacles > 20*areaScale {
+		numObstacles = 20 * areaScale
+	}
+	numObstacles = int(float64(numObstacles) * g.DifficultyFactor())
+
 	for i := 0; i < numObstacles; i++ {
 		for attempts := 0; attempts < 50; attempts++ {
-			pos := Point{
-				X: rand.Intn(g.Width-2) + 1,
-				Y: rand.Intn(g.Height-2) + 1,
+			pos := g.RandomCell()
+
+			if g.IsPositionSafe(pos) && !g.IsAheadOfPlayers(pos) {
+				g.Obstacles = append(g.Obstacles, Obstacle{Position: pos, Type: WallObstacle})
+				g.Occupancy.SetObstacle(pos, len(g.Obstacles)-1)
+				obs := &g.Obstacles[len(g.Obstacles)-1]
+				obstacleBehaviors[obs.Type].OnSpawn(g, obs)
+				break
 			}
+		}
+	}
+	g.SpawnRiskObstacles()
+}
+
+func (g *Game) PlayerSnakes() []*Snake {
+	snakes := []*Snake{&g.Snake}
+	if g.Mode == ModeCoop {
+		snakes = append(snakes, &g.Coop.Partner)
+	}
+	if g.IsSplit() {
+		snakes = append(snakes, &g.Twin)
+	}
+	return snakes
+}
+
+func (g *Game) IsAheadOfPlayers(pos Point) bool {
+	for _, s := range g.PlayerSnakes() {
+		if s.Body.Len() == 0 {
+			continue
+		}
 
-			if g.IsPositionSafe(pos) {
-				g.Obstacles = append(g.Obstacles, pos)
+		p := s.Body.Head()
+		for i := 0; i < SpawnClearance; i++ {
+			p = p.Move(s.Direction)
+			if p == pos {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (g *Game) GrantSpawnGrace() {
+	for _, s := range g.PlayerSnakes() {
+		if s.Body.Len() == 0 {
+			continue
+		}
+
+		head := s.Body.Head()
+		for _, obs := range g.Obstacles {
+			if abs(obs.Position.X-head.X)+abs(obs.Position.Y-head.Y) <= SpawnGraceDistance {
+				s.Invulnerable = max(s.Invulnerable, SpawnGraceTicks)
 				break
 			}
 		}
@@ -283,104 +670,269 @@ func (g *Game) GenerateObstacles() {
 }
 
 func (g *Game) GenerateFood() {
+	if g.Mode == ModePuzzle {
+		g.NextPuzzleFood()
+		return
+	}
+
 	var position Point
 
 	for attempts := 0; attempts < 100; attempts++ {
-		position = Point{
-			X: rand.Intn(g.Width-2) + 1,
-			Y: rand.Intn(g.Height-2) + 1,
-		}
+		position = g.RandomCell()
 
-		if g.IsPositionSafe(position) {
+		if g.IsPositionSafe(position) && g.ModAllowsSpawn(position) {
 			break
 		}
 	}
 
-	foodType := NormalFood
-	if rand.Intn(100) < 20 {
-		foodType = PowerUpFood
+	focus := g.FocusPoint()
+	g.Food = Food{
+		Position:      position,
+		Type:          g.ModSpawnKind(position, g.NextFoodType()),
+		TTL:           g.Environment.FoodTTL(),
+		SpawnTick:     g.PlayTicks,
+		SpawnDistance: abs(position.X-focus.X) + abs(position.Y-focus.Y),
 	}
+	foodBehaviors[g.Food.Type].OnSpawn(g, &g.Food)
+}
 
-	g.Food = Food{
-		Position: position,
-		Type:     foodType,
+func (g *Game) NextFoodType() FoodType {
+	if g.Mode == ModeTutorial {
+		return g.TutorialFoodType()
 	}
+	if g.Boss.Active {
+		return BossFood
+	}
+	return RandomFoodType(g.Rand, g.FoodWeights())
 }
 
 func (g *Game) MoveSnake() {
-	head := g.Snake.Body[0]
-	newHead := Point{X: head.X, Y: head.Y}
-
-	switch g.Snake.Direction {
-	case "up":
-		newHead.Y--
-	case "down":
-		newHead.Y++
-	case "left":
-		newHead.X--
-	case "right":
-		newHead.X++
-	}
-
-	if g.CheckWallCollision(newHead) ||
-		g.CheckSelfCollision(newHead) ||
-		g.CheckObstacleCollision(newHead) {
-		g.GameOver = true
-		g.State = StateGameOver
-		g.CheckAndSaveHighScore()
-		soundGameOver()
+	if g.FreezeTicks > 0 {
+		g.FreezeTicks--
 		return
 	}
+	g.PlayTicks++
 
-	g.Snake.Body = append([]Point{newHead}, g.Snake.Body...)
+	if g.Mode == ModeCasual {
+		g.PushHistory()
+		g.UpdateCheckpoint()
+	}
 
-	if newHead.X == g.Food.Position.X && newHead.Y == g.Food.Position.Y {
-		points := 10
-		if g.Food.Type == PowerUpFood {
-			points = 50
-			soundPowerUp()
+	g.Emit(Event{Type: EventTick, Position: g.FocusPoint()})
+	g.RecordInputApplied()
+	g.UpdateBoost()
+	g.UpdateTurnHold()
+	g.TickItems()
+
+	if g.PlayerController != nil && g.Snake.Body.Len() > 0 {
+		g.Snake.Direction = g.PlayerController.Direction(g, &g.Snake)
+	}
+
+	if g.Mode == ModeCoop {
+		g.MoveCoop()
+	} else if newHead, alive := g.StepPlayer(&g.Snake); !alive {
+		if g.Mode == ModeTutorial {
+			g.RetryTutorial(newHead)
+		} else if g.Mode == ModeLives && g.Lives > 1 {
+			g.LoseLife(newHead)
 		} else {
-			soundEat()
+			g.EndGame(newHead)
+			return
+		}
+	}
+
+	if g.IsSplit() && g.State == StatePlaying {
+		if newHead, alive := g.StepTwin(); !alive {
+			g.EndGame(newHead)
+			return
 		}
+	}
+
+	if g.State == StatePlaying {
+		g.MoveRivals()
+		g.UpdateProjectiles()
+		g.UpdateRiskZones()
+		g.UpdateShrink()
+	}
+
+	g.UpdateBoss()
+	g.UpdateHunger()
+	g.UpdateDecay()
+	if g.State != StatePlaying {
+		return
+	}
+
+	g.UpdateBulletTime()
+	g.CollectMetrics()
+	g.UpdateTrail()
 
-		oldLevel := g.Level
-		g.Score += points
-		g.UpdateLevel()
+	if g.Mode == ModeTutorial {
+		g.TickTutorial()
+	}
+	if g.Mode == ModePuzzle {
+		g.UpdatePuzzle()
+	}
+}
 
-		if g.Level > oldLevel {
-			soundLevelUp()
+func (g *Game) StepPlayer(s *Snake) (Point, bool) {
+	head := s.Body.Head()
+	if g.Environment.SkipMove(g, s) {
+		return head, true
+	}
+
+	direction := g.Environment.NextDirection(s)
+	newHead := g.WrapWalls(head.Move(direction))
+
+	if g.CollisionsDisabled() {
+		newHead = g.Wrap(newHead)
+	} else if s.Invulnerable > 0 {
+		s.Invulnerable--
+		if g.CheckWallCollision(newHead) {
+			return head, true
 		}
+	} else if g.CheckWallCollision(newHead) ||
+		(g.CheckSelfCollision(newHead) || g.CheckPartnerCollision(newHead)) && !g.VacatesTail(s, newHead) ||
+		g.CheckRivalCollision(newHead) ||
+		g.Boss.Contains(newHead) ||
+		g.HitObstacle(newHead) {
+		return newHead, false
+	}
 
+	if g.IsNearMiss(s, newHead) {
+		g.TriggerShake(3, 2)
+	}
+
+	s.Moved = direction
+
+	layer := g.LayerOf(s)
+	s.Body.PushFront(newHead)
+	g.Occupancy.Add(layer, newHead)
+
+	if newHead.X == g.Food.Position.X && newHead.Y == g.Food.Position.Y {
+		points := g.RiskPoints(g.FoodPoints(foodBehaviors[g.Food.Type].OnEaten(g, &g.Food), &g.Food), newHead)
+		g.AddScore(points, newHead, g.Food.Type)
 		g.GenerateFood()
+	} else if points, ok := g.EatPellet(newHead); ok {
+		g.AddScore(points, newHead, PelletFood)
 	} else {
-		g.Snake.Body = g.Snake.Body[:len(g.Snake.Body)-1]
+		g.Occupancy.Remove(layer, s.Body.PopBack())
 	}
+
+	return newHead, true
 }
 
-func (g *Game) CheckWallCollision(p Point) bool {
-	return p.X <= 0 || p.X >= g.Width-1 || p.Y <= 0 || p.Y >= g.Height-1
+func (g *Game) AddScore(points int, position Point, food FoodType) {
+	points = int(math.Round(float64(points) * g.SpeedMultiplier()))
+
+	oldLevel := g.Level
+	g.Score += points
+	g.Emit(Event{Type: EventFoodEaten, Position: position, Food: food, Points: points})
+
+	g.UpdateLevel()
+
+	if g.Level > oldLevel {
+		g.Emit(Event{Type: EventLevelUp, Position: position})
+	}
 }
 
-func (g *Game) CheckSelfCollision(head Point) bool {
-	for _, chunk := range g.Snake.Body {
-		if head.X == chunk.X && head.Y == chunk.Y {
-			return true
+func (g *Game) EndGame(position Point) {
+	if g.Metrics.DeathCause == "" {
+		g.Metrics.DeathCause = g.CollisionCause(position)
+	}
+	g.Metrics.Duration = time.Since(g.Metrics.StartedAt)
+	g.ShowSummary = true
+	g.GameOver = true
+	g.State = StateGameOver
+	g.MarkFairPlay()
+	if g.Persists() {
+		g.CheckAndSaveHighScore()
+		g.CheckAndSaveBestLength()
+		RemoveRecovery()
+		if err := AppendRun(g.RunRecord()); err != nil {
+			logger.Error("falha ao registrar partida", "erro", err)
 		}
 	}
-	return false
+	g.TriggerShake(10, 10)
+	g.Emit(Event{Type: EventDeath, Position: position})
 }
 
-func (g *Game) CheckObstacleCollision(p Point) bool {
-	for _, obs := range g.Obstacles {
-		if p.X == obs.X && p.Y == obs.Y {
-			return true
-		}
+func (g *Game) FocusPoint() Point {
+	if g.Snake.Body.Len() > 0 {
+		return g.Snake.Body.Head()
 	}
-	return false
+	if g.Coop.Partner.Body.Len() > 0 {
+		return g.Coop.Partner.Body.Head()
+	}
+	return Point{X: g.Width / 2, Y: g.Height / 2}
+}
+
+func (g *Game) IsDeadly(p Point) bool {
+	return g.CheckWallCollision(p) || g.CheckSelfCollision(p) || g.CheckPartnerCollision(p) ||
+		g.CheckObstacleCollision(p) || g.CheckRivalCollision(p) || g.Boss.Contains(p)
+}
+
+func (g *Game) IsNearMiss(s *Snake, newHead Point) bool {
+	if s.Body.Len() < 2 {
+		return false
+	}
+
+	head, neck := s.Body.Head(), s.Body.At(1)
+	ahead := g.WrapWalls(Point{X: head.X + (head.X - neck.X), Y: head.Y + (head.Y - neck.Y)})
+
+	return ahead != newHead && g.IsDeadly(ahead)
+}
+
+func (g *Game) TriggerShake(shakeFrames, flashFrames int) {
+	if shakeFrames > g.ShakeFrames {
+		g.ShakeFrames = shakeFrames
+	}
+	if flashFrames > g.FlashFrames {
+		g.FlashFrames = flashFrames
+	}
+}
+
+func (g *Game) UpdateEffects() {
+	if g.ShakeFrames > 0 {
+		g.ShakeFrames--
+	}
+	if g.FlashFrames > 0 {
+		g.FlashFrames--
+	}
+	g.ExpireToasts()
+	g.ExpirePopups()
+	g.UpdateLevelBar()
+}
+
+func (g *Game) ShakeOffset() (int, int) {
+	if g.ShakeFrames == 0 || g.ReducedEffects() {
+		return 0, 0
+	}
+
+	offsets := []Point{{X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: -1}}
+	offset := offsets[g.FrameCount%len(offsets)]
+	return offset.X, offset.Y
+}
+
+func (g *Game) CheckWallCollision(p Point) bool {
+	inset := g.Shrink.Rings
+	return p.X <= inset || p.X >= g.Width-1-inset || p.Y <= inset || p.Y >= g.Height-1-inset
+}
+
+func (g *Game) CheckSelfCollision(head Point) bool {
+	return g.Occupancy.Has(LayerPlayer, head)
+}
+
+func (g *Game) VacatesTail(s *Snake, p Point) bool {
+	return s.Body.Len() > 2 && p == s.Body.Tail() && p != g.Food.Position && g.PelletAt(p) < 0
+}
+
+func (g *Game) CheckObstacleCollision(p Point) bool {
+	obs := g.ObstacleAt(p)
+	return obs != nil && obs.IsSolid()
 }
 
 func (g *Game) DrawMenu() {
-	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+	screen.Clear(term.ColorDefault, term.ColorDefault)
 
 	title := []string{
 		"          ____  _   _    _    _  ________ ",
@@ -390,248 +942,904 @@ func (g *Game) DrawMenu() {
 		"         |____/|_| \\_/_/   \\_\\_|\\_\\_____|",
 	}
 
-	menu := []string{
-		"  ╔═════════════ ═╗",
-		"  ║                                           ║",
-		fmt.Sprintf("  ║         ★ RECORDE: %-21d║", g.HighScore),
-		"  ║                                           ║",
-		"  ║  CONTROLES:                               ║",
-		"  ║    Setas : Movimentar                     ║",
-		"  ║    ENTER : Iniciar jogo                   ║",
-		"  ║    R     : Reiniciar                      ║",
-		"  ║    ESC   : Sair                           ║",
-		"  ║                                           ║",
-		"  ║  REGRAS:                                  ║",
-		"  ║    ◆ Comida normal ....... 10 pontos    ║",
-		"  ║    ★ Power-up ............ 50 pontos    ║",
-		"  ║    ▓ Obstaculos .......... Evite!       ║",
-		"  ║                                           ║",
-		"  ║  A cada 50 pontos = +1 nivel              ║",
-		"  ║  Mais nivel = Mais rapido + obstaculos    ║",
-		"  ║                                           ║",
-		"  ║      Pressione ENTER para comecar         ║",
-		"  ║                                           ║",
-		"  ╚══════════════ ╝",
-	}
+	menu := BoxLines([]string{
+		"",
+		fmt.Sprintf("        ★ RECORDE: %d", g.HighScore),
+		fmt.Sprintf("        MODO: < %-11s >", g.Mode),
+		fmt.Sprintf(" MODS: %s", g.Modifiers),
+		" " + g.MenuChallengeLine(),
+		"",
+		" CONTROLES:",
+		fmt.Sprintf("   %s : Movimentar [%s]", runewidth.FillRight(g.Bindings().Label, 5), g.Bindings().Name),
+		"   ENTER : Iniciar jogo",
+		"   ←/→   : Trocar modo (no menu)",
+		"   E     : Editor de niveis",
+		"   1-6   : Modificadores",
+		"   C     : Jogar por codigo",
+		"   R     : Reiniciar",
+		"   ESC   : Sair",
+		"",
+		" REGRAS:",
+		"   ◆ Comida normal ....... 10 a 30 pontos",
+		"   ★ Power-up ............ 50 a 150 pontos",
+		"   (vale mais longe e cai se demorar)",
+		"   ▓ Obstaculos .......... Evite!",
+		"",
+		" A cada 50 pontos = +1 nivel",
+		" Mais nivel = Mais rapido + obstaculos",
+		"",
+		"     Pressione ENTER para comecar",
+		"",
+	}, 41)
 
 	startY := 3
 	startX := 2
 
-	for i, line := range title {
-		for j, char := range line {
-			termbox.SetCell(startX+j, startY+i, char, termbox.ColorGreen|termbox.AttrBold, termbox.ColorDefault)
-		}
-	}
+	g.DrawTitle(startX, startY, title)
+	g.DrawMenuSnake(startX+2, startY+len(title)+1, menu)
 
-	menuStartY := startY + len(title) + 1
-	for i, line := range menu {
-		color := termbox.ColorCyan
-		if i == 2 {
-			color = termbox.ColorYellow
-		}
-		if i == len(menu)-2 {
-			color = termbox.ColorYellow | termbox.AttrBold
-		}
-		for j, char := range line {
-			termbox.SetCell(startX+j, menuStartY+i, char, color, termbox.ColorDefault)
+	DrawBox(startX+2, startY+len(title)+1, menu, func(i int) term.Attribute {
+		switch i {
+		case 2, 3:
+			return term.ColorYellow
+		case len(menu) - 3:
+			return term.ColorYellow | term.AttrBold
 		}
+		return term.ColorCyan
+	})
+
+	if g.Recovery != nil {
+		g.DrawRecoveryDialog()
+	}
+	if g.CodeEntry.Active {
+		g.DrawCodeEntry()
 	}
 
-	termbox.Flush()
+	g.Flush()
 }
 
-func (g *Game) Draw() {
-	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+func (g *Game) DrawBorder(setCell CellSetter, color term.Attribute) {
+	left, right := g.Shrink.Rings, g.Width-1-g.Shrink.Rings
+	top, bottom := g.Shrink.Rings, g.Height-1-g.Shrink.Rings
 
-	for x := 0; x < g.Width; x++ {
-		termbox.SetCell(x, 0, '═', termbox.ColorWhite, termbox.ColorDefault)
-		termbox.SetCell(x, g.Height-1, '═', termbox.ColorWhite, termbox.ColorDefault)
+	for x := left; x <= right; x++ {
+		setCell(x, top, '═', color, term.ColorDefault)
+		setCell(x, bottom, '═', color, term.ColorDefault)
 	}
 
-	for y := 0; y < g.Height; y++ {
-		termbox.SetCell(0, y, '║', termbox.ColorWhite, termbox.ColorDefault)
-		termbox.SetCell(g.Width-1, y, '║', termbox.ColorWhite, termbox.ColorDefault)
+	for y := top; y <= bottom; y++ {
+		setCell(left, y, '║', color, term.ColorDefault)
+		setCell(right, y, '║', color, term.ColorDefault)
 	}
 
-	termbox.SetCell(0, 0, '╔', termbox.ColorWhite, termbox.ColorDefault)
-	termbox.SetCell(g.Width-1, 0, '╗', termbox.ColorWhite, termbox.ColorDefault)
-	termbox.SetCell(0, g.Height-1, '╚', termbox.ColorWhite, termbox.ColorDefault)
-	termbox.SetCell(g.Width-1, g.Height-1, '╝', termbox.ColorWhite, termbox.ColorDefault)
+	setCell(left, top, '╔', color, term.ColorDefault)
+	setCell(right, top, '╗', color, term.ColorDefault)
+	setCell(left, bottom, '╚', color, term.ColorDefault)
+	setCell(right, bottom, '╝', color, term.ColorDefault)
+}
 
-	for _, obs := range g.Obstacles {
-		termbox.SetCell(obs.X, obs.Y, '▓', termbox.ColorWhite, termbox.ColorDefault)
+func (g *Game) DrawBoard(setCell, boardSetter CellSetter) {
+	borderColor := g.Environment.BorderColor()
+	if g.BulletTime > 0 {
+		borderColor = term.ColorBlue | term.AttrBold
+	}
+	if g.FlashFrames > 0 && !g.ReducedEffects() {
+		borderColor = term.ColorRed | term.AttrBold
 	}
+	g.DrawAmbience(setCell)
+	g.DrawTrail(setCell)
+	g.DrawShrink(setCell)
+	g.DrawBorder(setCell, borderColor)
+	g.DrawRiskZones(setCell)
+
+	g.Environment.Draw(g, setCell)
+
+	for i := range g.Obstacles {
+		obs := &g.Obstacles[i]
+		if !obs.IsSolid() {
+			if !g.Blink(2) {
+				setCell(obs.Position.X, obs.Position.Y, '░', term.ColorYellow, term.ColorDefault)
+			}
+			continue
+		}
+		char, color := obstacleBehaviors[obs.Type].Render(g, obs)
+		setCell(obs.Position.X, obs.Position.Y, char, color, term.ColorDefault)
+	}
+	g.DrawProjectiles(setCell)
 
-	for i, chunk := range g.Snake.Body {
+	crossings := g.ZenCrossings()
+	for i, chunk := range g.Snake.Body.All() {
+		if i > 0 && g.Modifiers.Has(ModInvisibleTail) {
+			continue
+		}
 		char := '█'
-		color := termbox.ColorGreen
+		color := term.ColorGreen
+		if i > 0 && crossings[chunk] {
+			if chunk == g.Snake.Body.Head() {
+				continue
+			}
+			char = '▒'
+			color |= term.AttrDim
+		}
 
 		if i == 0 {
 			char = '●'
-			color = termbox.ColorYellow
+			color = term.ColorYellow
+			if g.FlashFrames > 0 && !g.ReducedEffects() {
+				color = term.ColorRed | term.AttrBold
+			}
 		}
 
-		termbox.SetCell(chunk.X, chunk.Y, char, color, termbox.ColorDefault)
+		if g.Snake.Invulnerable > 0 && g.Steady(2) {
+			color = term.ColorWhite
+		}
+
+		setCell(chunk.X, chunk.Y, char, color, term.ColorDefault)
+	}
+	g.DrawSmoothHead(setCell)
+	g.DrawTwin(setCell)
+	g.DrawBoss(setCell)
+
+	foodChar, foodColor := foodBehaviors[g.Food.Type].Render(g, &g.Food)
+	setCell(g.Food.Position.X, g.Food.Position.Y, foodChar, foodColor, term.ColorDefault)
+	g.DrawFoodGlow(boardSetter)
+	g.DrawFoodMarker(setCell)
+	g.DrawCollisionWarning(setCell)
+
+	g.DrawRivals(setCell)
+	g.DrawCoop(setCell)
+	g.DrawSandbox(setCell)
+	g.DrawAnnotations(boardSetter)
+	if !g.ReducedEffects() {
+		g.DrawPopups(setCell)
+	}
+	if g.Foggy() {
+		g.DrawFogHint(boardSetter)
 	}
+	if g.ShowHeatmap {
+		g.DrawHeatmap(setCell)
+	}
+}
 
-	foodChar := '◆'
-	foodColor := termbox.ColorRed
+func (g *Game) Draw() {
+	screen.Clear(term.ColorDefault, term.ColorDefault)
 
-	if g.Food.Type == PowerUpFood {
-		foodChar = '★'
-		foodColor = termbox.ColorYellow
-		if (g.FrameCount/5)%2 == 0 {
-			foodColor = termbox.ColorMagenta
-		}
+	g.UpdateCamera(g.FocusPoint())
+
+	boardSetter := g.BoardSetter(g.ShakeOffset())
+	setCell := boardSetter
+	if g.Foggy() {
+		setCell = g.FogSetter(boardSetter)
+	}
+	if g.IdlePaused {
+		setCell = g.DimSetter(setCell)
+	}
+
+	g.DrawBoard(setCell, boardSetter)
+	g.DrawTutorial()
+	g.DrawRandomEventBanner()
+
+	msg := fmt.Sprintf(" Pontos: %d | Recorde: %d | Nivel: %d %s| Tamanho: %d ",
+		g.Score, g.HighScore, g.Level, g.LevelBarHUD(), g.Snake.Body.Len())
+	if g.Mode == ModeBattle {
+		msg += fmt.Sprintf("| Rival: %d ", g.BestRivalScore())
 	}
+	if g.Theme != "normal" {
+		msg += fmt.Sprintf("| %s ", g.Environment.Name())
+	}
+	if g.SpeedOffset != 0 {
+		msg += fmt.Sprintf("| Vel: %+d (x%.2f) ", g.SpeedOffset, g.SpeedMultiplier())
+	}
+	if g.BulletTime > 0 {
+		msg += "| CAMERA LENTA "
+	}
+	if g.Undos > 0 {
+		msg += fmt.Sprintf("| Desfeitos: %d ", g.Undos)
+	}
+	if g.Mode == ModeSandbox && g.Sandbox.CollisionsOff {
+		msg += "| Colisoes: OFF "
+	}
+	if g.Mode == ModeLives {
+		msg += fmt.Sprintf("| Vidas: %s ", strings.Repeat("♥", g.Lives))
+	}
+	if g.Mode == ModeCoop && g.Coop.Down > 0 {
+		msg += fmt.Sprintf("| P%d caido: %ds ", g.Coop.Down, g.Coop.ReviveSecondsLeft())
+	}
+	if g.ShowHeatmap {
+		msg += fmt.Sprintf("| Mapa de mortes: %d ", len(g.Stats.Deaths))
+	}
+	if g.Modifiers != 0 {
+		msg += fmt.Sprintf("| Mods: %s ", g.Modifiers)
+	}
+	if g.IdlePaused {
+		msg += "| AUSENTE - pressione qualquer tecla "
+	} else if g.UserPaused {
+		msg += "| PAUSADO "
+	}
+	if g.Mode == ModeHunger {
+		msg += fmt.Sprintf("| Fome %s ", g.HungerBar())
+	}
+	if g.Decaying() {
+		msg += g.DecayHUD()
+	}
+	if g.IsSplit() {
+		msg += "| DIVIDIDA "
+	}
+	if g.Boss.Active {
+		msg += g.BossHUD()
+	}
+	if g.VenomMode() {
+		msg += g.VenomHUD()
+	}
+	if g.Mode == ModeShrink {
+		msg += g.ShrinkHUD()
+	}
+	if g.Mode == ModePuzzle {
+		msg += g.PuzzleHUD()
+	}
+	if g.Mode == ModeHardcore {
+		msg += g.HardcoreHUD()
+	}
+	if g.Modifiers.Has(ModDayNight) {
+		msg += g.DayNightHUD()
+	}
+	if g.InRiskZone(g.FocusPoint()) {
+		msg += fmt.Sprintf("| ZONA DE RISCO x%d ", RiskMultiplier)
+	}
+	if !g.VenomMode() && g.Remote == nil {
+		msg += fmt.Sprintf("| Folego %s ", g.StaminaBar())
+	}
+	if g.Boost.Active {
+		msg += "TURBO "
+	}
+	if g.Demo {
+		msg += "| DEMO - pressione qualquer tecla "
+	}
+	if twitch, ok := g.PlayerController.(*TwitchController); ok {
+		msg += fmt.Sprintf("| Chat: #%s ", twitch.Channel)
+	}
+	if g.Scripts.Active() {
+		msg += fmt.Sprintf("| Lua: %d ", len(g.Scripts.Mods))
+	}
+	msg += g.NetHUD()
+	if g.Mode == ModeZen {
+		msg = g.ZenHUD()
+	}
+	DrawText(g.Camera.OffsetX+2, g.Camera.OffsetY+g.Camera.ScreenHeight(), msg, term.ColorCyan, term.ColorDefault)
+	g.DrawChat(g.Camera.OffsetX+3, g.Camera.OffsetY+g.Camera.ScreenHeight()+1)
+	g.DrawAnnouncement()
 
-	termbox.SetCell(g.Food.Position.X, g.Food.Position.Y, foodChar, foodColor, termbox.ColorDefault)
+	g.DrawMinimap()
+	g.DrawToasts()
 
-	msg := fmt.Sprintf(" Pontos: %d | Recorde: %d | Nivel: %d | Tamanho: %d ",
-		g.Score, g.HighScore, g.Level, len(g.Snake.Body))
-	for i, char := range msg {
-		termbox.SetCell(i+2, g.Height, char, termbox.ColorCyan, termbox.ColorDefault)
+	if g.ShowHelp {
+		g.DrawHelp()
+	}
+	if g.ConfirmQuit {
+		g.DrawQuitDialog()
 	}
 
-	termbox.Flush()
+	g.Flush()
+}
+
+func (g *Game) Flush() {
+	if g.Debug.Show {
+		g.DrawDebugOverlay()
+	}
+	g.present(screen.CellBuffer(), screen.Flush)
+}
+
+func (g *Game) present(cells []term.Cell, flush func() error) {
+	if !g.Render.Frame.Changed(cells) {
+		g.SkipRender()
+		return
+	}
+	flush()
+	g.RecordRender()
+	if g.Recorder != nil {
+		g.Recorder.Capture()
+	}
 }
 
 func (g *Game) DrawGameOver() {
-	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+	screen.Clear(term.ColorDefault, term.ColorDefault)
 
 	isNewRecord := g.Score >= g.HighScore && g.Score > 0
 
-	var messages []string
-
+	messages := []string{"    GAME OVER!", ""}
 	if isNewRecord {
-		messages = []string{
-			"╔═══════════════════════════╗",
-			"║     GAME OVER!            ║",
-			"║                           ║",
-			"║  ★ NOVO RECORDE! ★        ║",
-			"║                           ║",
-			fmt.Sprintf("║  Pontos: %-16d║", g.Score),
-			fmt.Sprintf("║  Nivel: %-17d║", g.Level),
-			fmt.Sprintf("║  Tamanho: %-15d║", len(g.Snake.Body)),
-			"║                           ║",
-			"║  Pressione R - Reiniciar  ║",
-			"║  Pressione ESC - Sair     ║",
-			"╚═══════════════════════════╝",
-		}
+		messages = append(messages,
+			" ★ NOVO RECORDE! ★",
+			"",
+			fmt.Sprintf(" Pontos: %d", g.Score),
+		)
 	} else {
-		messages = []string{
-			"╔═════════╗",
-			"║     GAME OVER!            ║",
-			"║                           ║",
-			fmt.Sprintf("║  Pontos: %-16d ║", g.Score),
-			fmt.Sprintf("║  Recorde: %-15d ║", g.HighScore),
-			fmt.Sprintf("║  Nivel: %-17d ║", g.Level),
-			fmt.Sprintf("║  Tamanho: %-15d ║", len(g.Snake.Body)),
-			"║                           ║",
-			"║  Pressione R - Reiniciar  ║",
-			"║  Pressione ESC - Sair     ║",
-			"╚═════════╝",
+		messages = append(messages,
+			fmt.Sprintf(" Pontos: %d", g.Score),
+			fmt.Sprintf(" Recorde: %d", g.HighScore),
+		)
+	}
+	detail := " Desafio: " + g.ShareCode()
+	if g.Mode == ModePuzzle {
+		detail = " " + g.PuzzleResult()
+	}
+	if g.Mode == ModeHardcore && g.Hardcore.Replay != "" {
+		detail = " Replay: " + g.Hardcore.Replay
+	}
+	messages = append(messages,
+		fmt.Sprintf(" Nivel: %d", g.Level),
+		fmt.Sprintf(" Tamanho: %d", g.Snake.Body.Len()),
+		detail,
+		"",
+	)
+	if g.CanUndo() {
+		messages = append(messages, fmt.Sprintf(" U - Desfazer (-%d pts)", UndoPenalty))
+	}
+	if g.CanRestoreCheckpoint() {
+		messages = append(messages, fmt.Sprintf(" K - Voltar ao checkpoint (-%d pts)", CheckpointPenalty))
+	}
+	messages = append(messages,
+		" Pressione R - Reiniciar",
+		" Pressione E - Exportar",
+		" Pressione C - Copiar",
+		" Pressione M - Mortes",
+		" Pressione ESC - Sair",
+	)
+	box := BoxLines(messages, 25)
+
+	screenWidth, screenHeight := screen.Size()
+	startX := screenWidth/2 - BoxWidth(box)/2
+	startY := screenHeight/2 - len(box)/2
+
+	DrawBox(startX, startY, box, func(i int) term.Attribute {
+		if isNewRecord && i == 3 {
+			return term.ColorYellow
 		}
+		return term.ColorRed
+	})
+
+	DrawText(startX, startY+len(box)+1, g.StatusMsg, term.ColorCyan, term.ColorDefault)
+
+	g.Flush()
+}
+
+func (g *Game) DrawQuitDialog() {
+	lines := []string{
+		"  Sair? (S/N)",
+		fmt.Sprintf(" Pontos: %d", g.Score),
 	}
+	if g.Mode == ModeHardcore {
+		lines = append(lines, " O jogo continua!")
+	}
+	box := BoxLines(lines, 16)
+
+	centerX, centerY := g.Camera.Center()
+	startX := centerX - BoxWidth(box)/2
+	startY := centerY - len(box)/2
 
-	startX := g.Width/2 - 14
-	startY := g.Height/2 - len(messages)/2
+	DrawBox(startX, startY, box, func(int) term.Attribute { return term.ColorYellow })
+}
 
-	for i, msg := range messages {
-		color := termbox.ColorRed
-		if isNewRecord && (i == 3) {
-			color = termbox.ColorYellow
+func (g *Game) HandleInput(ev term.Event) bool {
+	g.MenuDirty = true
+	switch ev.Type {
+	case term.EventKey:
+		if g.NoteInput() {
+			return false
 		}
-		for j, char := range msg {
-			termbox.SetCell(startX+j, startY+i, char, color, termbox.ColorDefault)
+
+		if ev.Key == term.KeyF3 {
+			g.Debug.Show = !g.Debug.Show
+			return false
 		}
-	}
 
-	termbox.Flush()
-}
+		if g.State == StateEditor {
+			g.HandleEditorKey(ev)
+			return false
+		}
 
-func (g *Game) HandleInput(end chan bool) {
-	for {
-		switch ev := termbox.PollEvent(); ev.Type {
-		case termbox.EventKey:
-			if ev.Key == termbox.KeyEsc {
-				end <- true
-				return
+		if ev.Key == term.KeyCtrlC {
+			return true
+		}
+
+		if g.Chat.Typing {
+			g.HandleChatKey(ev)
+			return false
+		}
+
+		if g.State == StatePlaying && g.IsChatKey(ev) {
+			g.Chat.Typing = true
+			return false
+		}
+
+		if g.Demo {
+			g.StopDemo()
+			return false
+		}
+
+		if g.Recovery != nil {
+			switch {
+			case ev.Ch == 's' || ev.Ch == 'S':
+				g.ResumeRecovery()
+			case ev.Ch == 'n' || ev.Ch == 'N' || ev.Key == term.KeyEsc:
+				g.DiscardRecovery()
 			}
+			return false
+		}
 
-			if ev.Key == termbox.KeyEnter && g.State == StateMenu {
-				g.State = StatePlaying
+		if g.CodeEntry.Active {
+			g.HandleCodeEntryKey(ev)
+			return false
+		}
+
+		if g.ConfirmQuit {
+			switch {
+			case ev.Ch == 's' || ev.Ch == 'S':
+				g.CheckAndSaveHighScore()
+				g.CheckAndSaveBestLength()
+				return true
+			case ev.Ch == 'n' || ev.Ch == 'N' || ev.Key == term.KeyEsc:
+				g.ConfirmQuit = false
 			}
+			return false
+		}
 
-			if (ev.Ch == 'r' || ev.Ch == 'R') && g.State == StateGameOver {
-				g.Reset()
+		if g.State == StatePlaying && !g.Paused() && g.Mode == ModeSandbox && g.HandleSandboxKey(ev) {
+			return false
+		}
+
+		if g.State == StatePlaying && !g.Paused() {
+			if direction, ok := g.Bindings().Direction(ev); ok {
+				g.RecordInput()
+				g.SteerPlayer(direction)
+				g.RequestStep(direction)
+				return false
 			}
+			if turn, ok := g.Bindings().Turn(ev); ok {
+				g.RecordInput()
+				if g.PressTurn(turn) {
+					direction := g.Snake.Direction.Rotate(turn)
+					g.SteerPlayer(direction)
+					g.RequestStep(direction)
+				}
+				return false
+			}
+		}
 
+		if g.State == StatePlaying && g.IsHelpKey(ev) {
+			g.ToggleHelp()
+			return false
+		}
+
+		if g.State == StatePlaying && (ev.Ch == 'p' || ev.Ch == 'P') {
+			g.TogglePause()
+			return false
+		}
+
+		if g.ShowHelp {
+			if ev.Key == term.KeyEsc {
+				g.ShowHelp = false
+			}
+			return false
+		}
+
+		if ev.Key == term.KeyEsc {
+			if g.State == StatePlaying && g.Mode == ModeZen {
+				g.LeaveZen()
+				return false
+			}
 			if g.State == StatePlaying {
-				switch ev.Key {
-				case termbox.KeyArrowUp:
-					if g.Snake.Direction != "down" {
-						g.Snake.Direction = "up"
-					}
-				case termbox.KeyArrowDown:
-					if g.Snake.Direction != "up" {
-						g.Snake.Direction = "down"
-					}
-				case termbox.KeyArrowLeft:
-					if g.Snake.Direction != "right" {
-						g.Snake.Direction = "left"
-					}
-				case termbox.KeyArrowRight:
-					if g.Snake.Direction != "left" {
-						g.Snake.Direction = "right"
-					}
-				}
+				g.ConfirmQuit = true
+				return false
+			}
+			return true
+		}
+
+		if ev.Key == term.KeyEnter && g.State == StateMenu {
+			g.Reset()
+		}
+
+		if (ev.Ch == 'e' || ev.Ch == 'E') && g.State == StateMenu {
+			g.OpenEditor()
+			return false
+		}
+
+		if (ev.Ch == 'c' || ev.Ch == 'C') && g.State == StateMenu {
+			g.OpenCodeEntry()
+			return false
+		}
+
+		if g.State == StateMenu && g.ToggleModifier(ev.Ch) {
+			return false
+		}
+
+		if g.State == StateMenu {
+			switch ev.Key {
+			case term.KeyArrowLeft:
+				g.SelectMode(GameMode((int(g.Mode) + len(modeNames) - 1) % len(modeNames)))
+			case term.KeyArrowRight:
+				g.SelectMode(GameMode((int(g.Mode) + 1) % len(modeNames)))
+			}
+		}
+
+		if (ev.Ch == 'r' || ev.Ch == 'R') && g.State == StateGameOver {
+			g.Reset()
+		}
+
+		if g.ShowSummary && g.State == StateGameOver {
+			if ev.Key == term.KeyEnter {
+				g.ShowSummary = false
+			}
+			return false
+		}
+
+		if (ev.Ch == 'm' || ev.Ch == 'M') && (g.State == StatePlaying || g.State == StateGameOver) {
+			g.ShowHeatmap = !g.ShowHeatmap
+		}
+
+		if (ev.Ch == 'u' || ev.Ch == 'U') && g.CanUndo() {
+			g.Undo()
+		}
+
+		if (ev.Ch == 'k' || ev.Ch == 'K') && g.CanRestoreCheckpoint() {
+			g.RestoreCheckpoint()
+		}
+
+		if (ev.Ch == 'e' || ev.Ch == 'E') && g.State == StateGameOver {
+			if path, err := g.ExportScoreCard(); err != nil {
+				g.StatusMsg = "Erro ao exportar: " + err.Error()
+			} else {
+				g.StatusMsg = "Score card salvo em " + path
 			}
 		}
+
+		if (ev.Ch == 'c' || ev.Ch == 'C') && g.State == StateGameOver {
+			if err := CopyToClipboard(g.ScoreSummary()); err != nil {
+				g.StatusMsg = "Erro ao copiar: " + err.Error()
+			} else {
+				g.StatusMsg = "Resumo copiado!"
+			}
+		}
+
+		if g.State == StatePlaying {
+			g.RecordInput()
+
+			if g.Mode == ModeCoop {
+				g.HandlePartnerInput(ev.Ch)
+			}
+
+			if matchesKey(eventRune(ev), g.Settings.BoostKey) {
+				g.HoldBoost()
+			}
+			if matchesKey(eventRune(ev), g.Settings.VenomKey) {
+				g.FireVenom()
+			}
+
+			if matchesKey(ev.Ch, g.Settings.SpeedUpKey) {
+				g.AdjustSpeed(1)
+			}
+			if matchesKey(ev.Ch, g.Settings.SpeedDownKey) {
+				g.AdjustSpeed(-1)
+			}
+		}
+	case term.EventMouse:
+		if ev.Mod&term.ModMotion == 0 && g.NoteInput() {
+			return false
+		}
+		if g.State == StatePlaying && g.Mode == ModeSandbox && ev.Mod&term.ModMotion == 0 {
+			g.HandleSandboxMouse(ev)
+		} else if g.State == StatePlaying && g.Settings.MouseSteering && !g.Paused() {
+			g.HandleSteeringMouse(ev)
+		}
+		if g.State == StateEditor && ev.Mod&term.ModMotion == 0 {
+			g.HandleEditorMouse(ev)
+		}
 	}
+	return false
 }
 
-func main() {
+type Options struct {
+	Width     int
+	Height    int
+	Record    string
+	Lives     int
+	Theme     string
+	Layout    *Layout
+	Debug     bool
+	PprofAddr string
+	Twitch    string
+	Gamepad   string
+	Square    bool
+	Renderer  string
+
+	Mode         GameMode
+	StartPlaying bool
+	Editor       bool
+	Demo         string
+	RivalAI      string
+	Code         *ChallengeCode
+	Announce     string
+	Serve        string
+}
+
+func NewSession(opts Options) (game *Game, closeSession func(), err error) {
+	var closers []func()
+	cleanup := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+	defer func() {
+		if err != nil {
+			cleanup()
+		}
+	}()
+
 	initSound()
+	closers = append(closers, closeSound)
+
+	game = NewGame(opts.Width, opts.Height, opts.Theme, opts.Layout)
+	game.MaxLives = max(1, opts.Lives)
+	game.Settings = LoadSettings()
+	if pack, err := LoadSoundPack(game.Settings.SoundPack); err != nil {
+		logger.Error("falha ao carregar pacote de sons, usando o padrao", "erro", err)
+	} else {
+		soundPack = pack
+	}
+	soundSamples = LoadSamples(game.Settings.SampleDir)
+	glyphFallback = game.Settings.ASCIIGlyphs || NeedsGlyphFallback()
+	if opts.Square {
+		game.Settings.SquareCells = true
+	}
+	game.Renderer = opts.Renderer
+	if opts.RivalAI != "" {
+		game.Settings.RivalAI = opts.RivalAI
+	}
+	game.Stats = LoadStats()
+
+	if game.Settings.DiscordPresence {
+		if client, err := ConnectDiscord(game.Settings.DiscordClientID); err != nil {
+			logger.Warn("discord indisponivel", "erro", err)
+		} else {
+			closers = append(closers, func() { client.Close() })
+			game.StartPresence(client)
+		}
+	}
+	switch {
+	case opts.Editor:
+		game.OpenEditor()
+	case opts.Code != nil:
+		game.StartChallenge(*opts.Code)
+	case opts.Demo != "":
+		controller, err := NewController(opts.Demo)
+		if err != nil {
+			return nil, nil, err
+		}
+		game.SelectMode(opts.Mode)
+		game.StartDemo(controller)
+	case opts.StartPlaying:
+		game.SelectMode(opts.Mode)
+		game.Reset()
+	case game.HasRecovery():
+	case !game.Settings.TutorialDone:
+		game.Mode = ModeTutorial
+		game.Reset()
+	}
+	game.SubscribeSounds()
+	game.SubscribeMusic()
+	if opts.Announce != "" {
+		out, err := OpenAnnouncements(opts.Announce)
+		if err != nil {
+			return nil, nil, err
+		}
+		closers = append(closers, func() { out.Close() })
+		game.Announcer.Out = out
+	}
+	game.SubscribeAnnouncements()
+	if game.Settings.LuaMods {
+		game.LoadMods(ModsDir)
+		closers = append(closers, game.CloseMods)
+		game.SubscribeMods()
+	}
+	closers = append(closers, game.Music.Stop, game.CloseHardcoreReplay)
+	SubscribeLogging(game.Events)
+
+	if opts.Twitch != "" {
+		twitch, err := ConnectTwitch(opts.Twitch)
+		if err != nil {
+			return nil, nil, err
+		}
+		closers = append(closers, func() { twitch.Close() })
+		game.PlayerController = twitch
+	}
+	return game, cleanup, nil
+}
+
+func (g *Game) SmoothFrame() {
+	if g.SmoothRender() && g.State == StatePlaying && !g.Paused() {
+		g.BeginRender()
+		g.Draw()
+	}
+}
+
+func (g *Game) Step() {
+	if g.State == StatePlaying && !g.Paused() {
+		g.MoveSnake()
+		g.RecoveryDirty = true
+		g.BeginRender()
+		g.Draw()
+		g.PublishSnapshot()
+	}
+}
+
+func (g *Game) Tick() {
+	tickStart := time.Now()
+
+	g.FrameCount++
+	g.UpdateEffects()
+	g.BeginRender()
+
+	switch g.State {
+	case StateEditor:
+		g.DrawEditor()
+	case StatePlaying:
+		if !g.Paused() && !g.TurnBased() {
+			g.MoveSnake()
+			g.LastTick = time.Now()
+			g.RecoveryDirty = true
+		}
+		g.Autosave()
+		g.CheckIdle()
+		g.BeginRender()
+		g.Draw()
+	case StateGameOver:
+		g.UpdateDemo()
+		if g.ShakeFrames > 0 || g.ShowHeatmap {
+			g.Draw()
+		} else if g.ShowSummary {
+			g.DrawSummary()
+		} else {
+			g.DrawGameOver()
+		}
+	}
 
-	if err := termbox.Init(); err != nil {
-		panic(err)
+	g.PublishSnapshot()
+	g.RecordTick(tickStart)
+}
+
+func Run(opts Options) (err error) {
+	if opts.Debug {
+		logFile, err := SetupDebugLog()
+		if err != nil {
+			return err
+		}
+		defer logFile.Close()
+		logger.Info("jogo iniciado", "largura", opts.Width, "altura", opts.Height, "tema", opts.Theme)
+	}
+
+	if opts.PprofAddr != "" {
+		StartProfiler(opts.PprofAddr)
+	}
+
+	if err := term.Init(); err != nil {
+		return err
 	}
-	defer termbox.Close()
+	defer term.Close()
 
-	game := NewGame()
-	end := make(chan bool)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+			logger.Error("panic", "erro", err)
+		}
+	}()
 
-	go game.HandleInput(end)
+	term.SetInputMode(term.InputEsc | term.InputMouse)
 
-	ticker := time.NewTicker(game.Speed)
-	defer ticker.Stop()
+	game, closeSession, err := NewSession(opts)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
 
-	lastSpeed := game.Speed
+	actions := make(chan func(), 8)
+
+	if opts.Gamepad != "" {
+		gamepad, err := OpenGamepad(opts.Gamepad, game.Settings.GamepadStartButton, func() {
+			select {
+			case actions <- game.TogglePause:
+			default:
+			}
+		})
+		if err != nil {
+			return err
+		}
+		defer gamepad.Close()
+		game.PlayerController = gamepad
+	}
+
+	if opts.Record != "" {
+		screenWidth, screenHeight := screen.Size()
+		recorder, err := NewRecorder(opts.Record, screenWidth, screenHeight)
+		if err != nil {
+			return err
+		}
+		defer recorder.Close()
+		game.Recorder = recorder
+		game.Events.Subscribe(EventDeath, func(e Event) {
+			recorder.Mark(deathMarker)
+		})
+	}
+
+	if opts.Serve != "" {
+		server, err := ServeMatch(game, opts.Serve, actions)
+		if err != nil {
+			return err
+		}
+		defer server.Close()
+		game.Server = server
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	inputs := make(chan term.Event, 16)
+	go func() {
+		for {
+			inputs <- term.PollEvent()
+		}
+	}()
+
+	return game.Loop(inputs, actions, signals)
+}
+
+func (g *Game) Loop(inputs <-chan term.Event, actions <-chan func(), signals <-chan os.Signal) error {
+	ticker := time.NewTicker(g.TickInterval())
+	defer func() { ticker.Stop() }()
+
+	lastSpeed := g.TickInterval()
+
+	frames := time.NewTicker(SmoothFrameInterval)
+	defer frames.Stop()
+
+	menuFrames := time.NewTicker(MenuFrameInterval)
+	defer menuFrames.Stop()
 
 	for {
 		select {
-		case <-end:
-			speaker.Close()
-			return
+		case ev := <-inputs:
+			if g.HandleInput(ev) {
+				RemoveRecovery()
+				return nil
+			}
+		case action := <-actions:
+			action()
+		case sig := <-signals:
+			logger.Info("sinal recebido", "sinal", sig.String())
+			return nil
+		case <-frames.C:
+			g.SmoothFrame()
+		case <-g.Steps:
+			g.Step()
+		case <-menuFrames.C:
+			g.BeginRender()
+			g.TickMenu()
 		case <-ticker.C:
-			if game.Speed != lastSpeed {
+			if g.TickInterval() != lastSpeed {
+				logger.Debug("reiniciando ticker", "de_ms", lastSpeed.Milliseconds(),
+					"para_ms", g.TickInterval().Milliseconds())
 				ticker.Stop()
-				ticker = time.NewTicker(game.Speed)
-				lastSpeed = game.Speed
-			}
-
-			game.FrameCount++
-
-			switch game.State {
-			case StateMenu:
-				game.DrawMenu()
-			case StatePlaying:
-				game.MoveSnake()
-				game.Draw()
-			case StateGameOver:
-				game.DrawGameOver()
+				ticker = time.NewTicker(g.TickInterval())
+				lastSpeed = g.TickInterval()
 			}
+			g.Tick()
 		}
 	}
 }
+
+func main() {
+	if err := RunCommand(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
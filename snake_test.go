@@ -0,0 +1,230 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestGame(t testing.TB, mode GameMode) *Game {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	return NewHeadlessGame(mode, 1)
+}
+
+func clearBoard(g *Game) {
+	g.Obstacles = []Obstacle{}
+	g.Food = Food{Position: Point{X: g.Width - 3, Y: g.Height - 3}}
+	g.RebuildOccupancy()
+}
+
+func placeSnake(g *Game, direction Direction, body ...Point) {
+	g.Snake = Snake{Body: NewSnakeBody(body), Direction: direction}
+	g.RebuildOccupancy()
+}
+
+func TestUpdateLevel(t *testing.T) {
+	tests := []struct {
+		mode  GameMode
+		score int
+		level int
+	}{
+		{ModeClassic, 0, 1},
+		{ModeClassic, 49, 1},
+		{ModeClassic, 50, 2},
+		{ModeClassic, 120, 3},
+		{ModeClassic, 1000, 21},
+		{ModeZen, 500, 1},
+	}
+
+	for _, tt := range tests {
+		g := newTestGame(t, tt.mode)
+		g.Score = tt.score
+		g.UpdateLevel()
+		if g.Level != tt.level {
+			t.Errorf("%s com %d pontos: nivel %d, quer %d", tt.mode, tt.score, g.Level, tt.level)
+		}
+	}
+}
+
+func TestBaseSpeed(t *testing.T) {
+	tests := []struct {
+		level int
+		speed time.Duration
+	}{
+		{1, 150 * time.Millisecond},
+		{5, 110 * time.Millisecond},
+		{11, 50 * time.Millisecond},
+		{30, 50 * time.Millisecond},
+	}
+
+	g := newTestGame(t, ModeClassic)
+	for _, tt := range tests {
+		g.Level = tt.level
+		if got := g.BaseSpeed(); got != tt.speed {
+			t.Errorf("nivel %d: velocidade %v, quer %v", tt.level, got, tt.speed)
+		}
+	}
+}
+
+func TestLevelProgress(t *testing.T) {
+	tests := []struct {
+		level    int
+		score    int
+		progress float64
+	}{
+		{1, 0, 0},
+		{1, 25, 0.5},
+		{2, 60, 0.2},
+		{2, 200, 1},
+	}
+
+	g := newTestGame(t, ModeClassic)
+	for _, tt := range tests {
+		g.Level, g.Score = tt.level, tt.score
+		if got := g.LevelProgress(); got != tt.progress {
+			t.Errorf("nivel %d com %d pontos: progresso %v, quer %v", tt.level, tt.score, got, tt.progress)
+		}
+	}
+}
+
+func TestFoodPoints(t *testing.T) {
+	g := newTestGame(t, ModeClassic)
+	far := g.Width + g.Height
+
+	tests := []struct {
+		name     string
+		distance int
+		ticks    int
+		points   int
+	}{
+		{"sem distancia", 0, 5, 10},
+		{"longe e rapido", far, far, 30},
+		{"longe e devagar", far, far * 2, 20},
+		{"perto e rapido", far / 4, far / 4, 15},
+	}
+
+	for _, tt := range tests {
+		g.PlayTicks = 100 + tt.ticks
+		food := Food{SpawnDistance: tt.distance, SpawnTick: 100}
+		if got := g.FoodPoints(10, &food); got != tt.points {
+			t.Errorf("%s: %d pontos, quer %d", tt.name, got, tt.points)
+		}
+	}
+}
+
+func TestAddScore(t *testing.T) {
+	tests := []struct {
+		offset int
+		points int
+		score  int
+	}{
+		{0, 10, 10},
+		{2, 10, 15},
+		{-2, 10, 5},
+		{0, 50, 50},
+	}
+
+	for _, tt := range tests {
+		g := newTestGame(t, ModeClassic)
+		g.SpeedOffset = tt.offset
+		g.AddScore(tt.points, g.Snake.Body.Head(), NormalFood)
+		if g.Score != tt.score {
+			t.Errorf("velocidade %+d, %d pontos: placar %d, quer %d", tt.offset, tt.points, g.Score, tt.score)
+		}
+	}
+}
+
+func TestCheckWallCollision(t *testing.T) {
+	tests := []struct {
+		point Point
+		rings int
+		hit   bool
+	}{
+		{Point{X: 0, Y: 5}, 0, true},
+		{Point{X: DefaultWidth - 1, Y: 5}, 0, true},
+		{Point{X: 5, Y: 0}, 0, true},
+		{Point{X: 5, Y: DefaultHeight - 1}, 0, true},
+		{Point{X: 1, Y: 1}, 0, false},
+		{Point{X: DefaultWidth - 2, Y: DefaultHeight - 2}, 0, false},
+		{Point{X: 1, Y: 1}, 1, true},
+		{Point{X: 2, Y: 2}, 1, false},
+	}
+
+	g := newTestGame(t, ModeClassic)
+	for _, tt := range tests {
+		g.Shrink.Rings = tt.rings
+		if got := g.CheckWallCollision(tt.point); got != tt.hit {
+			t.Errorf("(%d,%d) com %d aneis: colisao %v, quer %v", tt.point.X, tt.point.Y, tt.rings, got, tt.hit)
+		}
+	}
+}
+
+func TestStepPlayerCollisions(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     []Point
+		obstacle *Point
+		alive    bool
+	}{
+		{"caminho livre", []Point{{X: 10, Y: 10}, {X: 9, Y: 10}, {X: 8, Y: 10}}, nil, true},
+		{"parede", []Point{{X: DefaultWidth - 2, Y: 10}, {X: DefaultWidth - 3, Y: 10}, {X: DefaultWidth - 4, Y: 10}}, nil, false},
+		{"obstaculo", []Point{{X: 10, Y: 10}, {X: 9, Y: 10}, {X: 8, Y: 10}}, &Point{X: 11, Y: 10}, false},
+		{"proprio corpo", []Point{{X: 10, Y: 10}, {X: 10, Y: 11}, {X: 11, Y: 11}, {X: 11, Y: 10}, {X: 11, Y: 9}}, nil, false},
+	}
+
+	for _, tt := range tests {
+		g := newTestGame(t, ModeClassic)
+		clearBoard(g)
+		if tt.obstacle != nil {
+			g.Obstacles = append(g.Obstacles, Obstacle{Position: *tt.obstacle, Type: WallObstacle})
+		}
+		placeSnake(g, DirRight, tt.body...)
+
+		if _, alive := g.StepPlayer(&g.Snake); alive != tt.alive {
+			t.Errorf("%s: viva %v, quer %v", tt.name, alive, tt.alive)
+		}
+	}
+}
+
+func TestStepIntoVacatingTail(t *testing.T) {
+	loop := []Point{{X: 10, Y: 10}, {X: 10, Y: 11}, {X: 11, Y: 11}, {X: 11, Y: 10}}
+	tail := Point{X: 11, Y: 10}
+
+	tests := []struct {
+		name   string
+		body   []Point
+		food   bool
+		pellet bool
+		alive  bool
+		length int
+	}{
+		{"cauda sai no mesmo passo", loop, false, false, true, 4},
+		{"cresce com comida na cauda", loop, true, false, false, 4},
+		{"cresce com bolinha na cauda", loop, false, true, false, 4},
+		{"cobra de dois segmentos", []Point{{X: 10, Y: 10}, tail}, false, false, false, 2},
+	}
+
+	for _, tt := range tests {
+		g := newTestGame(t, ModeClassic)
+		clearBoard(g)
+		if tt.food {
+			g.Food = Food{Position: tail}
+		}
+		if tt.pellet {
+			g.Pellets = []Food{{Position: tail, Type: PelletFood}}
+		}
+		placeSnake(g, DirRight, tt.body...)
+
+		head, alive := g.StepPlayer(&g.Snake)
+		if alive != tt.alive {
+			t.Errorf("%s: viva %v, quer %v", tt.name, alive, tt.alive)
+			continue
+		}
+		if g.Snake.Body.Len() != tt.length {
+			t.Errorf("%s: tamanho %d, quer %d", tt.name, g.Snake.Body.Len(), tt.length)
+		}
+		if alive && (head != tail || !g.CheckSelfCollision(tail)) {
+			t.Errorf("%s: cabeca em (%d,%d) fora da ocupacao", tt.name, head.X, head.Y)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed assets/sounds/default.json
+var defaultSoundPack []byte
+
+var waveforms = map[string]func(phase float64) float64{
+	"sine":     func(phase float64) float64 { return math.Sin(2 * math.Pi * phase) },
+	"square":   func(phase float64) float64 { return 1 - 2*math.Floor(2*phase) },
+	"triangle": func(phase float64) float64 { return 4*math.Abs(phase-0.5) - 1 },
+	"sawtooth": func(phase float64) float64 { return 2*phase - 1 },
+}
+
+type Note struct {
+	Freq      float64 `json:"freq"`
+	Ms        int     `json:"ms"`
+	WaitMs    int     `json:"wait_ms,omitempty"`
+	Waveform  string  `json:"waveform,omitempty"`
+	AttackMs  int     `json:"attack_ms,omitempty"`
+	ReleaseMs int     `json:"release_ms,omitempty"`
+	Volume    float64 `json:"volume,omitempty"`
+}
+
+type SoundPack map[string][]Note
+
+var soundPack = mustParseSoundPack(defaultSoundPack)
+
+func (n Note) Duration() time.Duration {
+	return time.Duration(n.Ms) * time.Millisecond
+}
+
+func (n Note) Wait() time.Duration {
+	if n.WaitMs > 0 {
+		return time.Duration(n.WaitMs) * time.Millisecond
+	}
+	return n.Duration()
+}
+
+func (n Note) Wave() func(phase float64) float64 {
+	if wave, ok := waveforms[n.Waveform]; ok {
+		return wave
+	}
+	return waveforms["sine"]
+}
+
+func (n Note) Gain() float64 {
+	if n.Volume > 0 {
+		return n.Volume
+	}
+	return 1
+}
+
+func (n Note) Validate() error {
+	switch {
+	case n.Freq <= 0 || n.Freq > 20000:
+		return fmt.Errorf("freq fora de 1-20000: %v", n.Freq)
+	case n.Ms <= 0 || n.Ms > 5000:
+		return fmt.Errorf("ms fora de 1-5000: %d", n.Ms)
+	case n.WaitMs < 0 || n.AttackMs < 0 || n.ReleaseMs < 0:
+		return fmt.Errorf("wait_ms, attack_ms e release_ms nao podem ser negativos")
+	case n.Volume < 0 || n.Volume > 1:
+		return fmt.Errorf("volume fora de 0-1: %v", n.Volume)
+	case n.Waveform != "" && waveforms[n.Waveform] == nil:
+		return fmt.Errorf("waveform desconhecida: %s (use %s)", n.Waveform, strings.Join(WaveformNames(), ", "))
+	}
+	return nil
+}
+
+func WaveformNames() []string {
+	var names []string
+	for name := range waveforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func parseSoundPack(data []byte) (SoundPack, error) {
+	var pack SoundPack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return nil, err
+	}
+	for name, notes := range pack {
+		for i, note := range notes {
+			if err := note.Validate(); err != nil {
+				return nil, fmt.Errorf("%s, nota %d: %w", name, i+1, err)
+			}
+		}
+	}
+	return pack, nil
+}
+
+func mustParseSoundPack(data []byte) SoundPack {
+	pack, err := parseSoundPack(data)
+	if err != nil {
+		panic("pacote de sons embutido invalido: " + err.Error())
+	}
+	return pack
+}
+
+func LoadSoundPack(path string) (SoundPack, error) {
+	pack := mustParseSoundPack(defaultSoundPack)
+	if path == "" {
+		return pack, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return pack, err
+	}
+	custom, err := parseSoundPack(data)
+	if err != nil {
+		return pack, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for name, notes := range custom {
+		if _, ok := pack[name]; !ok {
+			return pack, fmt.Errorf("%s: efeito desconhecido: %s", path, name)
+		}
+		pack[name] = notes
+	}
+	return pack, nil
+}
+
+func PlayEffect(name string) {
+	PlayEffectAt(name, 0)
+}
+
+func PlayEffectAt(name string, pan float64) {
+	if playSample(name, pan) {
+		return
+	}
+
+	notes := soundPack[name]
+	if len(notes) == 0 {
+		return
+	}
+
+	go func() {
+		for i, note := range notes {
+			playTone(note, pan)
+			if i < len(notes)-1 {
+				time.Sleep(note.Wait())
+			}
+		}
+	}()
+}
+
+func runSoundPack(args []string) error {
+	fs := flag.NewFlagSet("soundpack", flag.ExitOnError)
+	check := fs.String("check", "", "valida um pacote de sons em vez de mostrar o padrao")
+	samples := fs.String("samples", "", "valida as amostras WAV/OGG de uma pasta")
+	fs.Parse(args)
+
+	if *samples != "" {
+		count, err := CheckSamples(*samples)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d amostras validas\n", count)
+		return nil
+	}
+
+	if *check != "" {
+		if _, err := LoadSoundPack(*check); err != nil {
+			return err
+		}
+		fmt.Println("pacote de sons valido")
+		return nil
+	}
+
+	_, err := os.Stdout.Write(defaultSoundPack)
+	return err
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	MaxSoundPan       = 0.8
+	HeartbeatRange    = 2
+	HeartbeatInterval = 700 * time.Millisecond
+)
+
+func (g *Game) SoundPan(p Point) float64 {
+	if g.Width < 2 {
+		return 0
+	}
+	dx := p.X - g.FocusPoint().X
+	if dx == 0 {
+		dx = p.X - g.Width/2
+	}
+	pan := float64(dx) / float64(g.Width/2) * MaxSoundPan
+	return math.Max(-MaxSoundPan, math.Min(MaxSoundPan, pan))
+}
+
+func (g *Game) DangerDistance(s *Snake) int {
+	if s.Body.Len() == 0 || s.Invulnerable > 0 || g.CollisionsDisabled() {
+		return 0
+	}
+	p := s.Body.Head()
+	for distance := 1; distance <= HeartbeatRange; distance++ {
+		p = g.WrapWalls(p.Move(s.Direction))
+		if g.IsDeadly(p) {
+			return distance
+		}
+	}
+	return 0
+}
+
+func (g *Game) NearestDanger() int {
+	nearest := 0
+	for _, s := range g.PlayerSnakes() {
+		if d := g.DangerDistance(s); d > 0 && (nearest == 0 || d < nearest) {
+			nearest = d
+		}
+	}
+	return nearest
+}
+
+func (g *Game) SubscribeHeartbeat() {
+	var lastBeat time.Time
+	g.Events.Subscribe(EventTick, func(e Event) {
+		if !soundInitialized || g.State != StatePlaying {
+			return
+		}
+		distance := g.NearestDanger()
+		if distance == 0 {
+			return
+		}
+		interval := HeartbeatInterval * time.Duration(distance) / HeartbeatRange
+		if time.Since(lastBeat) < interval {
+			return
+		}
+		lastBeat = time.Now()
+		PlayEffect("heartbeat")
+	})
+}
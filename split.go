@@ -0,0 +1,88 @@
+package main
+
+import (
+	"slices"
+
+	"snake/term"
+)
+
+const (
+	SplitFood      FoodType = 102
+	MinSplitLength          = 4
+)
+
+type SplitFoodBehavior struct{}
+
+func (SplitFoodBehavior) Weight() int { return 5 }
+
+func (SplitFoodBehavior) OnSpawn(g *Game, f *Food) {}
+
+func (SplitFoodBehavior) OnEaten(g *Game, f *Food) int {
+	g.Split()
+	return 30
+}
+
+func (SplitFoodBehavior) Tick(g *Game, f *Food) {}
+
+func (SplitFoodBehavior) Render(g *Game, f *Food) (rune, term.Attribute) {
+	return '¤', term.ColorCyan | term.AttrBold
+}
+
+func init() {
+	RegisterFood(SplitFood, SplitFoodBehavior{})
+	foodNames[SplitFood] = "Divisao"
+}
+
+func (g *Game) IsSplit() bool {
+	return g.Twin.Body.Len() > 0
+}
+
+func (g *Game) Split() {
+	if g.IsSplit() || g.Mode == ModeCoop || g.Snake.Body.Len() < MinSplitLength {
+		return
+	}
+
+	points := g.Snake.Body.Points()
+	cut := (len(points) + 1) / 2
+	back := slices.Clone(points[cut:])
+	slices.Reverse(back)
+
+	g.Snake.Body = NewSnakeBody(points[:cut])
+	g.Twin = Snake{Body: NewSnakeBody(back), Direction: DirectionBetween(back[1], back[0])}
+	g.Twin.Moved = g.Twin.Direction
+	g.Emit(Event{Type: EventPowerUpActivated, Position: points[0], Food: SplitFood})
+}
+
+func (g *Game) Unsplit() {
+	g.Occupancy.RemoveBody(LayerPlayer, &g.Twin.Body)
+	g.Twin = Snake{}
+}
+
+func DirectionBetween(from, to Point) Direction {
+	for _, direction := range Directions {
+		if from.Move(direction) == to {
+			return direction
+		}
+	}
+	return DirNone
+}
+
+func (g *Game) StepTwin() (Point, bool) {
+	if mirrored := g.Snake.Direction.Opposite(); mirrored != g.Twin.Direction.Opposite() {
+		g.Twin.Direction = mirrored
+	}
+	return g.StepPlayer(&g.Twin)
+}
+
+func (g *Game) DrawTwin(setCell CellSetter) {
+	for i, chunk := range g.Twin.Body.All() {
+		if i > 0 && g.Modifiers.Has(ModInvisibleTail) {
+			continue
+		}
+		char, color := '█', term.ColorGreen
+		if i == 0 {
+			char, color = '●', term.ColorCyan
+		}
+		setCell(chunk.X, chunk.Y, char, color, term.ColorDefault)
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+var squareGlyphs = map[rune][2]rune{
+	'●': {'▐', '▌'},
+	'═': {'═', '═'},
+	'╔': {'╔', '═'},
+	'╚': {'╚', '═'},
+	'█': {'█', '█'},
+	'▓': {'▓', '▓'},
+	'░': {'░', '░'},
+	'▒': {'▒', '▒'},
+	'≈': {'≈', '≈'},
+}
+
+func SquareGlyph(ch rune) (rune, rune) {
+	if pair, ok := squareGlyphs[ch]; ok {
+		return pair[0], pair[1]
+	}
+	return ch, ' '
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"snake/term"
+)
+
+const (
+	statsFile       = "stats.json"
+	MaxDeathRecords = 1000
+)
+
+type Stats struct {
+	Deaths []Point `json:"deaths"`
+}
+
+var heatmapShades = []struct {
+	Char  rune
+	Color term.Attribute
+}{
+	{'░', term.ColorBlue},
+	{'▒', term.ColorCyan},
+	{'▓', term.ColorYellow},
+	{'█', term.ColorRed},
+}
+
+func LoadStats() Stats {
+	var stats Stats
+
+	data, err := statsFormat.Read(statsFile)
+	if os.IsNotExist(err) {
+		return stats
+	}
+
+	if err == nil {
+		err = json.Unmarshal(data, &stats)
+	}
+	if err != nil {
+		SetAsideBroken(statsFile, err)
+		return Stats{}
+	}
+
+	return stats
+}
+
+func SaveStats(stats Stats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	return statsFormat.Write(statsFile, data)
+}
+
+func (s *Stats) RecordDeath(p Point) {
+	s.Deaths = append(s.Deaths, p)
+	if len(s.Deaths) > MaxDeathRecords {
+		s.Deaths = s.Deaths[len(s.Deaths)-MaxDeathRecords:]
+	}
+}
+
+func (g *Game) SubscribeStats() {
+	g.Events.Subscribe(EventDeath, func(e Event) {
+		g.Stats.RecordDeath(e.Position)
+		if !g.Persists() {
+			return
+		}
+		if err := SaveStats(g.Stats); err != nil {
+			logger.Error("falha ao salvar estatisticas", "erro", err)
+		}
+	})
+}
+
+func (g *Game) DeathHeatmap() (map[Point]int, int) {
+	counts := map[Point]int{}
+	peak := 0
+	for _, p := range g.Stats.Deaths {
+		if p.X < 0 || p.X >= g.Width || p.Y < 0 || p.Y >= g.Height {
+			continue
+		}
+		counts[p]++
+		peak = max(peak, counts[p])
+	}
+	return counts, peak
+}
+
+func (g *Game) DrawHeatmap(setCell CellSetter) {
+	counts, peak := g.DeathHeatmap()
+	for p, count := range counts {
+		shade := heatmapShades[(count-1)*len(heatmapShades)/peak]
+		setCell(p.X, p.Y, shade.Char, shade.Color, term.ColorDefault)
+	}
+}
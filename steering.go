@@ -0,0 +1,41 @@
+package main
+
+import "snake/term"
+
+func SteeringDirection(head, target Point) Direction {
+	dx, dy := target.X-head.X, target.Y-head.Y
+	if dx == 0 && dy == 0 {
+		return DirNone
+	}
+
+	if abs(dx) >= abs(dy) {
+		if dx > 0 {
+			return DirRight
+		}
+		return DirLeft
+	}
+	if dy > 0 {
+		return DirDown
+	}
+	return DirUp
+}
+
+func (g *Game) TurnPlayer(direction Direction) {
+	direction = g.MirrorDirection(direction)
+	if g.Snake.CanTurn(direction) {
+		if direction != g.Snake.Direction {
+			g.RecordReaction(g.Snake.Direction)
+		}
+		g.Snake.Direction = direction
+	}
+}
+
+func (g *Game) HandleSteeringMouse(ev term.Event) {
+	if ev.Key != term.MouseLeft || g.Snake.Body.Len() == 0 {
+		return
+	}
+
+	g.RecordInput()
+	target := g.Camera.ToBoard(ev.MouseX, ev.MouseY)
+	g.TurnPlayer(SteeringDirection(g.Snake.Body.Head(), target))
+}
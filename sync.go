@@ -0,0 +1,426 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SyncTimeout    = 5 * time.Second
+	ProfileVersion = 2
+	syncStateFile  = "sync-state.json"
+	settingsPrefix = "settings."
+)
+
+type ProfileField struct {
+	Data      []byte    `json:"data"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Profile struct {
+	Version int                     `json:"version"`
+	Fields  map[string]ProfileField `json:"fields"`
+}
+
+type SyncResult struct {
+	Pulled []string
+	Pushed []string
+}
+
+type SyncClient struct {
+	URL   string
+	Token string
+	HTTP  *http.Client
+}
+
+func NewSyncClient(settings Settings) SyncClient {
+	return SyncClient{
+		URL:   settings.SyncURL,
+		Token: settings.SyncToken,
+		HTTP:  &http.Client{Timeout: SyncTimeout},
+	}
+}
+
+var localOnlySettings = map[string]bool{
+	"discord_client_id":    true,
+	"gamepad_start_button": true,
+	"mouse_steering":       true,
+	"smooth_render":        true,
+	"scale_cells":          true,
+	"square_cells":         true,
+	"ascii_glyphs":         true,
+	"reduced_effects":      true,
+	"sound_pack":           true,
+	"sample_dir":           true,
+	"lua_mods":             true,
+}
+
+func IsSyncedSetting(key string) bool {
+	return !strings.HasPrefix(key, "sync_") && !localOnlySettings[key]
+}
+
+func IsProfileField(name string) bool {
+	if key, ok := strings.CutPrefix(name, settingsPrefix); ok {
+		return IsSyncedSetting(key)
+	}
+	if filepath.Base(name) != name {
+		return false
+	}
+	switch name {
+	case bestLengthFile, statsFile:
+		return true
+	}
+	return strings.HasPrefix(name, "highscore") && strings.HasSuffix(name, ".txt")
+}
+
+func LocalProfile() (Profile, error) {
+	profile := Profile{Version: ProfileVersion, Fields: map[string]ProfileField{}}
+
+	names, err := filepath.Glob("highscore*.txt")
+	if err != nil {
+		return profile, err
+	}
+	for _, name := range names {
+		if err := profile.addFile(name, func() (any, error) {
+			data, err := highScoreFormat.Read(name)
+			if err != nil {
+				return nil, err
+			}
+			return strconv.Atoi(strings.TrimSpace(string(data)))
+		}); err != nil {
+			return profile, err
+		}
+	}
+	if err := profile.addFile(bestLengthFile, func() (any, error) { return LoadBestLength(), nil }); err != nil {
+		return profile, err
+	}
+	if err := profile.addFile(statsFile, func() (any, error) { return LoadStats(), nil }); err != nil {
+		return profile, err
+	}
+
+	fields, err := localSettingsFields(loadSyncState())
+	for name, field := range fields {
+		profile.Fields[name] = field
+	}
+	return profile, err
+}
+
+func (p Profile) addFile(name string, load func() (any, error)) error {
+	info, err := os.Stat(name)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	value, err := load()
+	if err != nil {
+		logger.Warn("arquivo ignorado na sincronizacao", "arquivo", name, "erro", err)
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	p.Fields[name] = ProfileField{Data: data, UpdatedAt: info.ModTime().UTC()}
+	return nil
+}
+
+func localSettingsFields(state map[string]ProfileField) (map[string]ProfileField, error) {
+	fields := map[string]ProfileField{}
+
+	info, err := os.Stat(settingsFile)
+	if os.IsNotExist(err) {
+		return fields, nil
+	}
+	if err != nil {
+		return fields, err
+	}
+
+	values, err := settingsValues(LoadSettings())
+	if err != nil {
+		return fields, err
+	}
+	for key, value := range values {
+		if !IsSyncedSetting(key) {
+			continue
+		}
+		field := ProfileField{Data: value, UpdatedAt: info.ModTime().UTC()}
+		if base, ok := state[key]; ok && bytes.Equal(base.Data, value) {
+			field.UpdatedAt = base.UpdatedAt
+		}
+		fields[settingsPrefix+key] = field
+	}
+	return fields, nil
+}
+
+func settingsValues(settings Settings) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]json.RawMessage
+	err = json.Unmarshal(data, &values)
+	return values, err
+}
+
+func loadSyncState() map[string]ProfileField {
+	state := map[string]ProfileField{}
+	data, err := syncStateFormat.Read(syncStateFile)
+	if os.IsNotExist(err) {
+		return state
+	}
+	if err == nil {
+		err = json.Unmarshal(data, &state)
+	}
+	if err != nil {
+		SetAsideBroken(syncStateFile, err)
+		return map[string]ProfileField{}
+	}
+	return state
+}
+
+func saveSyncState(profile Profile) error {
+	state := map[string]ProfileField{}
+	for name, field := range profile.Fields {
+		if key, ok := strings.CutPrefix(name, settingsPrefix); ok {
+			state[key] = field
+		}
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return syncStateFormat.Write(syncStateFile, append(data, '\n'))
+}
+
+func remoteWins(name string, local, remote ProfileField) bool {
+	if strings.HasPrefix(name, settingsPrefix) {
+		return !bytes.Equal(local.Data, remote.Data) && remote.UpdatedAt.After(local.UpdatedAt)
+	}
+	if name == statsFile {
+		var localStats, remoteStats Stats
+		if json.Unmarshal(remote.Data, &remoteStats) != nil {
+			return false
+		}
+		json.Unmarshal(local.Data, &localStats)
+		return len(remoteStats.Deaths) > len(localStats.Deaths)
+	}
+
+	var localValue, remoteValue int
+	if json.Unmarshal(remote.Data, &remoteValue) != nil {
+		return false
+	}
+	json.Unmarshal(local.Data, &localValue)
+	return remoteValue > localValue
+}
+
+func MergeProfiles(local, remote Profile) (Profile, SyncResult) {
+	merged := Profile{Version: ProfileVersion, Fields: map[string]ProfileField{}}
+	var result SyncResult
+
+	if remote.Version != ProfileVersion {
+		remote.Fields = map[string]ProfileField{}
+	}
+
+	for name, field := range local.Fields {
+		merged.Fields[name] = field
+	}
+	for name, field := range remote.Fields {
+		if current, ok := merged.Fields[name]; !ok || !IsProfileField(name) || remoteWins(name, current, field) {
+			merged.Fields[name] = field
+		}
+	}
+
+	for name, field := range merged.Fields {
+		if current, ok := local.Fields[name]; IsProfileField(name) && (!ok || !bytes.Equal(current.Data, field.Data)) {
+			result.Pulled = append(result.Pulled, name)
+		}
+		if other, ok := remote.Fields[name]; !ok || !bytes.Equal(other.Data, field.Data) {
+			result.Pushed = append(result.Pushed, name)
+		}
+	}
+	sort.Strings(result.Pulled)
+	sort.Strings(result.Pushed)
+	return merged, result
+}
+
+func ApplyProfile(profile Profile, names []string) error {
+	settings := LoadSettings()
+	values, err := settingsValues(settings)
+	if err != nil {
+		return err
+	}
+	settingsChanged := false
+
+	for _, name := range names {
+		data := profile.Fields[name].Data
+		switch key, isSetting := strings.CutPrefix(name, settingsPrefix); {
+		case isSetting:
+			values[key] = data
+			settingsChanged = true
+		case name == statsFile:
+			var stats Stats
+			if err := json.Unmarshal(data, &stats); err != nil {
+				return fmt.Errorf("sync: %s: %w", name, err)
+			}
+			err = SaveStats(stats)
+		default:
+			var value int
+			if err := json.Unmarshal(data, &value); err != nil {
+				return fmt.Errorf("sync: %s: %w", name, err)
+			}
+			format := highScoreFormat
+			if name == bestLengthFile {
+				format = bestLengthFormat
+			}
+			err = format.Write(name, []byte(strconv.Itoa(value)))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if !settingsChanged {
+		return nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+	return SaveSettings(settings)
+}
+
+func (c SyncClient) request(method string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, c.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.HTTP.Do(req)
+}
+
+func (c SyncClient) Fetch() (Profile, error) {
+	profile := Profile{Fields: map[string]ProfileField{}}
+
+	resp, err := c.request(http.MethodGet, nil)
+	if err != nil {
+		return profile, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return profile, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return profile, fmt.Errorf("sync: GET %s: %s", c.URL, resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return profile, fmt.Errorf("sync: perfil remoto invalido: %w", err)
+	}
+	if profile.Fields == nil {
+		profile.Fields = map[string]ProfileField{}
+	}
+	return profile, nil
+}
+
+func (c SyncClient) Push(profile Profile) error {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.request(http.MethodPut, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sync: PUT %s: %s", c.URL, resp.Status)
+	}
+	return nil
+}
+
+func SyncProfile(settings Settings) (SyncResult, error) {
+	if settings.SyncURL == "" {
+		return SyncResult{}, errors.New("sync_url nao configurado em " + settingsFile)
+	}
+	client := NewSyncClient(settings)
+
+	local, err := LocalProfile()
+	if err != nil {
+		return SyncResult{}, err
+	}
+	remote, err := client.Fetch()
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	merged, result := MergeProfiles(local, remote)
+	if err := ApplyProfile(merged, result.Pulled); err != nil {
+		return result, err
+	}
+	if err := saveSyncState(merged); err != nil {
+		return result, err
+	}
+
+	if len(result.Pushed) > 0 {
+		if err := client.Push(merged); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func AutoSync() {
+	settings := LoadSettings()
+	if settings.SyncURL == "" {
+		return
+	}
+	if result, err := SyncProfile(settings); err != nil {
+		logger.Warn("falha ao sincronizar perfil", "erro", err)
+	} else {
+		logger.Info("perfil sincronizado", "recebidos", len(result.Pulled), "enviados", len(result.Pushed))
+	}
+}
+
+func runSync(args []string) error {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	fs.Parse(args)
+
+	result, err := SyncProfile(LoadSettings())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("recebidos: %d, enviados: %d\n", len(result.Pulled), len(result.Pushed))
+	for _, name := range result.Pulled {
+		fmt.Println("  <", name)
+	}
+	for _, name := range result.Pushed {
+		fmt.Println("  >", name)
+	}
+	return nil
+}
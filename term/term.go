@@ -0,0 +1,78 @@
+//go:build !js
+
+// Package term reune os tipos de celula, cor e evento do termbox usados pelo
+// jogo, para que o motor tambem compile onde nao ha terminal (WebAssembly).
+package term
+
+import "github.com/nsf/termbox-go"
+
+type (
+	Attribute = termbox.Attribute
+	Cell      = termbox.Cell
+	Event     = termbox.Event
+	EventType = termbox.EventType
+	InputMode = termbox.InputMode
+	Key       = termbox.Key
+	Modifier  = termbox.Modifier
+)
+
+const (
+	EventKey    = termbox.EventKey
+	EventResize = termbox.EventResize
+	EventMouse  = termbox.EventMouse
+	EventError  = termbox.EventError
+
+	InputEsc   = termbox.InputEsc
+	InputMouse = termbox.InputMouse
+
+	ModMotion = termbox.ModMotion
+
+	ColorDefault      = termbox.ColorDefault
+	ColorBlack        = termbox.ColorBlack
+	ColorRed          = termbox.ColorRed
+	ColorGreen        = termbox.ColorGreen
+	ColorYellow       = termbox.ColorYellow
+	ColorBlue         = termbox.ColorBlue
+	ColorMagenta      = termbox.ColorMagenta
+	ColorCyan         = termbox.ColorCyan
+	ColorWhite        = termbox.ColorWhite
+	ColorDarkGray     = termbox.ColorDarkGray
+	ColorLightRed     = termbox.ColorLightRed
+	ColorLightGreen   = termbox.ColorLightGreen
+	ColorLightYellow  = termbox.ColorLightYellow
+	ColorLightBlue    = termbox.ColorLightBlue
+	ColorLightMagenta = termbox.ColorLightMagenta
+	ColorLightCyan    = termbox.ColorLightCyan
+	ColorLightGray    = termbox.ColorLightGray
+
+	AttrBold    = termbox.AttrBold
+	AttrDim     = termbox.AttrDim
+	AttrReverse = termbox.AttrReverse
+
+	KeyF1         = termbox.KeyF1
+	KeyF3         = termbox.KeyF3
+	KeyHome       = termbox.KeyHome
+	KeyArrowUp    = termbox.KeyArrowUp
+	KeyArrowDown  = termbox.KeyArrowDown
+	KeyArrowLeft  = termbox.KeyArrowLeft
+	KeyArrowRight = termbox.KeyArrowRight
+	MouseLeft     = termbox.MouseLeft
+	MouseRight    = termbox.MouseRight
+	MouseRelease  = termbox.MouseRelease
+	KeyCtrlC      = termbox.KeyCtrlC
+	KeyBackspace  = termbox.KeyBackspace
+	KeyEnter      = termbox.KeyEnter
+	KeyEsc        = termbox.KeyEsc
+	KeySpace      = termbox.KeySpace
+	KeyBackspace2 = termbox.KeyBackspace2
+)
+
+func Init() error                                 { return termbox.Init() }
+func Close()                                      { termbox.Close() }
+func SetInputMode(mode InputMode)                 { termbox.SetInputMode(mode) }
+func PollEvent() Event                            { return termbox.PollEvent() }
+func SetCell(x, y int, ch rune, fg, bg Attribute) { termbox.SetCell(x, y, ch, fg, bg) }
+func Clear(fg, bg Attribute) error                { return termbox.Clear(fg, bg) }
+func Size() (int, int)                            { return termbox.Size() }
+func CellBuffer() []Cell                          { return termbox.CellBuffer() }
+func Flush() error                                { return termbox.Flush() }
@@ -0,0 +1,131 @@
+//go:build js
+
+package term
+
+import "errors"
+
+var ErrNoTerminal = errors.New("terminal indisponivel no navegador")
+
+type (
+	InputMode int
+	EventType uint8
+	Modifier  uint8
+	Key       uint16
+	Attribute uint64
+)
+
+type Event struct {
+	Type   EventType
+	Mod    Modifier
+	Key    Key
+	Ch     rune
+	Width  int
+	Height int
+	Err    error
+	MouseX int
+	MouseY int
+	N      int
+}
+
+type Cell struct {
+	Ch rune
+	Fg Attribute
+	Bg Attribute
+}
+
+const (
+	InputEsc InputMode = 1 << iota
+	InputAlt
+	InputMouse
+)
+
+const (
+	EventKey EventType = iota
+	EventResize
+	EventMouse
+	EventError
+)
+
+const (
+	ModAlt Modifier = 1 << iota
+	ModMotion
+)
+
+const (
+	ColorDefault Attribute = iota
+	ColorBlack
+	ColorRed
+	ColorGreen
+	ColorYellow
+	ColorBlue
+	ColorMagenta
+	ColorCyan
+	ColorWhite
+	ColorDarkGray
+	ColorLightRed
+	ColorLightGreen
+	ColorLightYellow
+	ColorLightBlue
+	ColorLightMagenta
+	ColorLightCyan
+	ColorLightGray
+)
+
+const (
+	AttrBold Attribute = 1 << (iota + 9)
+	AttrBlink
+	AttrHidden
+	AttrDim
+	AttrUnderline
+	AttrCursive
+	AttrReverse
+)
+
+const (
+	KeyF1 Key = 0xFFFF - iota
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+	KeyInsert
+	KeyDelete
+	KeyHome
+	KeyEnd
+	KeyPgup
+	KeyPgdn
+	KeyArrowUp
+	KeyArrowDown
+	KeyArrowLeft
+	KeyArrowRight
+	keyMin
+	MouseLeft
+	MouseMiddle
+	MouseRight
+	MouseRelease
+)
+
+const (
+	KeyCtrlC      Key = 0x03
+	KeyBackspace  Key = 0x08
+	KeyEnter      Key = 0x0D
+	KeyEsc        Key = 0x1B
+	KeySpace      Key = 0x20
+	KeyBackspace2 Key = 0x7F
+)
+
+func Init() error                                 { return ErrNoTerminal }
+func Close()                                      {}
+func SetInputMode(mode InputMode)                 {}
+func PollEvent() Event                            { return Event{Type: EventError, Err: ErrNoTerminal} }
+func SetCell(x, y int, ch rune, fg, bg Attribute) {}
+func Clear(fg, bg Attribute) error                { return ErrNoTerminal }
+func Size() (int, int)                            { return 0, 0 }
+func CellBuffer() []Cell                          { return nil }
+func Flush() error                                { return ErrNoTerminal }
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+
+	"snake/term"
+)
+
+func BoxLines(lines []string, minWidth int) []string {
+	width := minWidth
+	for _, line := range lines {
+		width = max(width, runewidth.StringWidth(line))
+	}
+
+	box := []string{"╔" + strings.Repeat("═", width+2) + "╗"}
+	for _, line := range lines {
+		box = append(box, "║ "+runewidth.FillRight(line, width)+" ║")
+	}
+	return append(box, "╚"+strings.Repeat("═", width+2)+"╝")
+}
+
+func BoxWidth(box []string) int {
+	if len(box) == 0 {
+		return 0
+	}
+	return runewidth.StringWidth(box[0])
+}
+
+func DrawText(x, y int, text string, fg, bg term.Attribute) int {
+	for _, char := range text {
+		screen.SetCell(x, y, DisplayRune(char), fg, bg)
+		x += runewidth.RuneWidth(char)
+	}
+	return x
+}
+
+func DrawBox(x, y int, box []string, color func(i int) term.Attribute) {
+	for i, line := range box {
+		DrawText(x, y+i, line, color(i), term.ColorDefault)
+	}
+}
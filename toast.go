@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"snake/term"
+)
+
+const (
+	bestLengthFile  = "bestlength.txt"
+	ToastFrames     = 40
+	MaxToasts       = 3
+	LengthMilestone = 25
+)
+
+type Toast struct {
+	Text      string
+	ExpiresAt int
+	Color     term.Attribute
+}
+
+func LoadBestLength() int {
+	data, err := bestLengthFormat.Read(bestLengthFile)
+	if os.IsNotExist(err) {
+		return 0
+	}
+
+	length := 0
+	if err == nil {
+		length, err = strconv.Atoi(strings.TrimSpace(string(data)))
+	}
+	if err != nil {
+		SetAsideBroken(bestLengthFile, err)
+		return 0
+	}
+
+	return length
+}
+
+func SaveBestLength(length int) error {
+	return bestLengthFormat.Write(bestLengthFile, []byte(fmt.Sprintf("%d", length)))
+}
+
+func (g *Game) SubscribeMilestones() {
+	g.Events.Subscribe(EventFoodEaten, func(e Event) {
+		g.CheckLengthMilestones()
+	})
+}
+
+func (g *Game) ShowToast(text string, color term.Attribute) {
+	g.Toasts = append(g.Toasts, Toast{Text: text, ExpiresAt: g.FrameCount + ToastFrames, Color: color})
+	if len(g.Toasts) > MaxToasts {
+		g.Toasts = g.Toasts[len(g.Toasts)-MaxToasts:]
+	}
+}
+
+func (g *Game) ExpireToasts() {
+	live := g.Toasts[:0]
+	for _, toast := range g.Toasts {
+		if toast.ExpiresAt > g.FrameCount {
+			live = append(live, toast)
+		}
+	}
+	g.Toasts = live
+}
+
+func (g *Game) CheckLengthMilestones() {
+	length := g.Snake.Body.Len()
+
+	if length%LengthMilestone == 0 {
+		g.ShowToast(fmt.Sprintf("Tamanho %d!", length), term.ColorGreen)
+	}
+
+	if g.Mode.IsRanked() && !g.BestLengthBeaten && g.BestLength > 0 && length > g.BestLength {
+		g.BestLengthBeaten = true
+		g.ShowToast("Novo recorde de tamanho!", term.ColorYellow)
+	}
+}
+
+func (g *Game) CheckAndSaveBestLength() {
+	length := g.Snake.Body.Len()
+	if g.Mode.IsRanked() && length > g.BestLength {
+		g.BestLength = length
+		SaveBestLength(length)
+	}
+}
+
+func (g *Game) DrawToasts() {
+	for i, toast := range g.Toasts {
+		y := g.Camera.OffsetY + g.Camera.ScreenHeight() - len(g.Toasts) + i - 1
+		DrawText(g.Camera.OffsetX+2, y, " "+toast.Text+" ", term.ColorBlack, toast.Color)
+	}
+}
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"snake/matchpb"
+)
+
+const DefaultTournamentSize = 4
+
+func DailyBoard(now time.Time, mode GameMode) ChallengeCode {
+	now = now.UTC()
+	return ChallengeCode{
+		Mode:   mode,
+		Width:  DefaultWidth,
+		Height: DefaultHeight,
+		Seed:   int64(now.Year()*10000 + int(now.Month())*100 + now.Day()),
+	}
+}
+
+type Entrant struct {
+	Name   string
+	Wins   int
+	Losses int
+	Points int
+	Out    bool
+	Left   bool
+
+	out chan *matchpb.TournamentServerMessage
+}
+
+type BracketMatch struct {
+	ID       int
+	Round    int
+	A, B     *Entrant
+	Score    [2]int
+	Ticks    [2]int
+	Reported [2]bool
+	Winner   *Entrant
+}
+
+type TournamentServer struct {
+	matchpb.UnimplementedTournamentServer
+	Capacity int
+	Board    ChallengeCode
+	Log      io.Writer
+
+	mu       sync.Mutex
+	entrants []*Entrant
+	matches  []*BracketMatch
+	champion *Entrant
+}
+
+func NewTournament(capacity int, board ChallengeCode, log io.Writer) *TournamentServer {
+	return &TournamentServer{Capacity: capacity, Board: board, Log: log}
+}
+
+func (t *TournamentServer) Enter(stream matchpb.Tournament_EnterServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	entry := first.GetEntry()
+	if entry == nil {
+		return status.Error(codes.InvalidArgument, "a conexao deve comecar com Entry")
+	}
+
+	e, err := t.Add(entry.GetName())
+	if err != nil {
+		return err
+	}
+	defer t.Leave(e)
+
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			if result := msg.GetResult(); result != nil {
+				t.Report(e, result)
+			}
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-e.out:
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		case err := <-errs:
+			return err
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (t *TournamentServer) Add(name string) (*Entrant, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.matches) > 0 {
+		return nil, status.Error(codes.FailedPrecondition, "o torneio ja comecou")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "convidado"
+	}
+	unique := name
+	for i := 2; t.entrant(unique) != nil; i++ {
+		unique = fmt.Sprintf("%s (%d)", name, i)
+	}
+
+	e := &Entrant{Name: unique, out: make(chan *matchpb.TournamentServerMessage, netOutboxSize)}
+	t.entrants = append(t.entrants, e)
+	fmt.Fprintf(t.Log, "%s entrou (%d/%d)\n", e.Name, len(t.entrants), t.Capacity)
+	if len(t.entrants) == t.Capacity {
+		t.startRound(1, t.entrants)
+	}
+	t.broadcast()
+	return e, nil
+}
+
+func (t *TournamentServer) Leave(e *Entrant) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.matches) == 0 {
+		t.entrants = slices.DeleteFunc(t.entrants, func(other *Entrant) bool { return other == e })
+		fmt.Fprintf(t.Log, "%s saiu (%d/%d)\n", e.Name, len(t.entrants), t.Capacity)
+		t.broadcast()
+		return
+	}
+	e.Left = true
+	if m := t.pending(e); m != nil {
+		fmt.Fprintf(t.Log, "%s desconectou e perde a partida %d\n", e.Name, m.ID)
+		t.record(m, e, -1, 0)
+	}
+	t.broadcast()
+}
+
+func (t *TournamentServer) Report(e *Entrant, result *matchpb.MatchResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	m := t.pending(e)
+	if m == nil || m.ID != int(result.GetMatch()) {
+		return
+	}
+	fmt.Fprintf(t.Log, "partida %d: %s fez %d pontos\n", m.ID, e.Name, result.GetScore())
+	t.record(m, e, max(0, int(result.GetScore())), int(result.GetTicks()))
+	t.broadcast()
+}
+
+func (t *TournamentServer) entrant(name string) *Entrant {
+	for _, e := range t.entrants {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+func (t *TournamentServer) pending(e *Entrant) *BracketMatch {
+	for _, m := range t.matches {
+		if m.Winner != nil {
+			continue
+		}
+		if m.A == e && !m.Reported[0] || m.B == e && !m.Reported[1] {
+			return m
+		}
+	}
+	return nil
+}
+
+func (t *TournamentServer) startRound(round int, players []*Entrant) {
+	fmt.Fprintf(t.Log, "rodada %d\n", round)
+	var matches []*BracketMatch
+	for i := 0; i < len(players); i += 2 {
+		m := &BracketMatch{ID: len(t.matches) + 1, Round: round, A: players[i]}
+		if i+1 < len(players) {
+			m.B = players[i+1]
+		}
+		t.matches = append(t.matches, m)
+		matches = append(matches, m)
+	}
+
+	for _, m := range matches {
+		if m.B == nil {
+			fmt.Fprintf(t.Log, "  partida %d: %s passa direto\n", m.ID, m.A.Name)
+			m.Winner = m.A
+			continue
+		}
+		fmt.Fprintf(t.Log, "  partida %d: %s x %s\n", m.ID, m.A.Name, m.B.Name)
+		for _, pair := range [][2]*Entrant{{m.A, m.B}, {m.B, m.A}} {
+			pair[0].send(&matchpb.TournamentServerMessage{Message: &matchpb.TournamentServerMessage_Start{
+				Start: &matchpb.MatchStart{Match: int32(m.ID), Opponent: pair[1].Name, Board: t.Board.Encode()},
+			}})
+		}
+	}
+	for _, m := range matches {
+		for _, e := range []*Entrant{m.A, m.B} {
+			if e != nil && e.Left && m.Winner == nil {
+				t.record(m, e, -1, 0)
+			}
+		}
+	}
+	t.advance()
+}
+
+func (t *TournamentServer) record(m *BracketMatch, e *Entrant, score, ticks int) {
+	side := 0
+	if m.B == e {
+		side = 1
+	}
+	m.Score[side], m.Ticks[side], m.Reported[side] = score, ticks, true
+	e.Points += max(0, score)
+	if score < 0 {
+		m.Reported[1-side] = true
+	}
+	if !m.Reported[0] || !m.Reported[1] {
+		return
+	}
+
+	winner, loser := m.A, m.B
+	if m.Score[1] > m.Score[0] || m.Score[1] == m.Score[0] && m.Ticks[1] > m.Ticks[0] {
+		winner, loser = m.B, m.A
+	}
+	m.Winner = winner
+	winner.Wins++
+	loser.Losses++
+	loser.Out = true
+	fmt.Fprintf(t.Log, "  partida %d: %s %d x %d %s, vence %s\n", m.ID, m.A.Name, m.Score[0], m.Score[1], m.B.Name, winner.Name)
+	t.advance()
+}
+
+func (t *TournamentServer) advance() {
+	if len(t.matches) == 0 || t.champion != nil {
+		return
+	}
+	round := t.matches[len(t.matches)-1].Round
+	var winners []*Entrant
+	for _, m := range t.matches {
+		if m.Round != round {
+			continue
+		}
+		if m.Winner == nil {
+			return
+		}
+		winners = append(winners, m.Winner)
+	}
+	if len(winners) == 1 {
+		t.champion = winners[0]
+		fmt.Fprintf(t.Log, "campeao: %s\n", t.champion.Name)
+		return
+	}
+	t.startRound(round+1, winners)
+}
+
+func (t *TournamentServer) Standings() []*Entrant {
+	standings := slices.Clone(t.entrants)
+	slices.SortStableFunc(standings, func(a, b *Entrant) int {
+		switch {
+		case a.Wins != b.Wins:
+			return b.Wins - a.Wins
+		case a.Out != b.Out:
+			if a.Out {
+				return 1
+			}
+			return -1
+		}
+		return b.Points - a.Points
+	})
+	return standings
+}
+
+func (t *TournamentServer) Bracket() *matchpb.Bracket {
+	bracket := &matchpb.Bracket{
+		Board:    t.Board.Encode(),
+		Entrants: int32(len(t.entrants)),
+		Capacity: int32(t.Capacity),
+	}
+	if t.champion != nil {
+		bracket.Champion = t.champion.Name
+	}
+	for _, m := range t.matches {
+		bm := &matchpb.BracketMatch{Id: int32(m.ID), Round: int32(m.Round), A: m.A.Name, ScoreA: int32(m.Score[0]), ScoreB: int32(m.Score[1])}
+		if m.B != nil {
+			bm.B = m.B.Name
+		}
+		if m.Winner != nil {
+			bm.Winner = m.Winner.Name
+		}
+		bracket.Matches = append(bracket.Matches, bm)
+	}
+	for _, e := range t.Standings() {
+		bracket.Standings = append(bracket.Standings, &matchpb.Standing{
+			Name:       e.Name,
+			Wins:       int32(e.Wins),
+			Losses:     int32(e.Losses),
+			Points:     int32(e.Points),
+			Eliminated: e.Out,
+		})
+	}
+	return bracket
+}
+
+func (t *TournamentServer) broadcast() {
+	base := t.Bracket()
+	for _, e := range t.entrants {
+		bracket := &matchpb.Bracket{
+			Matches:   base.Matches,
+			Standings: base.Standings,
+			Board:     base.Board,
+			Entrants:  base.Entrants,
+			Capacity:  base.Capacity,
+			Champion:  base.Champion,
+			You:       e.Name,
+		}
+		e.send(&matchpb.TournamentServerMessage{Message: &matchpb.TournamentServerMessage_Bracket{Bracket: bracket}})
+	}
+}
+
+func (e *Entrant) send(msg *matchpb.TournamentServerMessage) {
+	if e.Left {
+		return
+	}
+	select {
+	case e.out <- msg:
+	default:
+		logger.Warn("inscrito sem ler as mensagens do torneio", "nome", e.Name)
+	}
+}
+
+func runServeTournament(args []string) error {
+	fs := flag.NewFlagSet("serve-tournament", flag.ExitOnError)
+	address := fs.String("addr", DefaultMatchPort, "endereco em que o torneio aceita inscricoes")
+	size := fs.Int("players", DefaultTournamentSize, "quantidade de inscritos; o torneio comeca quando completa")
+	mode := fs.String("mode", "Classico", "modo das partidas")
+	fs.Parse(args)
+
+	if *size < 2 {
+		return fmt.Errorf("o torneio precisa de pelo menos 2 jogadores")
+	}
+	parsed, ok := ParseMode(*mode)
+	if !ok {
+		return fmt.Errorf("modo desconhecido: %s (modos: %s)", *mode, strings.Join(modeNames, ", "))
+	}
+
+	listener, err := net.Listen("tcp", *address)
+	if err != nil {
+		return err
+	}
+	t := NewTournament(*size, DailyBoard(time.Now(), parsed), os.Stdout)
+	server := grpc.NewServer()
+	matchpb.RegisterTournamentServer(server, t)
+
+	fmt.Printf("torneio aberto em %s para %d jogadores, tabuleiro do dia %s (Ctrl+C encerra)\n",
+		listener.Addr(), *size, t.Board.Encode())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		<-signals
+		server.Stop()
+	}()
+	if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"snake/matchpb"
+)
+
+func TestDailyBoard(t *testing.T) {
+	morning := DailyBoard(time.Date(2026, 10, 15, 1, 0, 0, 0, time.UTC), ModeClassic)
+	night := DailyBoard(time.Date(2026, 10, 15, 23, 0, 0, 0, time.UTC), ModeClassic)
+	tomorrow := DailyBoard(time.Date(2026, 10, 16, 1, 0, 0, 0, time.UTC), ModeClassic)
+	if morning != night || morning.Seed == tomorrow.Seed {
+		t.Errorf("tabuleiro do dia: %+v, %+v, amanha %+v", morning, night, tomorrow)
+	}
+	if _, err := DecodeChallenge(morning.Encode()); err != nil {
+		t.Errorf("codigo do tabuleiro do dia invalido: %v", err)
+	}
+}
+
+func matchStarts(e *Entrant) []*matchpb.MatchStart {
+	var starts []*matchpb.MatchStart
+	for {
+		select {
+		case msg := <-e.out:
+			if start := msg.GetStart(); start != nil {
+				starts = append(starts, start)
+			}
+		default:
+			return starts
+		}
+	}
+}
+
+func TestTournamentBracket(t *testing.T) {
+	tournament := NewTournament(3, DailyBoard(time.Now(), ModeClassic), io.Discard)
+	ana, _ := tournament.Add("ana")
+	bia, _ := tournament.Add("bia")
+	if starts := matchStarts(ana); len(starts) != 0 {
+		t.Fatalf("partida comecou antes de completar: %v", starts)
+	}
+	caio, _ := tournament.Add("caio")
+	if _, err := tournament.Add("davi"); err == nil {
+		t.Error("inscricao aceita depois do inicio")
+	}
+
+	starts := matchStarts(ana)
+	if len(starts) != 1 || starts[0].GetOpponent() != "bia" || starts[0].GetBoard() != tournament.Board.Encode() {
+		t.Fatalf("inicio da partida de ana: %v", starts)
+	}
+	if len(matchStarts(caio)) != 0 {
+		t.Error("caio tem folga na primeira rodada")
+	}
+
+	tournament.Report(ana, &matchpb.MatchResult{Match: 1, Score: 50, Ticks: 300})
+	tournament.Report(bia, &matchpb.MatchResult{Match: 1, Score: 50, Ticks: 200})
+	starts = matchStarts(caio)
+	if len(starts) != 1 || starts[0].GetOpponent() != "ana" {
+		t.Fatalf("empate deveria ir para quem sobreviveu mais: %v", starts)
+	}
+
+	tournament.Leave(ana)
+	bracket := tournament.Bracket()
+	if bracket.GetChampion() != "caio" {
+		t.Fatalf("campeao: %q", bracket.GetChampion())
+	}
+	final := bracket.GetMatches()[len(bracket.GetMatches())-1]
+	if final.GetRound() != 2 || final.GetScoreA() != -1 || final.GetWinner() != "caio" {
+		t.Errorf("final com desistencia: %v", final)
+	}
+	var names []string
+	for _, s := range bracket.GetStandings() {
+		names = append(names, s.GetName())
+	}
+	if len(names) != 3 || names[0] != "caio" || names[1] != "ana" || names[2] != "bia" {
+		t.Errorf("classificacao: %v", names)
+	}
+}
+
+func TestTournamentUniqueNames(t *testing.T) {
+	tournament := NewTournament(4, DailyBoard(time.Now(), ModeClassic), io.Discard)
+	first, _ := tournament.Add("ana")
+	second, _ := tournament.Add(" ana ")
+	if first.Name != "ana" || second.Name != "ana (2)" {
+		t.Errorf("nomes repetidos: %q, %q", first.Name, second.Name)
+	}
+	tournament.Leave(first)
+	if bracket := tournament.Bracket(); bracket.GetEntrants() != 1 {
+		t.Errorf("saida antes do inicio nao liberou a vaga: %d inscritos", bracket.GetEntrants())
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"snake/matchpb"
+	"snake/term"
+)
+
+const bracketColumnWidth = 26
+
+type TournamentView struct {
+	Name    string
+	Bracket *matchpb.Bracket
+	Match   *matchpb.MatchStart
+	Status  string
+}
+
+func matchScore(score int32) string {
+	if score < 0 {
+		return "W.O."
+	}
+	return fmt.Sprint(score)
+}
+
+func (v *TournamentView) Draw() {
+	screen.Clear(term.ColorDefault, term.ColorDefault)
+	width, height := screen.Size()
+	b := v.Bracket
+
+	title := "TORNEIO"
+	if b != nil {
+		title = fmt.Sprintf("TORNEIO - tabuleiro do dia %s - %d/%d inscritos", b.GetBoard(), b.GetEntrants(), b.GetCapacity())
+	}
+	DrawText(2, 1, title, term.ColorYellow|term.AttrBold, term.ColorDefault)
+
+	y := 3
+	if b != nil {
+		rows := 0
+		for _, m := range b.GetMatches() {
+			x := 2 + int(m.GetRound()-1)*bracketColumnWidth
+			row := 0
+			for _, other := range b.GetMatches() {
+				if other.GetRound() == m.GetRound() && other.GetId() < m.GetId() {
+					row++
+				}
+			}
+			if row == 0 {
+				DrawText(x, y, fmt.Sprintf("Rodada %d", m.GetRound()), term.ColorCyan|term.AttrBold, term.ColorDefault)
+			}
+			line := y + 1 + row*3
+			for i, side := range []struct {
+				name  string
+				score int32
+			}{{m.GetA(), m.GetScoreA()}, {m.GetB(), m.GetScoreB()}} {
+				color := term.ColorWhite
+				text := side.name
+				switch {
+				case side.name == "":
+					text, color = "(folga)", term.ColorBlack|term.AttrBold
+				case m.GetWinner() == side.name:
+					color = term.ColorGreen | term.AttrBold
+				case m.GetWinner() != "":
+					color = term.ColorRed
+				}
+				if m.GetWinner() != "" && side.name != "" && m.GetB() != "" {
+					text = fmt.Sprintf("%-16s %5s", text, matchScore(side.score))
+				}
+				if side.name == v.Name {
+					text = "> " + text
+				} else {
+					text = "  " + text
+				}
+				DrawText(x, line+i, text, color, term.ColorDefault)
+			}
+			rows = max(rows, row+1)
+		}
+		y += 2 + rows*3
+
+		DrawText(2, y, "CLASSIFICACAO      V  D  PONTOS", term.ColorCyan|term.AttrBold, term.ColorDefault)
+		for i, s := range b.GetStandings() {
+			color := term.ColorWhite
+			if s.GetEliminated() {
+				color = term.ColorBlack | term.AttrBold
+			}
+			if s.GetName() == v.Name {
+				color |= term.AttrBold
+			}
+			DrawText(2, y+1+i, fmt.Sprintf("%d. %-15s %2d %2d %7d", i+1, s.GetName(), s.GetWins(), s.GetLosses(), s.GetPoints()), color, term.ColorDefault)
+		}
+	}
+
+	status := v.Status
+	if b != nil && b.GetChampion() != "" {
+		status = "Campeao: " + b.GetChampion() + "!  [Esc] sai"
+	}
+	DrawText(2, height-2, status, term.ColorYellow, term.ColorDefault)
+	DrawText(2, height-1, strings.Repeat(" ", max(0, width-2)), term.ColorDefault, term.ColorDefault)
+	screen.Flush()
+}
+
+func JoinTournament(address, name string) error {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	stream, err := matchpb.NewTournamentClient(conn).Enter(context.Background())
+	if err != nil {
+		return err
+	}
+	entry := &matchpb.Entry{Name: name}
+	if err := stream.Send(&matchpb.TournamentClientMessage{Message: &matchpb.TournamentClientMessage_Entry{Entry: entry}}); err != nil {
+		return err
+	}
+
+	if err := term.Init(); err != nil {
+		return err
+	}
+	defer term.Close()
+	term.SetInputMode(term.InputEsc)
+
+	opts := Options{Width: DefaultWidth, Height: DefaultHeight, Theme: "normal", Lives: 1}
+	game, closeSession, err := NewSession(opts)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+	game.Recovery = nil
+
+	messages := make(chan *matchpb.TournamentServerMessage, 16)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			messages <- msg
+		}
+	}()
+
+	inputs := make(chan term.Event, 16)
+	go func() {
+		for {
+			inputs <- term.PollEvent()
+		}
+	}()
+
+	view := &TournamentView{Name: name, Status: "Aguardando os outros jogadores...  [Esc] sai"}
+	view.Draw()
+
+	report := func() error {
+		result := &matchpb.MatchResult{Match: view.Match.GetMatch(), Score: int32(game.Score), Ticks: int32(game.PlayTicks)}
+		view.Match = nil
+		view.Status = fmt.Sprintf("Voce fez %d pontos. Aguardando o resultado...  [Esc] sai", game.Score)
+		return stream.Send(&matchpb.TournamentClientMessage{Message: &matchpb.TournamentClientMessage_Result{Result: result}})
+	}
+
+	ticker := time.NewTicker(game.TickInterval())
+	defer func() { ticker.Stop() }()
+	interval := game.TickInterval()
+	for {
+		select {
+		case ev := <-inputs:
+			if view.Match != nil {
+				if ev.Type == term.EventKey && game.HandleInput(ev) {
+					return report()
+				}
+				continue
+			}
+			if ev.Type == term.EventKey && (ev.Key == term.KeyEsc || ev.Key == term.KeyCtrlC) {
+				return nil
+			}
+			if ev.Type == term.EventResize {
+				view.Draw()
+			}
+		case msg := <-messages:
+			switch m := msg.GetMessage().(type) {
+			case *matchpb.TournamentServerMessage_Bracket:
+				view.Bracket = m.Bracket
+				view.Name = m.Bracket.GetYou()
+				if view.Match == nil {
+					view.Draw()
+				}
+			case *matchpb.TournamentServerMessage_Start:
+				code, err := DecodeChallenge(m.Start.GetBoard())
+				if err != nil {
+					return err
+				}
+				view.Match = m.Start
+				game.StartChallenge(code)
+				game.ShowToast("Partida contra "+m.Start.GetOpponent(), term.ColorCyan)
+			}
+		case err := <-errs:
+			if errors.Is(err, io.EOF) || status.Code(err) == codes.Unavailable {
+				return errors.New("o servidor encerrou o torneio")
+			}
+			return fmt.Errorf("torneio em %s: %s", address, status.Convert(err).Message())
+		case <-game.Steps:
+			if view.Match != nil {
+				game.Step()
+			}
+		case <-ticker.C:
+			if view.Match == nil {
+				continue
+			}
+			game.Tick()
+			if game.State == StateGameOver {
+				if err := report(); err != nil {
+					return err
+				}
+				view.Draw()
+			}
+		}
+		if game.TickInterval() != interval {
+			interval = game.TickInterval()
+			ticker.Reset(interval)
+		}
+	}
+}
+
+func runTournament(args []string) error {
+	fs := flag.NewFlagSet("tournament", flag.ExitOnError)
+	name := fs.String("name", "", "nome mostrado na chave")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("uso: snake tournament [-name N] endereco[%s]", DefaultMatchPort)
+	}
+	return JoinTournament(MatchAddress(fs.Arg(0)), *name)
+}
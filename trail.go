@@ -0,0 +1,55 @@
+package main
+
+import "snake/term"
+
+const TrailLength = 3
+
+var trailGlyphs = []rune{'▓', '▒', '░'}
+
+type Trail struct {
+	History []Point
+}
+
+func (g *Game) TrailEnabled() bool {
+	return g.Settings.SnakeTrail && !g.ReducedEffects()
+}
+
+func (g *Game) UpdateTrail() {
+	body := &g.Snake.Body
+	if body.Len() == 0 {
+		g.Trail = Trail{}
+		return
+	}
+
+	history := g.Trail.History
+	if len(history) > 0 && history[0] == body.Head() {
+		return
+	}
+
+	history = append([]Point{body.Head()}, history...)
+	if len(history) < body.Len() || history[body.Len()-1] != body.Tail() {
+		history = nil
+		for _, p := range body.All() {
+			history = append(history, p)
+		}
+	}
+	g.Trail.History = history[:min(len(history), body.Len()+TrailLength)]
+}
+
+func TrailShades(base term.Attribute) []term.Attribute {
+	return []term.Attribute{base, base | term.AttrDim, base | term.AttrDim}
+}
+
+func (g *Game) DrawTrail(setCell CellSetter) {
+	if !g.TrailEnabled() || g.Snake.Body.Len() == 0 || g.Modifiers.Has(ModInvisibleTail) {
+		return
+	}
+
+	shades := TrailShades(g.Environment.TrailColor())
+	for i, p := range g.Trail.History[min(len(g.Trail.History), g.Snake.Body.Len()):] {
+		if g.Occupancy.HasSnake(p) || g.IsDeadly(p) || p == g.Food.Position {
+			continue
+		}
+		setCell(p.X, p.Y, trailGlyphs[i], shades[i], term.ColorDefault)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+
+	"snake/term"
+)
+
+type TutorialStep int
+
+const (
+	TutorialTurn TutorialStep = iota
+	TutorialEat
+	TutorialPowerUp
+	TutorialObstacle
+	TutorialDone
+)
+
+const (
+	TutorialTurns     = 3
+	TutorialDoneTicks = 30
+)
+
+var tutorialPrompts = map[TutorialStep]string{
+	TutorialTurn:     "Use as setas para virar a cobra",
+	TutorialEat:      "Coma a comida ◆ para crescer",
+	TutorialPowerUp:  "Pegue o power-up ★, que vale muito mais",
+	TutorialObstacle: "Desvie dos obstaculos ▓ e pegue a comida",
+	TutorialDone:     "Tutorial concluido! Boa sorte!",
+}
+
+type Tutorial struct {
+	Step      TutorialStep
+	Turns     int
+	LastMoved Direction
+	DoneTicks int
+}
+
+func (g *Game) SubscribeTutorial() {
+	g.Events.Subscribe(EventFoodEaten, func(e Event) {
+		if g.Mode == ModeTutorial {
+			g.AdvanceTutorial(e.Food)
+		}
+	})
+}
+
+func (g *Game) StartTutorial() {
+	g.Tutorial = Tutorial{}
+}
+
+func (g *Game) TutorialFoodType() FoodType {
+	if g.Tutorial.Step == TutorialPowerUp {
+		return PowerUpFood
+	}
+	return NormalFood
+}
+
+func (g *Game) AdvanceTutorial(food FoodType) {
+	switch {
+	case g.Tutorial.Step == TutorialEat && food == NormalFood:
+		g.Tutorial.Step = TutorialPowerUp
+	case g.Tutorial.Step == TutorialPowerUp && food == PowerUpFood:
+		g.Tutorial.Step = TutorialObstacle
+		g.PlaceTutorialObstacles()
+	case g.Tutorial.Step == TutorialObstacle && food == NormalFood:
+		g.Tutorial.Step = TutorialDone
+		g.Tutorial.DoneTicks = TutorialDoneTicks
+		g.Settings.TutorialDone = true
+		if g.Persists() {
+			SaveSettings(g.Settings)
+		}
+	}
+}
+
+func (g *Game) PlaceTutorialObstacles() {
+	x := g.Width / 2
+	for y := g.Height / 4; y <= g.Height*3/4; y++ {
+		pos := Point{X: x, Y: y}
+		if !g.IsPositionSafe(pos) || g.IsAheadOfPlayers(pos) {
+			continue
+		}
+		g.Obstacles = append(g.Obstacles, Obstacle{Position: pos, Type: WallObstacle})
+		g.Occupancy.SetObstacle(pos, len(g.Obstacles)-1)
+	}
+}
+
+func (g *Game) TickTutorial() {
+	switch g.Tutorial.Step {
+	case TutorialTurn:
+		if g.Snake.Moved != g.Tutorial.LastMoved && g.Tutorial.LastMoved != DirNone {
+			g.Tutorial.Turns++
+		}
+		g.Tutorial.LastMoved = g.Snake.Moved
+
+		if g.Tutorial.Turns >= TutorialTurns {
+			g.Tutorial.Step = TutorialEat
+			g.GenerateFood()
+		}
+	case TutorialDone:
+		g.Tutorial.DoneTicks--
+		if g.Tutorial.DoneTicks <= 0 {
+			g.SelectMode(ModeClassic)
+			g.State = StateMenu
+		}
+	}
+}
+
+func (g *Game) RetryTutorial(position Point) {
+	g.TriggerShake(4, 4)
+	g.Emit(Event{Type: EventDeath, Position: position})
+
+	g.Occupancy.RemoveBody(LayerPlayer, &g.Snake.Body)
+	g.Snake = g.NewPlayerSnake()
+	g.Occupancy.AddBody(LayerPlayer, &g.Snake.Body)
+	g.Tutorial.LastMoved = DirNone
+}
+
+func (g *Game) DrawTutorial() {
+	if g.Mode != ModeTutorial {
+		return
+	}
+
+	prompt := tutorialPrompts[g.Tutorial.Step]
+	if g.Tutorial.Step == TutorialTurn {
+		prompt += fmt.Sprintf(" (%d/%d)", g.Tutorial.Turns, TutorialTurns)
+	}
+	prompt = " " + prompt + " "
+
+	centerX, _ := g.Camera.Center()
+	startX := max(0, centerX-len([]rune(prompt))/2)
+	DrawText(startX, g.Camera.OffsetY, prompt, term.ColorBlack, term.ColorYellow)
+}
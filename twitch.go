@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+)
+
+const twitchServer = "irc.chat.twitch.tv:6667"
+
+var twitchCommands = map[string]Direction{
+	"up": DirUp, "cima": DirUp, "w": DirUp,
+	"down": DirDown, "baixo": DirDown, "s": DirDown,
+	"left": DirLeft, "esquerda": DirLeft, "a": DirLeft,
+	"right": DirRight, "direita": DirRight, "d": DirRight,
+}
+
+type TwitchController struct {
+	Channel string
+
+	mu    sync.Mutex
+	votes map[string]Direction
+	conn  net.Conn
+}
+
+func ConnectTwitch(channel string) (*TwitchController, error) {
+	channel = strings.ToLower(strings.TrimPrefix(channel, "#"))
+
+	conn, err := net.Dial("tcp", twitchServer)
+	if err != nil {
+		return nil, err
+	}
+
+	nick := fmt.Sprintf("justinfan%d", rand.Intn(100000))
+	if _, err := fmt.Fprintf(conn, "NICK %s\r\nJOIN #%s\r\n", nick, channel); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	t := &TwitchController{
+		Channel: channel,
+		votes:   map[string]Direction{},
+		conn:    conn,
+	}
+	go t.readLoop()
+	return t, nil
+}
+
+func (t *TwitchController) readLoop() {
+	scanner := bufio.NewScanner(t.conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, "PING") {
+			fmt.Fprintf(t.conn, "PONG%s\r\n", strings.TrimPrefix(line, "PING"))
+			continue
+		}
+
+		prefix, message, ok := strings.Cut(line, " PRIVMSG #"+t.Channel+" :")
+		if !ok {
+			continue
+		}
+		user, _, _ := strings.Cut(strings.TrimPrefix(prefix, ":"), "!")
+
+		if direction, ok := twitchCommands[strings.ToLower(strings.TrimSpace(message))]; ok {
+			t.mu.Lock()
+			t.votes[user] = direction
+			t.mu.Unlock()
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		logger.Error("conexao com o chat da twitch encerrada", "erro", err)
+	}
+}
+
+func (t *TwitchController) Direction(g *Game, s *Snake) Direction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	counts := map[Direction]int{}
+	for _, direction := range t.votes {
+		counts[direction]++
+	}
+
+	best := s.Direction
+	bestVotes := 0
+	for _, direction := range Directions {
+		if !s.CanTurn(direction) {
+			continue
+		}
+		if counts[direction] > bestVotes {
+			best = direction
+			bestVotes = counts[direction]
+		}
+	}
+
+	clear(t.votes)
+	return best
+}
+
+func (t *TwitchController) Close() error {
+	return t.conn.Close()
+}
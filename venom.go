@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+
+	"snake/term"
+)
+
+const (
+	VenomPerPowerUp = 3
+	MaxVenom        = 9
+	VenomSpeed      = 2
+	VenomTrail      = 3
+)
+
+var venomModes = map[GameMode]bool{ModeDebris: true, ModeRisk: true}
+
+type Projectile struct {
+	Position  Point
+	Direction Direction
+	Trail     []Point
+	Range     int
+}
+
+func (g *Game) VenomMode() bool {
+	return venomModes[g.Mode]
+}
+
+func (g *Game) SubscribeVenom() {
+	g.Events.Subscribe(EventPowerUpActivated, func(e Event) {
+		if e.Food == PowerUpFood && g.VenomMode() {
+			g.Venom = min(MaxVenom, g.Venom+VenomPerPowerUp)
+		}
+	})
+}
+
+func (g *Game) FireVenom() {
+	if !g.VenomMode() || g.Venom == 0 || g.Paused() || g.Snake.Body.Len() == 0 {
+		return
+	}
+
+	g.Venom--
+	g.Projectiles = append(g.Projectiles, Projectile{
+		Position:  g.Snake.Body.Head(),
+		Direction: g.Snake.Direction,
+		Range:     max(g.Width, g.Height),
+	})
+	soundVenom()
+}
+
+func (g *Game) UpdateProjectiles() {
+	active := g.Projectiles[:0]
+	for _, p := range g.Projectiles {
+		if g.advanceProjectile(&p) {
+			active = append(active, p)
+		}
+	}
+	g.Projectiles = active
+}
+
+func (g *Game) advanceProjectile(p *Projectile) bool {
+	for step := 0; step < VenomSpeed; step++ {
+		if p.Range == 0 {
+			return false
+		}
+		p.Range--
+
+		p.Trail = append(p.Trail, p.Position)
+		if len(p.Trail) > VenomTrail {
+			p.Trail = p.Trail[1:]
+		}
+
+		next := g.WrapWalls(p.Position.Move(p.Direction))
+		if g.CheckWallCollision(next) {
+			return false
+		}
+		p.Position = next
+
+		if g.ObstacleAt(next) != nil {
+			g.DestroyObstacle(next)
+			return false
+		}
+	}
+	return true
+}
+
+func (g *Game) DestroyObstacle(p Point) {
+	i := g.Occupancy.ObstacleIndex(p)
+	if i < 0 {
+		return
+	}
+
+	g.Obstacles = append(g.Obstacles[:i], g.Obstacles[i+1:]...)
+	g.IndexObstacles()
+
+	for j, debris := range g.Debris {
+		if debris == p {
+			g.Debris = append(g.Debris[:j], g.Debris[j+1:]...)
+			return
+		}
+	}
+}
+
+func (g *Game) VenomHUD() string {
+	return fmt.Sprintf("| Veneno: %d ", g.Venom)
+}
+
+func (g *Game) DrawProjectiles(setCell CellSetter) {
+	for _, p := range g.Projectiles {
+		for _, t := range p.Trail {
+			setCell(t.X, t.Y, '·', term.ColorGreen, term.ColorDefault)
+		}
+		setCell(p.Position.X, p.Position.Y, '•', term.ColorGreen|term.AttrBold, term.ColorDefault)
+	}
+}
+
+func soundVenom() {
+	PlayEffect("venom")
+}
@@ -0,0 +1,236 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image/color"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"syscall/js"
+
+	"snake/term"
+)
+
+const (
+	webCellWidth  = 10
+	webCellHeight = 20
+	webFont       = "16px monospace"
+	webCanvasID   = "snake"
+)
+
+var webKeys = map[string]term.Key{
+	"ArrowUp":    term.KeyArrowUp,
+	"ArrowDown":  term.KeyArrowDown,
+	"ArrowLeft":  term.KeyArrowLeft,
+	"ArrowRight": term.KeyArrowRight,
+	"Enter":      term.KeyEnter,
+	"Escape":     term.KeyEsc,
+	" ":          term.KeySpace,
+	"Backspace":  term.KeyBackspace2,
+	"Home":       term.KeyHome,
+	"F1":         term.KeyF1,
+	"F3":         term.KeyF3,
+}
+
+type Canvas struct {
+	Events chan term.Event
+
+	element js.Value
+	ctx     js.Value
+	cells   *CellBuffer
+	painted []term.Cell
+	mouse   [2]int
+}
+
+func NewCanvas(element js.Value) *Canvas {
+	c := &Canvas{
+		Events:  make(chan term.Event, 64),
+		element: element,
+		ctx:     element.Call("getContext", "2d"),
+	}
+	c.fit()
+	c.listen()
+	return c
+}
+
+func (c *Canvas) fit() {
+	window := js.Global().Get("window")
+	columns := max(1, window.Get("innerWidth").Int()/webCellWidth)
+	rows := max(1, window.Get("innerHeight").Int()/webCellHeight)
+	c.element.Set("width", columns*webCellWidth)
+	c.element.Set("height", rows*webCellHeight)
+	c.ctx.Set("font", webFont)
+	c.ctx.Set("textBaseline", "top")
+	c.cells = NewCellBuffer(columns, rows)
+	c.painted = nil
+}
+
+func (c *Canvas) send(ev term.Event) {
+	select {
+	case c.Events <- ev:
+	default:
+	}
+}
+
+func (c *Canvas) listen() {
+	window := js.Global().Get("window")
+	window.Call("addEventListener", "resize", js.FuncOf(func(this js.Value, args []js.Value) any {
+		c.fit()
+		c.send(term.Event{Type: term.EventResize, Width: c.cells.Width, Height: c.cells.Height})
+		return nil
+	}))
+	window.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) any {
+		e := args[0]
+		key := e.Get("key").String()
+		if e.Get("ctrlKey").Bool() {
+			if key == "c" || key == "C" {
+				c.send(term.Event{Type: term.EventKey, Key: term.KeyCtrlC})
+			}
+			return nil
+		}
+		if code, ok := webKeys[key]; ok {
+			c.send(term.Event{Type: term.EventKey, Key: code})
+		} else if runes := []rune(key); len(runes) == 1 {
+			c.send(term.Event{Type: term.EventKey, Ch: runes[0]})
+		} else {
+			return nil
+		}
+		e.Call("preventDefault")
+		return nil
+	}))
+
+	mouse := func(e js.Value, key term.Key, mod term.Modifier) {
+		x := e.Get("offsetX").Int() / webCellWidth
+		y := e.Get("offsetY").Int() / webCellHeight
+		if mod == term.ModMotion && c.mouse == [2]int{x, y} {
+			return
+		}
+		c.mouse = [2]int{x, y}
+		c.send(term.Event{Type: term.EventMouse, Key: key, Mod: mod, MouseX: x, MouseY: y})
+	}
+	c.element.Call("addEventListener", "mousedown", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if args[0].Get("button").Int() == 2 {
+			mouse(args[0], term.MouseRight, 0)
+		} else {
+			mouse(args[0], term.MouseLeft, 0)
+		}
+		return nil
+	}))
+	c.element.Call("addEventListener", "mouseup", js.FuncOf(func(this js.Value, args []js.Value) any {
+		mouse(args[0], term.MouseRelease, 0)
+		return nil
+	}))
+	c.element.Call("addEventListener", "mousemove", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if args[0].Get("buttons").Int()&1 != 0 {
+			mouse(args[0], term.MouseLeft, term.ModMotion)
+		}
+		return nil
+	}))
+	c.element.Call("addEventListener", "contextmenu", js.FuncOf(func(this js.Value, args []js.Value) any {
+		args[0].Call("preventDefault")
+		return nil
+	}))
+}
+
+func cssColor(c color.RGBA) string {
+	return fmt.Sprintf("rgb(%d,%d,%d)", c.R, c.G, c.B)
+}
+
+func (c *Canvas) SetCell(x, y int, ch rune, fg, bg term.Attribute) { c.cells.SetCell(x, y, ch, fg, bg) }
+
+func (c *Canvas) Clear(fg, bg term.Attribute) error { return c.cells.Clear(fg, bg) }
+
+func (c *Canvas) Size() (int, int) { return c.cells.Size() }
+
+func (c *Canvas) CellBuffer() []term.Cell { return c.cells.Cells }
+
+func (c *Canvas) Flush() error {
+	for i, cell := range c.cells.Cells {
+		if i < len(c.painted) && c.painted[i] == cell {
+			continue
+		}
+		x, y := i%c.cells.Width*webCellWidth, i/c.cells.Width*webCellHeight
+		fg, bg := CellColors(cell)
+		c.ctx.Set("fillStyle", cssColor(bg))
+		c.ctx.Call("fillRect", x, y, webCellWidth, webCellHeight)
+		if cell.Ch != ' ' && cell.Ch != 0 {
+			c.ctx.Set("fillStyle", cssColor(fg))
+			c.ctx.Call("fillText", string(cell.Ch), x, y+2)
+		}
+	}
+	c.painted = slices.Clone(c.cells.Cells)
+	return nil
+}
+
+func FetchCast(url string) (*Cast, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCast(url, data)
+}
+
+func runWeb(args []string) error {
+	fs := flag.NewFlagSet("web", flag.ContinueOnError)
+	board := boardFlags(fs)
+	mode := fs.String("mode", "", "comeca direto neste modo (ex: Classico, Batalha, Neblina)")
+	demo := fs.String("demo", "", "modo demonstracao: a IA escolhida joga sozinha ("+strings.Join(BotNames(), ", ")+")")
+	replay := fs.String("replay", "", "reproduz uma gravacao .cast baixada deste endereco")
+	speed := fs.Float64("speed", 1, "velocidade da reproducao")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	element := js.Global().Get("document").Call("getElementById", webCanvasID)
+	if element.IsNull() {
+		return fmt.Errorf("pagina sem o canvas #%s", webCanvasID)
+	}
+	canvas := NewCanvas(element)
+	screen = canvas
+
+	if *replay != "" {
+		if *speed <= 0 {
+			return fmt.Errorf("velocidade invalida: %v", *speed)
+		}
+		cast, err := FetchCast(*replay)
+		if err != nil {
+			return err
+		}
+		player := &ReplayPlayer{Cast: cast, Speed: *speed}
+		player.Run(canvas.Events)
+		return nil
+	}
+
+	opts, err := board()
+	if err != nil {
+		return err
+	}
+	if *mode != "" {
+		parsed, ok := ParseMode(*mode)
+		if !ok {
+			return fmt.Errorf("modo desconhecido: %s (modos: %s)", *mode, strings.Join(modeNames, ", "))
+		}
+		opts.Mode = parsed
+		opts.StartPlaying = true
+	}
+	opts.Demo = *demo
+
+	game, closeSession, err := NewSession(opts)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+	return game.Loop(canvas.Events, nil, nil)
+}
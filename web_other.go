@@ -0,0 +1,9 @@
+//go:build !(js && wasm)
+
+package main
+
+import "errors"
+
+func runWeb(args []string) error {
+	return errors.New("versao web indisponivel: compile com GOOS=js GOARCH=wasm e abra web/index.html")
+}
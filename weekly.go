@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+type WeeklyChallenge struct {
+	Year      int
+	Week      int
+	Modifiers Modifiers
+	Seed      int64
+	Ends      time.Time
+}
+
+func CurrentChallenge(now time.Time) WeeklyChallenge {
+	now = now.UTC()
+	year, week := now.ISOWeek()
+	seed := int64(year*100 + week)
+
+	all := int(ModDayNight) - 1
+	mods := Modifiers(rand.New(rand.NewSource(seed)).Intn(all) + 1)
+
+	daysSinceMonday := (int(now.Weekday()) + 6) % 7
+	start := time.Date(now.Year(), now.Month(), now.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+
+	return WeeklyChallenge{
+		Year:      year,
+		Week:      week,
+		Modifiers: mods,
+		Seed:      seed,
+		Ends:      start.AddDate(0, 0, 7),
+	}
+}
+
+func (c WeeklyChallenge) ID() string {
+	return fmt.Sprintf("%d-W%02d", c.Year, c.Week)
+}
+
+func (c WeeklyChallenge) Countdown(now time.Time) string {
+	left := max(0, c.Ends.Sub(now))
+	days := int(left.Hours()) / 24
+	hours := int(left.Hours()) % 24
+	minutes := int(left.Minutes()) % 60
+	return fmt.Sprintf("%dd %02dh %02dm", days, hours, minutes)
+}
+
+func (g *Game) SelectMode(mode GameMode) {
+	if g.Mode == ModeWeekly {
+		g.Modifiers = 0
+	}
+	g.Mode = mode
+	g.Puzzle = Puzzle{}
+	if g.Mode == ModeWeekly {
+		g.Modifiers = CurrentChallenge(time.Now()).Modifiers
+	}
+	g.HighScore = LoadHighScore(g.Mode, g.Modifiers)
+	g.Challenge = nil
+}
+
+func (g *Game) RunSeed() int64 {
+	if g.Challenge != nil {
+		return g.Challenge.Seed
+	}
+	if g.Headless {
+		return g.Seed
+	}
+	if g.Mode == ModeWeekly {
+		return CurrentChallenge(time.Now()).Seed
+	}
+	return time.Now().UnixNano()
+}
+
+func (g *Game) WeeklyMenuLine() string {
+	challenge := CurrentChallenge(time.Now())
+	return fmt.Sprintf("SEMANAL %s: troca em %s", challenge.ID(), challenge.Countdown(time.Now()))
+}
+
+func (g *Game) ChallengeID() string {
+	if g.Mode != ModeWeekly {
+		return ""
+	}
+	return CurrentChallenge(time.Now()).ID()
+}
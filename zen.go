@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	ZenAmbientNotes = 16
+	ZenAmbientStep  = 1600 * time.Millisecond
+)
+
+var zenScale = []float64{220, 261.63, 293.66, 329.63, 392, 440}
+
+func AmbientTrack(seed int64) Track {
+	r := rand.New(rand.NewSource(seed))
+	track := Track{Name: "Ambiente zen", Length: ZenAmbientNotes * ZenAmbientStep}
+	for i := 0; i < ZenAmbientNotes; i++ {
+		track.Notes = append(track.Notes, MusicNote{
+			At: time.Duration(i) * ZenAmbientStep,
+			Note: Note{
+				Freq:      zenScale[r.Intn(len(zenScale))],
+				Ms:        2400,
+				Waveform:  "sine",
+				AttackMs:  600,
+				ReleaseMs: 1200,
+				Volume:    0.12,
+			},
+		})
+	}
+	return track
+}
+
+func (g *Game) ZenCrossings() map[Point]bool {
+	if g.Mode != ModeZen {
+		return nil
+	}
+	seen := map[Point]bool{}
+	crossings := map[Point]bool{}
+	for _, p := range g.Snake.Body.All() {
+		if seen[p] {
+			crossings[p] = true
+		}
+		seen[p] = true
+	}
+	return crossings
+}
+
+func (g *Game) LeaveZen() {
+	g.Music.Stop()
+	g.State = StateMenu
+}
+
+func (g *Game) ZenHUD() string {
+	return fmt.Sprintf(" Zen | Tamanho: %d | ESC volta ao menu ", g.Snake.Body.Len())
+}